@@ -15,6 +15,7 @@ import (
 	"k8s.io/kubectl/pkg/util/i18n"
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
@@ -47,12 +48,24 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
 	cmd.Flags().DurationVar(&r.reconcileTimeout, "reconcile-timeout", time.Duration(0),
 		"Timeout threshold for waiting for all resources to reach the Current status.")
+	cmd.Flags().StringToStringVar(&r.reconcileTimeouts, "reconcile-timeouts", nil,
+		flagutils.GroupKindTimeoutsUsage("reconcile-timeout"))
+	cmd.Flags().DurationVar(&r.stabilityWindow, "stability-window", time.Duration(0),
+		"Minimum duration resources must remain Current before they are considered reconciled.")
 	cmd.Flags().BoolVar(&r.noPrune, "no-prune", r.noPrune,
 		"If true, do not prune previously applied objects.")
+	cmd.Flags().BoolVar(&r.inferReferences, "infer-references", false,
+		"If true, order resources by the other resources they reference, like ConfigMaps used by Deployments.")
+	cmd.Flags().BoolVar(&r.allowExternalDependencies, "allow-external-dependencies", false,
+		"If true, allow depends-on annotations to reference resources outside the package, and wait for them to reach the Current status.")
+	cmd.Flags().DurationVar(&r.externalDependencyTimeout, "external-dependency-timeout", time.Duration(0),
+		"Timeout threshold for waiting for external dependencies to reach the Current status. Defaults to the reconcile timeout.")
 	cmd.Flags().StringVar(&r.prunePropagationPolicy, "prune-propagation-policy",
 		"Background", "Propagation policy for pruning")
 	cmd.Flags().DurationVar(&r.pruneTimeout, "prune-timeout", time.Duration(0),
 		"Timeout threshold for waiting for all pruned resources to be deleted")
+	cmd.Flags().StringToStringVar(&r.pruneTimeouts, "prune-timeouts", nil,
+		flagutils.GroupKindTimeoutsUsage("prune-timeout"))
 	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
 			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
@@ -60,6 +73,31 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		"How long to wait before exiting")
 	cmd.Flags().BoolVar(&r.printStatusEvents, "status-events", false,
 		"Print status events (always enabled for table output)")
+	cmd.Flags().BoolVar(&r.parallelPhases, "parallel-phases", false,
+		"If true, apply and prune independent sets of resources concurrently.")
+	cmd.Flags().BoolVar(&r.rollbackOnFailure, "rollback-on-failure", false,
+		"If true, restore the previous state of the applied resources if any of them fails to reconcile.")
+	cmd.Flags().IntVar(&r.applyConcurrency, "apply-concurrency", 1,
+		"Maximum number of resources to apply concurrently within each apply phase.")
+	cmd.Flags().IntVar(&r.pruneConcurrency, "prune-concurrency", 1,
+		"Maximum number of resources to prune concurrently within each prune phase.")
+	cmd.Flags().BoolVar(&r.skipUnchanged, "skip-unchanged", false,
+		"If true, skip resources that have not changed since they were last applied. Requires --inventory-status.")
+	cmd.Flags().IntVar(&r.retryPolicy.MaxAttempts, "retry-max-attempts", 1,
+		"Maximum number of attempts to apply or delete a resource when the server returns a transient error.")
+	cmd.Flags().DurationVar(&r.retryPolicy.InitialBackoff, "retry-initial-backoff", retry.DefaultInitialBackoff,
+		"Delay before the first retry. The delay doubles with each retry.")
+	cmd.Flags().DurationVar(&r.retryPolicy.MaxBackoff, "retry-max-backoff", retry.DefaultMaxBackoff,
+		"Maximum delay between retries.")
+	cmd.Flags().StringSliceVar(&r.retryErrors, "retry-errors", nil, flagutils.RetryErrorsUsage())
+	cmd.Flags().BoolVar(&r.failFast, "fail-fast", false,
+		"If true, skip the remaining resources after any resource fails to apply, prune, or reconcile.")
+	cmd.Flags().BoolVar(&r.lockInventory, "lock-inventory", false,
+		"If true, hold a lease on the inventory for the duration of the run, to prevent concurrent runs.")
+	cmd.Flags().DurationVar(&r.lockOptions.WaitTimeout, "lock-wait-timeout", time.Duration(0),
+		"How long to wait for an inventory lease held by someone else to be released.")
+	cmd.Flags().DurationVar(&r.lockOptions.StealTimeout, "lock-steal-timeout", inventory.DefaultLockStealTimeout,
+		"How long an inventory lease can go without being renewed before it is taken over.")
 
 	r.Command = cmd
 	return r
@@ -77,15 +115,31 @@ type Runner struct {
 	invFactory inventory.ClientFactory
 	loader     manifestreader.ManifestLoader
 
-	serverSideOptions      common.ServerSideOptions
-	output                 string
-	reconcileTimeout       time.Duration
-	noPrune                bool
-	prunePropagationPolicy string
-	pruneTimeout           time.Duration
-	inventoryPolicy        string
-	timeout                time.Duration
-	printStatusEvents      bool
+	serverSideOptions         common.ServerSideOptions
+	output                    string
+	reconcileTimeout          time.Duration
+	reconcileTimeouts         map[string]string
+	stabilityWindow           time.Duration
+	noPrune                   bool
+	inferReferences           bool
+	allowExternalDependencies bool
+	externalDependencyTimeout time.Duration
+	prunePropagationPolicy    string
+	pruneTimeout              time.Duration
+	pruneTimeouts             map[string]string
+	inventoryPolicy           string
+	timeout                   time.Duration
+	printStatusEvents         bool
+	parallelPhases            bool
+	rollbackOnFailure         bool
+	skipUnchanged             bool
+	applyConcurrency          int
+	pruneConcurrency          int
+	lockInventory             bool
+	lockOptions               inventory.LockOptions
+	retryPolicy               retry.Policy
+	retryErrors               []string
+	failFast                  bool
 }
 
 func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
@@ -106,6 +160,25 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	r.retryPolicy.Errors, err = flagutils.ConvertRetryErrors(r.retryErrors)
+	if err != nil {
+		return err
+	}
+
+	reconcileTimeouts, err := flagutils.ConvertGroupKindTimeouts(r.reconcileTimeouts)
+	if err != nil {
+		return err
+	}
+	pruneTimeouts, err := flagutils.ConvertGroupKindTimeouts(r.pruneTimeouts)
+	if err != nil {
+		return err
+	}
+
+	errorPolicy := apply.ContinueOnError
+	if r.failFast {
+		errorPolicy = apply.FailFast
+	}
+
 	if found := printers.ValidatePrinterType(r.output); !found {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
@@ -157,14 +230,29 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	ch := a.Run(ctx, inv, objs, apply.ApplierOptions{
 		ServerSideOptions: r.serverSideOptions,
 		ReconcileTimeout:  r.reconcileTimeout,
+		ReconcileTimeouts: reconcileTimeouts,
+		StabilityWindow:   r.stabilityWindow,
 		// If we are not waiting for status, tell the applier to not
 		// emit the events.
-		EmitStatusEvents:       r.printStatusEvents,
-		NoPrune:                r.noPrune,
-		DryRunStrategy:         common.DryRunNone,
-		PrunePropagationPolicy: prunePropPolicy,
-		PruneTimeout:           r.pruneTimeout,
-		InventoryPolicy:        inventoryPolicy,
+		EmitStatusEvents:          r.printStatusEvents,
+		NoPrune:                   r.noPrune,
+		InferReferences:           r.inferReferences,
+		AllowExternalDependencies: r.allowExternalDependencies,
+		ExternalDependencyTimeout: r.externalDependencyTimeout,
+		DryRunStrategy:            common.DryRunNone,
+		PrunePropagationPolicy:    prunePropPolicy,
+		PruneTimeout:              r.pruneTimeout,
+		PruneTimeouts:             pruneTimeouts,
+		InventoryPolicy:           inventoryPolicy,
+		ParallelPhases:            r.parallelPhases,
+		RollbackOnFailure:         r.rollbackOnFailure,
+		SkipUnchanged:             r.skipUnchanged,
+		ApplyConcurrency:          r.applyConcurrency,
+		PruneConcurrency:          r.pruneConcurrency,
+		LockInventory:             r.lockInventory,
+		ErrorPolicy:               errorPolicy,
+		RetryPolicy:               r.retryPolicy,
+		LockOptions:               r.lockOptions,
 	})
 
 	// The printer will print updates from the channel. It will block
@@ -15,6 +15,7 @@ import (
 	"k8s.io/kubectl/pkg/util/i18n"
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
@@ -44,12 +45,37 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
 	cmd.Flags().DurationVar(&r.deleteTimeout, "delete-timeout", time.Duration(0),
 		"Timeout threshold for waiting for all deleted resources to complete deletion")
+	cmd.Flags().StringToStringVar(&r.deleteTimeouts, "delete-timeouts", nil,
+		flagutils.GroupKindTimeoutsUsage("delete-timeout"))
 	cmd.Flags().StringVar(&r.deletePropagationPolicy, "delete-propagation-policy",
 		"Background", "Propagation policy for deletion")
 	cmd.Flags().DurationVar(&r.timeout, "timeout", 0,
 		"How long to wait before exiting")
 	cmd.Flags().BoolVar(&r.printStatusEvents, "status-events", false,
 		"Print status events (always enabled for table output)")
+	cmd.Flags().BoolVar(&r.parallelPhases, "parallel-phases", false,
+		"If true, delete independent sets of resources concurrently.")
+	cmd.Flags().BoolVar(&r.inferReferences, "infer-references", false,
+		"If true, order resources by the other resources they reference, like ConfigMaps used by Deployments.")
+	cmd.Flags().BoolVar(&r.allowExternalDependencies, "allow-external-dependencies", false,
+		"If true, allow depends-on annotations to reference resources outside the inventory.")
+	cmd.Flags().IntVar(&r.deleteConcurrency, "delete-concurrency", 1,
+		"Maximum number of resources to delete concurrently within each delete phase.")
+	cmd.Flags().IntVar(&r.retryPolicy.MaxAttempts, "retry-max-attempts", 1,
+		"Maximum number of attempts to delete a resource when the server returns a transient error.")
+	cmd.Flags().DurationVar(&r.retryPolicy.InitialBackoff, "retry-initial-backoff", retry.DefaultInitialBackoff,
+		"Delay before the first retry. The delay doubles with each retry.")
+	cmd.Flags().DurationVar(&r.retryPolicy.MaxBackoff, "retry-max-backoff", retry.DefaultMaxBackoff,
+		"Maximum delay between retries.")
+	cmd.Flags().StringSliceVar(&r.retryErrors, "retry-errors", nil, flagutils.RetryErrorsUsage())
+	cmd.Flags().BoolVar(&r.failFast, "fail-fast", false,
+		"If true, skip the remaining resources after any resource fails to be deleted or times out.")
+	cmd.Flags().BoolVar(&r.lockInventory, "lock-inventory", false,
+		"If true, hold a lease on the inventory for the duration of the run, to prevent concurrent runs.")
+	cmd.Flags().DurationVar(&r.lockOptions.WaitTimeout, "lock-wait-timeout", time.Duration(0),
+		"How long to wait for an inventory lease held by someone else to be released.")
+	cmd.Flags().DurationVar(&r.lockOptions.StealTimeout, "lock-steal-timeout", inventory.DefaultLockStealTimeout,
+		"How long an inventory lease can go without being renewed before it is taken over.")
 
 	r.Command = cmd
 	return r
@@ -69,12 +95,22 @@ type Runner struct {
 	invFactory inventory.ClientFactory
 	loader     manifestreader.ManifestLoader
 
-	output                  string
-	deleteTimeout           time.Duration
-	deletePropagationPolicy string
-	inventoryPolicy         string
-	timeout                 time.Duration
-	printStatusEvents       bool
+	output                    string
+	deleteTimeout             time.Duration
+	deleteTimeouts            map[string]string
+	deletePropagationPolicy   string
+	inventoryPolicy           string
+	timeout                   time.Duration
+	printStatusEvents         bool
+	parallelPhases            bool
+	deleteConcurrency         int
+	inferReferences           bool
+	allowExternalDependencies bool
+	lockInventory             bool
+	lockOptions               inventory.LockOptions
+	retryPolicy               retry.Policy
+	retryErrors               []string
+	failFast                  bool
 }
 
 func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
@@ -95,6 +131,21 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	r.retryPolicy.Errors, err = flagutils.ConvertRetryErrors(r.retryErrors)
+	if err != nil {
+		return err
+	}
+
+	deleteTimeouts, err := flagutils.ConvertGroupKindTimeouts(r.deleteTimeouts)
+	if err != nil {
+		return err
+	}
+
+	errorPolicy := apply.ContinueOnError
+	if r.failFast {
+		errorPolicy = apply.FailFast
+	}
+
 	if found := printers.ValidatePrinterType(r.output); !found {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
@@ -108,7 +159,7 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	invObj, _, err := inventory.SplitUnstructureds(objs)
+	invObj, objs, err := inventory.SplitUnstructureds(objs)
 	if err != nil {
 		return err
 	}
@@ -137,10 +188,20 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	// Run the destroyer. It will return a channel where we can receive updates
 	// to keep track of progress and any issues.
 	ch := d.Run(ctx, inv, apply.DestroyerOptions{
-		DeleteTimeout:           r.deleteTimeout,
-		DeletePropagationPolicy: deletePropPolicy,
-		InventoryPolicy:         inventoryPolicy,
-		EmitStatusEvents:        r.printStatusEvents,
+		DeleteTimeout:             r.deleteTimeout,
+		DeleteTimeouts:            deleteTimeouts,
+		DeletePropagationPolicy:   deletePropPolicy,
+		InventoryPolicy:           inventoryPolicy,
+		EmitStatusEvents:          r.printStatusEvents,
+		ParallelPhases:            r.parallelPhases,
+		DeleteConcurrency:         r.deleteConcurrency,
+		InferReferences:           r.inferReferences,
+		AllowExternalDependencies: r.allowExternalDependencies,
+		LockInventory:             r.lockInventory,
+		ErrorPolicy:               errorPolicy,
+		RetryPolicy:               r.retryPolicy,
+		LockOptions:               r.lockOptions,
+		Hooks:                     objs,
 	})
 
 	// The printer will print updates from the channel. It will block
@@ -5,8 +5,11 @@ package flagutils
 
 import (
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 )
 
@@ -18,6 +21,7 @@ const (
 	StatusPolicyFlag          = "status-policy"
 	StatusPolicyAll           = "all"
 	StatusPolicyNone          = "none"
+	InventoryTypeFlag         = "inventory-type"
 )
 
 // ConvertPropagationPolicy converts a propagationPolicy described as a
@@ -50,6 +54,61 @@ func ConvertInventoryPolicy(policy string) (inventory.Policy, error) {
 	}
 }
 
+// ConvertRetryErrors converts the names of retry error classes to the
+// ErrorClass type that is passed into the retry policy.
+func ConvertRetryErrors(names []string) ([]retry.ErrorClass, error) {
+	classes := make([]retry.ErrorClass, 0, len(names))
+	for _, name := range names {
+		class, err := retry.ParseErrorClass(name)
+		if err != nil {
+			return nil, err
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
+
+// RetryErrorsUsage returns the usage string of the retry errors flag.
+func RetryErrorsUsage() string {
+	return fmt.Sprintf("Classes of transient errors to retry, any of %v. Defaults to all.", retry.ErrorClasses())
+}
+
+// ConvertGroupKindTimeouts converts timeouts keyed by GroupKind, formatted as
+// "Kind.group" (e.g. "StatefulSet.apps" or "ConfigMap"), to the map that is
+// passed into the Applier and Destroyer.
+func ConvertGroupKindTimeouts(timeouts map[string]string) (map[schema.GroupKind]time.Duration, error) {
+	if len(timeouts) == 0 {
+		return nil, nil
+	}
+	result := make(map[schema.GroupKind]time.Duration, len(timeouts))
+	for gkStr, timeoutStr := range timeouts {
+		gk := schema.ParseGroupKind(gkStr)
+		if gk.Kind == "" {
+			return nil, fmt.Errorf("invalid timeout %q: kind is required", gkStr)
+		}
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for %q: %w", gkStr, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("invalid timeout for %q: duration must not be negative", gkStr)
+		}
+		result[gk] = timeout
+	}
+	return result, nil
+}
+
+// GroupKindTimeoutsUsage returns the usage string of the flags that
+// override a timeout by GroupKind.
+func GroupKindTimeoutsUsage(timeoutFlag string) string {
+	return fmt.Sprintf("Overrides --%s for resources of the given kinds, e.g. StatefulSet.apps=20m,ConfigMap=30s.", timeoutFlag)
+}
+
+// InventoryTypeUsage returns the usage string of the inventory type flag.
+func InventoryTypeUsage() string {
+	return fmt.Sprintf("Type of the inventory object, must be one of %v", inventory.StorageTypes())
+}
+
 // PathFromArgs returns the path which is a positional arg from args list
 // returns "-" if there is length of args is 0, which implies no path is provided
 func PathFromArgs(args []string) string {
@@ -5,8 +5,11 @@ package flagutils
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 )
 
@@ -50,3 +53,30 @@ func TestConvertInventoryPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertGroupKindTimeouts(t *testing.T) {
+	timeouts, err := ConvertGroupKindTimeouts(map[string]string{
+		"StatefulSet.apps": "20m",
+		"ConfigMap":        "30s",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := map[schema.GroupKind]time.Duration{
+		{Group: "apps", Kind: "StatefulSet"}: 20 * time.Minute,
+		{Kind: "ConfigMap"}:                  30 * time.Second,
+	}
+	if !reflect.DeepEqual(expected, timeouts) {
+		t.Errorf("expected %v but got %v", expected, timeouts)
+	}
+
+	for _, invalid := range []map[string]string{
+		{"ConfigMap": "30"},
+		{"ConfigMap": "-30s"},
+		{".apps": "30s"},
+	} {
+		if _, err := ConvertGroupKindTimeouts(invalid); err == nil {
+			t.Errorf("expected an error for %v, but not happened", invalid)
+		}
+	}
+}
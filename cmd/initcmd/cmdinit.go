@@ -8,7 +8,9 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/pkg/config"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
 )
 
 // InitRunner encapsulates the structures for the init command.
@@ -22,9 +24,9 @@ type InitRunner struct {
 func GetInitRunner(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *InitRunner {
 	io := config.NewInitOptions(f, ioStreams)
 	cmd := &cobra.Command{
-		Use:                   "init DIRECTORY",
+		Use:                   "init (DIRECTORY | --print-crd)",
 		DisableFlagsInUseLine: true,
-		Short:                 i18n.T("Create a prune manifest ConfigMap as a inventory object"),
+		Short:                 i18n.T("Create a prune manifest inventory object"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			err := io.Complete(args)
 			if err != nil {
@@ -34,6 +36,10 @@ func GetInitRunner(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *Ini
 		},
 	}
 	cmd.Flags().StringVarP(&io.InventoryID, "inventory-id", "i", "", "Identifier for group of applied resources. Must be composed of valid label characters.")
+	cmd.Flags().StringVar((*string)(&io.InventoryType), flagutils.InventoryTypeFlag, string(inventory.ConfigMapStorage),
+		flagutils.InventoryTypeUsage())
+	cmd.Flags().BoolVar(&io.PrintCRD, "print-crd", false,
+		"Print the ResourceGroup CRD manifest, which must be installed in the cluster to use the resourcegroup inventory type, instead of creating an inventory object template.")
 	i := &InitRunner{
 		Command:     cmd,
 		InitOptions: io,
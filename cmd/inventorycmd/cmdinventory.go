@@ -0,0 +1,218 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventorycmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cliprinters "k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/ownership"
+)
+
+// NewCmdInventory returns the inventory command group, with the list, show,
+// and orphans subcommands.
+func NewCmdInventory(factory cmdutil.Factory, invFactory inventory.ClientFactory,
+	ioStreams genericiooptions.IOStreams) *cobra.Command {
+	r := &Runner{
+		ioStreams:  ioStreams,
+		factory:    factory,
+		invFactory: invFactory,
+	}
+	cmd := &cobra.Command{
+		Use:                   "inventory",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Inspect the inventories in the cluster"),
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:                   "list",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("List the inventories in the cluster"),
+		Args:                  cobra.NoArgs,
+		RunE:                  r.runList,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:                   "show (NAME | INVENTORY_ID)",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Show the objects and recorded statuses of an inventory"),
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  r.runShow,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:                   "orphans",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("List objects owned by an inventory that does not list them"),
+		Args:                  cobra.NoArgs,
+		RunE:                  r.runOrphans,
+	})
+	return cmd
+}
+
+// Runner encapsulates data necessary to run the inventory commands.
+type Runner struct {
+	ioStreams  genericiooptions.IOStreams
+	factory    cmdutil.Factory
+	invFactory inventory.ClientFactory
+}
+
+// listInventories returns all the inventories in the cluster, sorted by
+// namespace and name.
+func (r *Runner) listInventories(cmd *cobra.Command) ([]inventory.Inventory, error) {
+	invClient, err := r.invFactory.NewClient(r.factory)
+	if err != nil {
+		return nil, err
+	}
+	invs, err := invClient.List(cmd.Context(), inventory.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(invs, func(i, j int) bool {
+		if invs[i].Info().GetNamespace() != invs[j].Info().GetNamespace() {
+			return invs[i].Info().GetNamespace() < invs[j].Info().GetNamespace()
+		}
+		return inventoryName(invs[i]) < inventoryName(invs[j])
+	})
+	return invs, nil
+}
+
+func (r *Runner) runList(cmd *cobra.Command, _ []string) error {
+	invs, err := r.listInventories(cmd)
+	if err != nil {
+		return err
+	}
+	w := cliprinters.GetNewTabWriter(r.ioStreams.Out)
+	defer w.Flush()
+	printRow(w, "NAMESPACE", "NAME", "INVENTORY-ID", "OBJECTS")
+	for _, inv := range invs {
+		printRow(w, inv.Info().GetNamespace(), inventoryName(inv),
+			inv.Info().GetID().String(), fmt.Sprint(len(inv.GetObjectRefs())))
+	}
+	return nil
+}
+
+func (r *Runner) runShow(cmd *cobra.Command, args []string) error {
+	namespace, _, err := r.factory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	invs, err := r.listInventories(cmd)
+	if err != nil {
+		return err
+	}
+	// Prefer the inventory ID, which is unique across namespaces.
+	var inv inventory.Inventory
+	for _, i := range invs {
+		if i.Info().GetID().String() == args[0] {
+			inv = i
+			break
+		}
+		if inv == nil && i.Info().GetNamespace() == namespace && inventoryName(i) == args[0] {
+			inv = i
+		}
+	}
+	if inv == nil {
+		return fmt.Errorf("inventory %q not found", args[0])
+	}
+
+	statuses := make(map[object.ObjMetadata]actuation.ObjectStatus, len(inv.GetObjectStatuses()))
+	for _, status := range inv.GetObjectStatuses() {
+		statuses[inventory.ObjMetadataFromObjectReference(status.ObjectReference)] = status
+	}
+	w := cliprinters.GetNewTabWriter(r.ioStreams.Out)
+	defer w.Flush()
+	printRow(w, "NAMESPACE", "RESOURCE", "STRATEGY", "ACTUATION", "RECONCILE", "UID", "GENERATION")
+	for _, id := range inv.GetObjectRefs() {
+		status, found := statuses[id]
+		if !found {
+			printRow(w, id.Namespace, resourceIDToString(id), "-", "-", "-", "-", "-")
+			continue
+		}
+		printRow(w, id.Namespace, resourceIDToString(id), status.Strategy.String(),
+			status.Actuation.String(), status.Reconcile.String(), string(status.UID),
+			fmt.Sprint(status.Generation))
+	}
+	return nil
+}
+
+func (r *Runner) runOrphans(cmd *cobra.Command, _ []string) error {
+	invs, err := r.listInventories(cmd)
+	if err != nil {
+		return err
+	}
+	dc, err := r.factory.ToDiscoveryClient()
+	if err != nil {
+		return err
+	}
+	resources, err := ownership.ListableResources(dc)
+	if err != nil {
+		return err
+	}
+	ownershipClient, err := ownership.NewClient(r.factory)
+	if err != nil {
+		return err
+	}
+	orphans, err := ownershipClient.FindOrphans(cmd.Context(), invs, resources)
+	if err != nil {
+		return err
+	}
+	w := cliprinters.GetNewTabWriter(r.ioStreams.Out)
+	defer w.Flush()
+	printRow(w, "NAMESPACE", "RESOURCE", "OWNING-INVENTORY", "REASON")
+	notFound := false
+	for _, orphan := range orphans {
+		reason := "inventory not found"
+		if orphan.InventoryFound {
+			reason = "not in inventory"
+		} else {
+			notFound = true
+		}
+		printRow(w, orphan.Identifier.Namespace, resourceIDToString(orphan.Identifier),
+			orphan.Owner, reason)
+	}
+	if notFound {
+		// Only one inventory storage type is loaded, so owners stored as
+		// another type look like they don't exist.
+		_, _ = fmt.Fprintf(r.ioStreams.ErrOut, "Note: only the inventories of the configured --%s were loaded, "+
+			"so objects owned by inventories of another type are reported as inventory not found.\n",
+			flagutils.InventoryTypeFlag)
+	}
+	return nil
+}
+
+// inventoryName returns the name of the inventory object, if known.
+func inventoryName(inv inventory.Inventory) string {
+	if soi, ok := inv.Info().(*inventory.SingleObjectInfo); ok {
+		return soi.GetName()
+	}
+	return ""
+}
+
+func printRow(w io.Writer, columns ...string) {
+	for i, column := range columns {
+		columns[i] = valueOrDash(column)
+	}
+	_, _ = fmt.Fprintln(w, strings.Join(columns, "\t"))
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// resourceIDToString returns the string representation of an object.
+func resourceIDToString(id object.ObjMetadata) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(id.GroupKind.String()), id.Name)
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventorycmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic/fake"
+	restfake "k8s.io/client-go/rest/fake"
+	clienttesting "k8s.io/client-go/testing"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+func newConfigMap(name string, labels, annotations, data map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetName(name)
+	u.SetNamespace("test-namespace")
+	u.SetLabels(labels)
+	u.SetAnnotations(annotations)
+	if data != nil {
+		dataMap := make(map[string]any, len(data))
+		for k, v := range data {
+			dataMap[k] = v
+		}
+		u.Object["data"] = dataMap
+	}
+	return u
+}
+
+func TestInventoryCommands(t *testing.T) {
+	// The inventory lists cm-a, which it owns.
+	inventoryObj := newConfigMap("inventory-name",
+		map[string]string{common.InventoryLabel: "inventory-id"},
+		nil,
+		map[string]string{
+			"test-namespace_cm-a__ConfigMap": `{"strategy":"Apply","actuation":"Succeeded","reconcile":"Succeeded"}`,
+		})
+	cmA := newConfigMap("cm-a", nil,
+		map[string]string{inventory.OwningInventoryKey: "inventory-id"}, nil)
+	// cm-b is owned by the inventory, but not listed in it.
+	cmB := newConfigMap("cm-b", nil,
+		map[string]string{inventory.OwningInventoryKey: "inventory-id"}, nil)
+	// cm-c is owned by an inventory that was not found.
+	cmC := newConfigMap("cm-c", nil,
+		map[string]string{inventory.OwningInventoryKey: "other-id"}, nil)
+	// Other ConfigMaps that are not inventories must be ignored, even if
+	// their data cannot be parsed as an inventory.
+	rootCA := newConfigMap("kube-root-ca.crt", nil, nil,
+		map[string]string{"ca.crt": "-----BEGIN CERTIFICATE-----"})
+
+	tests := map[string]struct {
+		args           []string
+		expectedOutput [][]string
+		expectedErrOut string
+	}{
+		"list": {
+			args: []string{"list"},
+			expectedOutput: [][]string{
+				{"NAMESPACE", "NAME", "INVENTORY-ID", "OBJECTS"},
+				{"test-namespace", "inventory-name", "inventory-id", "1"},
+			},
+		},
+		"show by name": {
+			args: []string{"show", "inventory-name"},
+			expectedOutput: [][]string{
+				{"NAMESPACE", "RESOURCE", "STRATEGY", "ACTUATION", "RECONCILE", "UID", "GENERATION"},
+				{"test-namespace", "configmap/cm-a", "Apply", "Succeeded", "Succeeded", "-", "0"},
+			},
+		},
+		"show by inventory id": {
+			args: []string{"show", "inventory-id"},
+			expectedOutput: [][]string{
+				{"NAMESPACE", "RESOURCE", "STRATEGY", "ACTUATION", "RECONCILE", "UID", "GENERATION"},
+				{"test-namespace", "configmap/cm-a", "Apply", "Succeeded", "Succeeded", "-", "0"},
+			},
+		},
+		"orphans": {
+			args: []string{"orphans"},
+			expectedOutput: [][]string{
+				{"NAMESPACE", "RESOURCE", "OWNING-INVENTORY", "REASON"},
+				{"test-namespace", "configmap/cm-b", "inventory-id", "not", "in", "inventory"},
+				{"test-namespace", "configmap/cm-c", "other-id", "inventory", "not", "found"},
+			},
+			expectedErrOut: "Note: only the inventories of the configured --inventory-type were loaded, " +
+				"so objects owned by inventories of another type are reported as inventory not found.\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeDiscovery := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+				Resources: []*metav1.APIResourceList{
+					{
+						GroupVersion: "v1",
+						APIResources: []metav1.APIResource{
+							{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: metav1.Verbs{"list"}},
+						},
+					},
+				},
+			}}
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-namespace").
+				WithDiscoveryClient(memory.NewMemCacheClient(fakeDiscovery))
+			defer tf.Cleanup()
+			clusterObjs := []*unstructured.Unstructured{inventoryObj, cmA, cmB, cmC, rootCA}
+			var runtimeObjs []runtime.Object
+			for _, obj := range clusterObjs {
+				runtimeObjs = append(runtimeObjs, obj)
+			}
+			tf.FakeDynamicClient = fake.NewSimpleDynamicClient(scheme.Scheme, runtimeObjs...)
+			// The orphans command lists the object metadata over HTTP.
+			tf.ClientConfigVal.Transport = restfake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path != "/api/v1/configmaps" {
+					return nil, fmt.Errorf("unexpected request: %s", req.URL)
+				}
+				list := &metav1.PartialObjectMetadataList{
+					TypeMeta: metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "PartialObjectMetadataList"},
+				}
+				for _, obj := range clusterObjs {
+					list.Items = append(list.Items, metav1.PartialObjectMetadata{
+						TypeMeta: metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "PartialObjectMetadata"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        obj.GetName(),
+							Namespace:   obj.GetNamespace(),
+							Labels:      obj.GetLabels(),
+							Annotations: obj.GetAnnotations(),
+						},
+					})
+				}
+				body, err := json.Marshal(list)
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     cmdtesting.DefaultHeader(),
+					Body:       io.NopCloser(bytes.NewReader(body)),
+				}, nil
+			}).Transport
+
+			ioStreams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			cmd := NewCmdInventory(tf, inventory.ConfigMapClientFactory{StatusEnabled: true}, ioStreams)
+			cmd.SetArgs(tc.args)
+			require.NoError(t, cmd.ExecuteContext(t.Context()))
+
+			var actual [][]string
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				actual = append(actual, strings.Fields(line))
+			}
+			assert.Equal(t, tc.expectedOutput, actual)
+			assert.Equal(t, tc.expectedErrOut, errOut.String())
+		})
+	}
+}
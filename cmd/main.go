@@ -25,8 +25,13 @@ import (
 	"sigs.k8s.io/cli-utils/cmd/apply"
 	"sigs.k8s.io/cli-utils/cmd/destroy"
 	"sigs.k8s.io/cli-utils/cmd/diff"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/cmd/initcmd"
+	"sigs.k8s.io/cli-utils/cmd/inventorycmd"
+	"sigs.k8s.io/cli-utils/cmd/migrate"
+	"sigs.k8s.io/cli-utils/cmd/ownershipcmd"
 	"sigs.k8s.io/cli-utils/cmd/preview"
+	"sigs.k8s.io/cli-utils/cmd/rollback"
 	"sigs.k8s.io/cli-utils/cmd/status"
 	"sigs.k8s.io/cli-utils/pkg/flowcontrol"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
@@ -63,19 +68,40 @@ func main() {
 	}
 
 	loader := manifestreader.NewManifestLoader(f)
-	invFactory := inventory.ConfigMapClientFactory{StatusEnabled: false}
+	invFactory := &inventory.TypedClientFactory{Type: inventory.ConfigMapStorage, StatusEnabled: false}
+	flags.StringVar((*string)(&invFactory.Type), flagutils.InventoryTypeFlag, string(inventory.ConfigMapStorage),
+		flagutils.InventoryTypeUsage())
+	flags.BoolVar(&invFactory.StatusEnabled, "inventory-status", false,
+		"If true, persist the actuation and reconcile status of each object in the inventory.")
+	flags.IntVar(&invFactory.RevisionHistoryLimit, "revision-history-limit", 0,
+		"Number of applied revisions to keep in the inventory for rollback. Zero disables revision history.")
+	flags.IntVar(&invFactory.MaxObjectsPerShard, "inventory-shard-size", 0,
+		"Maximum number of objects to store in each inventory object, before splitting the inventory across multiple objects. Zero disables sharding.")
+	flags.BoolVar(&invFactory.CompactEncoding, "inventory-compact", false,
+		"If true, store the inventory contents as a single compressed value. Inventories in either encoding can be read.")
+	flags.StringVar(&invFactory.ApplySetTooling, "applyset-tooling", "",
+		"Value of the ApplySet tooling annotation, as <name>/<version>. Required by the applyset inventory type. kubectl only manages ApplySets with the kubectl tooling name.")
 
-	names := []string{"init", "apply", "destroy", "diff", "preview", "status"}
+	names := []string{"init", "abandon", "adopt", "apply", "destroy", "diff", "inventory", "migrate-inventory", "preview", "rollback", "status"}
 	subCmds := []*cobra.Command{
 		initcmd.NewCmdInit(f, ioStreams),
+		ownershipcmd.NewCmdAbandon(f, invFactory, loader, ioStreams),
+		ownershipcmd.NewCmdAdopt(f, invFactory, loader, ioStreams),
 		apply.Command(f, invFactory, loader, ioStreams),
 		destroy.Command(f, invFactory, loader, ioStreams),
 		diff.NewCommand(f, ioStreams),
+		inventorycmd.NewCmdInventory(f, invFactory, ioStreams),
+		migrate.Command(f, invFactory, loader, ioStreams),
 		preview.Command(f, invFactory, loader, ioStreams),
+		rollback.Command(f, invFactory, loader, ioStreams),
 		status.Command(cmd.Context(), f, invFactory, status.NewInventoryLoader(loader)),
 	}
 	for _, subCmd := range subCmds {
 		subCmd.PreRunE = preRunE
+		// Command groups, like inventory, run the PreRunE of the subcommand.
+		for _, c := range subCmd.Commands() {
+			c.PreRunE = preRunE
+		}
 		updateHelp(names, subCmd)
 		cmd.AddCommand(subCmd)
 	}
@@ -0,0 +1,189 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/ownership"
+)
+
+// GetRunner creates and returns the Runner which stores the cobra command.
+func GetRunner(factory cmdutil.Factory, invFactory *inventory.TypedClientFactory,
+	loader manifestreader.ManifestLoader, ioStreams genericiooptions.IOStreams) *Runner {
+	r := &Runner{
+		ioStreams:  ioStreams,
+		factory:    factory,
+		invFactory: invFactory,
+		loader:     loader,
+	}
+	cmd := &cobra.Command{
+		Use:                   "migrate-inventory (DIRECTORY | STDIN)",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Move the inventory of the configuration to another inventory object"),
+		Long: i18n.T(`Move the inventory of the configuration to another inventory object.
+
+The destination may use a different inventory type, namespace, name, or inventory ID.
+Objects owned by the source inventory are updated to be owned by the destination
+inventory, then the source inventory object is deleted. Update the inventory template
+in the package to match the destination afterwards.`),
+		RunE: r.RunE,
+	}
+
+	cmd.Flags().StringVar(&r.toType, "to-inventory-type", "",
+		"Type of the destination inventory object. Defaults to the --inventory-type.")
+	cmd.Flags().StringVar(&r.toNamespace, "to-namespace", "",
+		"Namespace of the destination inventory object. Defaults to the source namespace.")
+	cmd.Flags().StringVar(&r.toName, "to-name", "",
+		"Name of the destination inventory object. Defaults to the source name.")
+	cmd.Flags().StringVar(&r.toID, "to-inventory-id", "",
+		"Inventory ID of the destination inventory. Defaults to the source inventory ID.")
+	cmd.Flags().BoolVar(&r.dryRun, "dry-run", false,
+		"If true, only print the changes that would be made.")
+	cmd.Flags().DurationVar(&r.timeout, "timeout", 0,
+		"How long to wait before exiting")
+
+	r.Command = cmd
+	return r
+}
+
+// Command creates the Runner, returning the cobra command associated with it.
+func Command(f cmdutil.Factory, invFactory *inventory.TypedClientFactory, loader manifestreader.ManifestLoader,
+	ioStreams genericiooptions.IOStreams) *cobra.Command {
+	return GetRunner(f, invFactory, loader, ioStreams).Command
+}
+
+// Runner encapsulates data necessary to run the migrate-inventory command.
+type Runner struct {
+	Command    *cobra.Command
+	ioStreams  genericiooptions.IOStreams
+	factory    cmdutil.Factory
+	invFactory *inventory.TypedClientFactory
+	loader     manifestreader.ManifestLoader
+
+	toType      string
+	toNamespace string
+	toName      string
+	toID        string
+	dryRun      bool
+	timeout     time.Duration
+}
+
+func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// If specified, cancel with timeout.
+	if r.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	// Retrieve the source inventory object from the package.
+	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
+	if err != nil {
+		return err
+	}
+	objs, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	invObj, _, err := inventory.SplitUnstructureds(objs)
+	if err != nil {
+		return err
+	}
+	fromInfo := inventory.NewSingleObjectInventory(invObj).SingleObjectInfo
+
+	toFactory := *r.invFactory
+	if r.toType != "" {
+		toFactory.Type = inventory.StorageType(r.toType)
+	}
+	toID := fromInfo.GetID()
+	if r.toID != "" {
+		toID = inventory.ID(r.toID)
+	}
+	toNN := types.NamespacedName{Namespace: fromInfo.GetNamespace(), Name: fromInfo.GetName()}
+	if r.toNamespace != "" {
+		toNN.Namespace = r.toNamespace
+	}
+	if r.toName != "" {
+		toNN.Name = r.toName
+	}
+	toInfo := inventory.NewSingleObjectInfo(toID, toNN)
+	if toFactory.Type.GroupKind() == r.invFactory.Type.GroupKind() && toNN.Namespace == fromInfo.GetNamespace() &&
+		toNN.Name == fromInfo.GetName() {
+		return fmt.Errorf("the source and destination inventory are the same object")
+	}
+
+	fromClient, err := r.invFactory.NewClient(r.factory)
+	if err != nil {
+		return err
+	}
+	toClient, err := toFactory.NewClient(r.factory)
+	if err != nil {
+		return err
+	}
+	ownershipClient, err := ownership.NewClient(r.factory)
+	if err != nil {
+		return err
+	}
+
+	result, err := ownershipClient.Migrate(ctx, fromClient, &fromInfo, toClient, toInfo,
+		ownership.MigrateOptions{DryRun: r.dryRun})
+	if result != nil {
+		r.printResult(result, toFactory.Type, toInfo, toID)
+	}
+	return err
+}
+
+func (r *Runner) printResult(result *ownership.MigrateResult, toType inventory.StorageType,
+	toInfo *inventory.SingleObjectInfo, toID inventory.ID) {
+	suffix := ""
+	if r.dryRun {
+		suffix = " (dry-run)"
+	}
+	updated := 0
+	for _, objResult := range result.Objects {
+		var msg string
+		switch objResult.Action {
+		case ownership.ActionUpdate:
+			updated++
+			msg = fmt.Sprintf("owner updated to %q", toID)
+		case ownership.ActionUnchanged:
+			msg = "owner unchanged"
+		case ownership.ActionSkipped:
+			msg = fmt.Sprintf("skipped: owned by inventory %q", objResult.Owner)
+		case ownership.ActionNotFound:
+			msg = "skipped: not found"
+		case ownership.ActionFailed:
+			msg = fmt.Sprintf("failed: %v", objResult.Error)
+		}
+		r.print("%s %s%s", resourceIDToString(objResult.Identifier), msg, suffix)
+	}
+	r.print("%d objects migrated to %s %s/%s, %d owners updated%s", len(result.Objects),
+		toType, toInfo.GetNamespace(), toInfo.GetName(), updated, suffix)
+	if result.SourceDeleted {
+		r.print("source inventory deleted")
+	}
+}
+
+func (r *Runner) print(format string, a ...any) {
+	_, _ = fmt.Fprintf(r.ioStreams.Out, format+"\n", a...)
+}
+
+// resourceIDToString returns the string representation of an object.
+func resourceIDToString(id object.ObjMetadata) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(id.GroupKind.String()), id.Name)
+}
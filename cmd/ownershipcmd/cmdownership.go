@@ -0,0 +1,207 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ownershipcmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/ownership"
+	"sigs.k8s.io/cli-utils/pkg/printers"
+)
+
+// NewCmdAdopt returns the adopt command, which transfers ownership of objects
+// to the inventory of a package.
+func NewCmdAdopt(factory cmdutil.Factory, invFactory inventory.ClientFactory,
+	loader manifestreader.ManifestLoader, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	r := &Runner{
+		ioStreams:  ioStreams,
+		factory:    factory,
+		invFactory: invFactory,
+		loader:     loader,
+	}
+	cmd := &cobra.Command{
+		Use:                   "adopt DIRECTORY TYPE/NAME...",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Transfer ownership of objects to the inventory of the configuration"),
+		Long: i18n.T(`Transfer ownership of objects to the inventory of the configuration.
+
+Objects without an owning inventory are adopted. Objects owned by the inventory of
+the --from configuration are adopted and removed from that inventory. Objects owned
+by any other inventory are skipped.`),
+		Args: cobra.MinimumNArgs(2),
+		RunE: r.runAdopt,
+	}
+	cmd.Flags().StringVar(&r.from, "from", "",
+		"Directory of the configuration whose inventory currently owns the objects.")
+	r.addFlags(cmd)
+	return cmd
+}
+
+// NewCmdAbandon returns the abandon command, which detaches objects from the
+// inventory of a package, without deleting them.
+func NewCmdAbandon(factory cmdutil.Factory, invFactory inventory.ClientFactory,
+	loader manifestreader.ManifestLoader, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	r := &Runner{
+		ioStreams:  ioStreams,
+		factory:    factory,
+		invFactory: invFactory,
+		loader:     loader,
+	}
+	cmd := &cobra.Command{
+		Use:                   "abandon DIRECTORY TYPE/NAME...",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Detach objects from the inventory of the configuration without deleting them"),
+		Args:                  cobra.MinimumNArgs(2),
+		RunE:                  r.runAbandon,
+	}
+	r.addFlags(cmd)
+	return cmd
+}
+
+// Runner encapsulates data necessary to run the adopt and abandon commands.
+type Runner struct {
+	ioStreams  genericiooptions.IOStreams
+	factory    cmdutil.Factory
+	invFactory inventory.ClientFactory
+	loader     manifestreader.ManifestLoader
+
+	from   string
+	dryRun bool
+	output string
+}
+
+func (r *Runner) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&r.dryRun, "dry-run", false,
+		"If true, only print the changes that would be made.")
+	cmd.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
+		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
+}
+
+func (r *Runner) runAdopt(cmd *cobra.Command, args []string) error {
+	to, invClient, ownershipClient, ids, err := r.setup(cmd, args)
+	if err != nil {
+		return err
+	}
+	opts := ownership.AdoptOptions{DryRun: r.dryRun}
+	if r.from != "" {
+		opts.From, err = r.readInventoryInfo(cmd.InOrStdin(), r.from)
+		if err != nil {
+			return err
+		}
+	}
+	return r.print(ownershipClient.Adopt(cmd.Context(), invClient, to, ids, opts))
+}
+
+func (r *Runner) runAbandon(cmd *cobra.Command, args []string) error {
+	from, invClient, ownershipClient, ids, err := r.setup(cmd, args)
+	if err != nil {
+		return err
+	}
+	return r.print(ownershipClient.Abandon(cmd.Context(), invClient, from, ids,
+		ownership.AbandonOptions{DryRun: r.dryRun}))
+}
+
+// setup returns the inventory of the package, the clients, and the objects
+// specified by the arguments.
+func (r *Runner) setup(cmd *cobra.Command, args []string) (inventory.Info, inventory.Client,
+	*ownership.Client, object.ObjMetadataSet, error) {
+	if found := printers.ValidatePrinterType(r.output); !found {
+		return nil, nil, nil, nil, fmt.Errorf("unknown output type %q", r.output)
+	}
+	inv, err := r.readInventoryInfo(cmd.InOrStdin(), args[0])
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	ids, err := r.parseObjects(args[1:])
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	invClient, err := r.invFactory.NewClient(r.factory)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	ownershipClient, err := ownership.NewClient(r.factory)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return inv, invClient, ownershipClient, ids, nil
+}
+
+func (r *Runner) print(ch <-chan event.Event) error {
+	dryRunStrategy := common.DryRunNone
+	if r.dryRun {
+		dryRunStrategy = common.DryRunClient
+	}
+	printer := printers.GetPrinter(r.output, r.ioStreams)
+	return printer.Print(ch, dryRunStrategy, false)
+}
+
+// readInventoryInfo returns the inventory of the package in the directory.
+func (r *Runner) readInventoryInfo(in io.Reader, path string) (inventory.Info, error) {
+	reader, err := r.loader.ManifestReader(in, path)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	invObj, _, err := inventory.SplitUnstructureds(objs)
+	if err != nil {
+		return nil, err
+	}
+	return inventory.ConfigMapToInventoryInfo(invObj)
+}
+
+// parseObjects returns the object references from TYPE/NAME arguments, like
+// kubectl. Namespaced objects are in the namespace of the current context,
+// or the --namespace flag.
+func (r *Runner) parseObjects(args []string) (object.ObjMetadataSet, error) {
+	namespace, _, err := r.factory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := r.factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	var ids object.ObjMetadataSet
+	for _, arg := range args {
+		resource, name, found := strings.Cut(arg, "/")
+		if !found || resource == "" || name == "" {
+			return nil, fmt.Errorf("invalid object %q: must be TYPE/NAME", arg)
+		}
+		gvr, err := mapper.ResourceFor(schema.ParseGroupResource(resource).WithVersion(""))
+		if err != nil {
+			return nil, err
+		}
+		gvk, err := mapper.KindFor(gvr)
+		if err != nil {
+			return nil, err
+		}
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, err
+		}
+		id := object.ObjMetadata{GroupKind: gvk.GroupKind(), Name: name}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			id.Namespace = namespace
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -169,6 +169,7 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		ch = d.Run(ctx, inv, apply.DestroyerOptions{
 			InventoryPolicy: inventoryPolicy,
 			DryRunStrategy:  drs,
+			Hooks:           objs,
 		})
 	}
 
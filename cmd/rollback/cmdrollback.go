@@ -0,0 +1,163 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	"sigs.k8s.io/cli-utils/pkg/printers"
+)
+
+// GetRunner creates and returns the Runner which stores the cobra command.
+func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
+	loader manifestreader.ManifestLoader, ioStreams genericiooptions.IOStreams) *Runner {
+	r := &Runner{
+		ioStreams:  ioStreams,
+		factory:    factory,
+		invFactory: invFactory,
+		loader:     loader,
+	}
+	cmd := &cobra.Command{
+		Use:                   "rollback (DIRECTORY | STDIN)",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Roll back the resources related to configuration to a previous revision"),
+		RunE:                  r.RunE,
+	}
+
+	cmd.Flags().Int64Var(&r.revision, "to-revision", 0,
+		"The revision to roll back to. Default to the last successful revision before the latest one.")
+	cmd.Flags().BoolVar(&r.serverSideOptions.ServerSideApply, "server-side", false,
+		"If true, apply merge patch is calculated on API server instead of client.")
+	cmd.Flags().BoolVar(&r.serverSideOptions.ForceConflicts, "force-conflicts", false,
+		"If true, overwrite applied fields on server if field manager conflict.")
+	cmd.Flags().StringVar(&r.serverSideOptions.FieldManager, "field-manager", common.DefaultFieldManager,
+		"The client owner of the fields being applied on the server-side.")
+	cmd.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
+		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
+	cmd.Flags().DurationVar(&r.reconcileTimeout, "reconcile-timeout", time.Duration(0),
+		"Timeout threshold for waiting for all resources to reach the Current status.")
+	cmd.Flags().StringVar(&r.prunePropagationPolicy, "prune-propagation-policy",
+		"Background", "Propagation policy for pruning")
+	cmd.Flags().DurationVar(&r.pruneTimeout, "prune-timeout", time.Duration(0),
+		"Timeout threshold for waiting for all pruned resources to be deleted")
+	cmd.Flags().DurationVar(&r.timeout, "timeout", 0,
+		"How long to wait before exiting")
+	cmd.Flags().BoolVar(&r.printStatusEvents, "status-events", false,
+		"Print status events (always enabled for table output)")
+
+	r.Command = cmd
+	return r
+}
+
+// Command creates the Runner, returning the cobra command associated with it.
+func Command(f cmdutil.Factory, invFactory inventory.ClientFactory, loader manifestreader.ManifestLoader,
+	ioStreams genericiooptions.IOStreams) *cobra.Command {
+	return GetRunner(f, invFactory, loader, ioStreams).Command
+}
+
+// Runner encapsulates data necessary to run the rollback command.
+type Runner struct {
+	Command    *cobra.Command
+	ioStreams  genericiooptions.IOStreams
+	factory    cmdutil.Factory
+	invFactory inventory.ClientFactory
+	loader     manifestreader.ManifestLoader
+
+	revision               int64
+	serverSideOptions      common.ServerSideOptions
+	output                 string
+	reconcileTimeout       time.Duration
+	prunePropagationPolicy string
+	pruneTimeout           time.Duration
+	timeout                time.Duration
+	printStatusEvents      bool
+}
+
+func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// If specified, cancel with timeout.
+	if r.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	if r.revision < 0 {
+		return fmt.Errorf("invalid revision %d: must not be negative", r.revision)
+	}
+	prunePropPolicy, err := flagutils.ConvertPropagationPolicy(r.prunePropagationPolicy)
+	if err != nil {
+		return err
+	}
+
+	if found := printers.ValidatePrinterType(r.output); !found {
+		return fmt.Errorf("unknown output type %q", r.output)
+	}
+
+	// Retrieve the inventory object. The objects to apply are read from
+	// the inventory revision instead of the package.
+	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
+	if err != nil {
+		return err
+	}
+	objs, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	invObj, _, err := inventory.SplitUnstructureds(objs)
+	if err != nil {
+		return err
+	}
+	inv, err := inventory.ConfigMapToInventoryInfo(invObj)
+	if err != nil {
+		return err
+	}
+
+	invClient, err := r.invFactory.NewClient(r.factory)
+	if err != nil {
+		return err
+	}
+	a, err := apply.NewApplierBuilder().
+		WithFactory(r.factory).
+		WithInventoryClient(invClient).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	// Always enable status events for the table printer
+	if r.output == printers.TablePrinter {
+		r.printStatusEvents = true
+	}
+
+	// Run the rollback. It will return a channel where we can receive updates
+	// to keep track of progress and any issues.
+	ch := a.Rollback(ctx, inv, r.revision, apply.ApplierOptions{
+		ServerSideOptions:      r.serverSideOptions,
+		ReconcileTimeout:       r.reconcileTimeout,
+		EmitStatusEvents:       r.printStatusEvents,
+		DryRunStrategy:         common.DryRunNone,
+		PrunePropagationPolicy: prunePropPolicy,
+		PruneTimeout:           r.pruneTimeout,
+		// Only objects in the inventory can be rolled back.
+		InventoryPolicy: inventory.PolicyMustMatch,
+	})
+
+	// The printer will print updates from the channel. It will block
+	// until the channel is closed.
+	printer := printers.GetPrinter(r.output, r.ioStreams)
+	return printer.Print(ch, common.DryRunNone, r.printStatusEvents)
+}
@@ -57,6 +57,11 @@ type ObjectStatus struct {
 	// Generation is not available for deleted objects.
 	// +optional
 	Generation int64 `json:"generation,omitempty"`
+	// Hash is the last known hash of the applied object configuration.
+	// This can help identify if the local object has changed since it was
+	// applied. Hash is not available for deleted objects.
+	// +optional
+	Hash string `json:"hash,omitempty"`
 }
 
 // ActuationStrategy defines the actuation strategy used for a specific object.
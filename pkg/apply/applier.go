@@ -5,12 +5,14 @@ package apply
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
@@ -23,12 +25,15 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
 	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/apply/solver"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/graph"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 )
 
@@ -54,7 +59,8 @@ type Applier struct {
 }
 
 // prepareObjects returns the set of objects to apply and to prune or
-// an error if one occurred.
+// an error if one occurred. The objects to apply include the lifecycle hooks,
+// which are never pruned, but are not labelled as inventory members.
 func (a *Applier) prepareObjects(ctx context.Context, inv inventory.Inventory, localObjs object.UnstructuredSet,
 	o ApplierOptions) (object.UnstructuredSet, object.UnstructuredSet, error) {
 	if inv == nil {
@@ -63,9 +69,28 @@ func (a *Applier) prepareObjects(ctx context.Context, inv inventory.Inventory, l
 	if err := inventory.ValidateNoInventory(localObjs); err != nil {
 		return nil, nil, err
 	}
-	// Add the inventory annotation to the resources being applied.
+	// Add the inventory annotation to the resources being applied, and the
+	// labels required by the inventory client, if any.
+	var memberLabels map[string]string
+	if labeler, ok := a.invClient.(inventory.MemberLabeler); ok {
+		var err error
+		memberLabels, err = labeler.MemberLabels(inv.Info())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	for _, localObj := range localObjs {
 		inventory.AddInventoryIDAnnotation(localObj, inv.Info().GetID())
+		if len(memberLabels) > 0 && !hook.HasAnnotation(localObj) {
+			labels := localObj.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string, len(memberLabels))
+			}
+			for k, v := range memberLabels {
+				labels[k] = v
+			}
+			localObj.SetLabels(labels)
+		}
 	}
 	pruneObjs, err := a.pruner.GetPruneObjs(ctx, inv, localObjs, prune.Options{
 		DryRunStrategy: o.DryRunStrategy,
@@ -99,6 +124,19 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 		}
 		validator.Validate(objects)
 
+		// Lock the inventory for the duration of the run, so that concurrent
+		// runs do not prune each other's objects.
+		// If the lock is lost, the run is cancelled.
+		if options.LockInventory && !options.DryRunStrategy.ClientOrServerDryRun() {
+			lockCtx, unlock, err := lockInventory(ctx, a.client, invInfo, options.LockOptions)
+			if err != nil {
+				handleError(eventChannel, err)
+				return
+			}
+			defer unlock()
+			ctx = lockCtx
+		}
+
 		inv, err := a.invClient.Get(ctx, invInfo, inventory.GetOptions{})
 		if apierrors.IsNotFound(err) {
 			inv, err = a.invClient.NewInventory(invInfo)
@@ -119,7 +157,43 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 			handleError(eventChannel, err)
 			return
 		}
-		klog.V(4).Infof("calculated %d apply objs; %d prune objs", len(applyObjs), len(pruneObjs))
+		// Lifecycle hooks are applied separately, before or after the
+		// other objects.
+		hookObjs, applyObjs := hook.SplitHooks(applyObjs)
+		klog.V(4).Infof("calculated %d apply objs; %d prune objs; %d hooks", len(applyObjs), len(pruneObjs), len(hookObjs))
+
+		// Find the hooks that are still in the cluster from a previous run,
+		// so they are deleted before they are applied again.
+		prevHookObjs, err := previousHooks(ctx, a.client, a.mapper, invInfo, options.InventoryPolicy, hookObjs)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		klog.V(4).Infof("replacing %d previous hooks", len(prevHookObjs))
+
+		// Find the objects that were applied and reconciled by a previous,
+		// interrupted run, so their phases can be skipped.
+		var checkpoint object.ObjectStatusSet
+		if options.Resume {
+			checkpoint, err = a.checkpointedObjects(ctx, inv, applyObjs)
+			if err != nil {
+				handleError(eventChannel, err)
+				return
+			}
+			klog.V(4).Infof("resuming with %d objects already reconciled", len(checkpoint))
+		}
+
+		// Find the objects that have not changed since they were last
+		// applied, so they are not sent to the server again.
+		var unchanged object.ObjectStatusSet
+		if options.SkipUnchanged {
+			unchanged, err = a.unchangedObjects(ctx, inv, applyObjs, false)
+			if err != nil {
+				handleError(eventChannel, err)
+				return
+			}
+			klog.V(4).Infof("skipping %d unchanged objects", len(unchanged))
+		}
 
 		// Build a TaskContext for passing info between tasks
 		resourceCache := cache.NewResourceCacheMap()
@@ -145,6 +219,9 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 				ActuationStrategy: actuation.ActuationStrategyApply,
 				DryRunStrategy:    options.DryRunStrategy,
 			},
+			filter.HookFilter{
+				TaskContext: taskContext,
+			},
 		}
 		// Build list of prune validation filters.
 		pruneFilters := []filter.ValidationFilter{
@@ -161,6 +238,30 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 				ActuationStrategy: actuation.ActuationStrategyDelete,
 				DryRunStrategy:    options.DryRunStrategy,
 			},
+			filter.HookFilter{
+				TaskContext: taskContext,
+			},
+		}
+		// Build list of hook replace validation filters.
+		hookReplaceFilters := []filter.ValidationFilter{
+			filter.HookFilter{
+				TaskContext: taskContext,
+			},
+		}
+		// Skip the remaining objects after any object fails.
+		if options.ErrorPolicy == FailFast {
+			failFastFilter := filter.FailFastFilter{
+				TaskContext: taskContext,
+			}
+			applyFilters = append([]filter.ValidationFilter{failFastFilter}, applyFilters...)
+			pruneFilters = append([]filter.ValidationFilter{failFastFilter}, pruneFilters...)
+			hookReplaceFilters = append([]filter.ValidationFilter{failFastFilter}, hookReplaceFilters...)
+		}
+		// Keep the objects to prune if the apply needs to be rolled back.
+		if options.RollbackOnFailure {
+			pruneFilters = append(pruneFilters, filter.ReconcileFailureFilter{
+				TaskContext: taskContext,
+			})
 		}
 		// Build list of apply mutators.
 		applyMutators := []mutator.Interface{
@@ -182,22 +283,41 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 			ApplyFilters:  applyFilters,
 			ApplyMutators: applyMutators,
 			PruneFilters:  pruneFilters,
+
+			HookReplaceFilters: hookReplaceFilters,
 		}
 		opts := solver.Options{
-			ServerSideOptions:      options.ServerSideOptions,
-			ReconcileTimeout:       options.ReconcileTimeout,
-			Destroy:                false,
-			Prune:                  !options.NoPrune,
-			DryRunStrategy:         options.DryRunStrategy,
-			PrunePropagationPolicy: options.PrunePropagationPolicy,
-			PruneTimeout:           options.PruneTimeout,
-			InventoryPolicy:        options.InventoryPolicy,
+			ServerSideOptions:         options.ServerSideOptions,
+			ReconcileTimeout:          options.ReconcileTimeout,
+			Destroy:                   false,
+			Prune:                     !options.NoPrune,
+			DryRunStrategy:            options.DryRunStrategy,
+			PrunePropagationPolicy:    options.PrunePropagationPolicy,
+			PruneTimeout:              options.PruneTimeout,
+			InventoryPolicy:           options.InventoryPolicy,
+			ParallelPhases:            options.ParallelPhases,
+			Checkpoint:                options.Resume,
+			StoreAppliedState:         options.Resume || options.SkipUnchanged,
+			RollbackOnFailure:         options.RollbackOnFailure,
+			ApplyConcurrency:          options.ApplyConcurrency,
+			PruneConcurrency:          options.PruneConcurrency,
+			RetryPolicy:               options.RetryPolicy,
+			StabilityWindow:           options.StabilityWindow,
+			ReconcileTimeouts:         options.ReconcileTimeouts,
+			PruneTimeouts:             options.PruneTimeouts,
+			InferReferences:           options.InferReferences,
+			AllowExternalDependencies: options.AllowExternalDependencies,
+			ExternalDependencyTimeout: options.ExternalDependencyTimeout,
 		}
 
 		// Build the ordered set of tasks to execute.
 		taskQueue := taskBuilder.
 			WithApplyObjects(applyObjs).
 			WithPruneObjects(pruneObjs).
+			WithHookObjects(hookObjs).
+			WithPreviousHookObjects(prevHookObjs).
+			WithCheckpointedObjects(checkpoint).
+			WithUnchangedObjects(unchanged).
 			Build(taskContext, opts)
 
 		klog.V(4).Infof("validation errors: %d", len(vCollector.Errors))
@@ -230,12 +350,16 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 		eventChannel <- event.Event{
 			Type: event.InitType,
 			InitEvent: event.InitEvent{
-				ActionGroups: taskQueue.ToActionGroups(),
+				ActionGroups:         taskQueue.ToActionGroups(),
+				InferredDependencies: inferredDependencies(taskContext.Graph()),
 			},
 		}
 		// Create a new TaskStatusRunner to execute the taskQueue.
 		klog.V(4).Infoln("applier building TaskStatusRunner...")
 		allIDs := object.UnstructuredSetToObjMetadataSet(append(applyObjs, pruneObjs...))
+		// Watch the external dependencies and hooks too, to wait for them.
+		allIDs = allIDs.Union(taskContext.ExternalObjects())
+		allIDs = allIDs.Union(object.UnstructuredSetToObjMetadataSet(hookObjs))
 		statusWatcher := a.statusWatcher
 		// Disable watcher for dry runs
 		if opts.DryRunStrategy.ClientOrServerDryRun() {
@@ -243,11 +367,18 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 		}
 		runner := taskrunner.NewTaskStatusRunner(allIDs, statusWatcher)
 		klog.V(4).Infoln("applier running TaskStatusRunner...")
-		err = runner.Run(ctx, taskContext, taskQueue.ToChannel(), taskrunner.Options{
+		err = runner.RunGraph(ctx, taskContext, taskQueue.ToGraph(), taskrunner.Options{
 			EmitStatusEvents:         options.EmitStatusEvents,
 			WatcherRESTScopeStrategy: options.WatcherRESTScopeStrategy,
 		})
-		if err != nil {
+		if err := runError(ctx, err); err != nil {
+			// The rollback tasks were not run, so restore the applied
+			// objects now, unless the apply was cancelled.
+			if ctx.Err() == nil {
+				for _, rollbackTask := range taskQueue.RollbackTasks() {
+					rollbackTask.Restore(taskContext)
+				}
+			}
 			handleError(eventChannel, err)
 			return
 		}
@@ -264,6 +395,13 @@ type ApplierOptions struct {
 	// how long to wait.
 	ReconcileTimeout time.Duration
 
+	// ReconcileTimeouts overrides the ReconcileTimeout for all applied
+	// resources of a GroupKind. The timeout of each resource can also be
+	// overridden with the config.kubernetes.io/reconcile-timeout annotation,
+	// which takes precedence. Resources that time out before the others in
+	// the same phase are reported as soon as they time out.
+	ReconcileTimeouts map[schema.GroupKind]time.Duration
+
 	// EmitStatusEvents defines whether status events should be
 	// emitted on the eventChannel to the caller.
 	EmitStatusEvents bool
@@ -286,15 +424,118 @@ type ApplierOptions struct {
 	// wait.
 	PruneTimeout time.Duration
 
+	// PruneTimeouts overrides the PruneTimeout for all pruned resources of a
+	// GroupKind. The timeout of each resource can also be overridden with
+	// the config.kubernetes.io/reconcile-timeout annotation, which takes
+	// precedence.
+	PruneTimeouts map[schema.GroupKind]time.Duration
+
 	// InventoryPolicy defines the inventory policy of apply.
 	InventoryPolicy inventory.Policy
 
 	// ValidationPolicy defines how to handle invalid objects.
 	ValidationPolicy validation.Policy
 
+	// ErrorPolicy defines how to handle objects that fail to be applied or
+	// pruned, fail to reconcile, or time out. With FailFast, the remaining
+	// objects are skipped, but the inventory is still updated.
+	ErrorPolicy ErrorPolicy
+
 	// RESTScopeStrategy specifies which strategy to use when listing and
 	// watching resources. By default, the strategy is selected automatically.
 	WatcherRESTScopeStrategy watcher.RESTScopeStrategy
+
+	// ParallelPhases defines whether independent sets of objects should be
+	// applied and pruned concurrently. If true, objects only wait for their
+	// own dependencies to reconcile, instead of waiting for every object in
+	// the previous phase.
+	ParallelPhases bool
+
+	// Resume defines whether the apply progress should be persisted to the
+	// inventory after each apply phase has reconciled, and whether the
+	// progress persisted by a previous run should be used to skip phases
+	// that have already been applied and reconciled. Objects are only
+	// skipped if they have not changed since, locally or in the cluster.
+	// Requires an inventory client with object status enabled.
+	Resume bool
+
+	// RollbackOnFailure defines whether the applied objects should be
+	// restored to the state they had before the apply, if any of them
+	// failed to reconcile or timed out. Objects that did not exist before
+	// are deleted. Pruning is skipped when a reconcile fails, so the pruned
+	// objects are kept. The objects are restored in reverse apply order,
+	// before the inventory is updated. They are also restored if the apply
+	// fails with an error, unless it was cancelled. Rollback results are
+	// reported with RollbackEvents.
+	RollbackOnFailure bool
+
+	// SkipUnchanged defines whether objects that have not changed since they
+	// were last applied should be skipped, instead of sending them to the
+	// server. An object has not changed if the hash of the local object is
+	// the same as the hash stored in the inventory, and the object in the
+	// cluster has the same UID and generation. Skipped objects are reported
+	// with the ApplyUnchanged status. Requires an inventory client with
+	// object status enabled. Changes made in the cluster to objects without
+	// a generation, like ConfigMaps, are not detected.
+	SkipUnchanged bool
+
+	// ApplyConcurrency defines the maximum number of objects to apply
+	// concurrently within each apply phase. If less than two, objects are
+	// applied one at a time. The events of each object are still sent in the
+	// order of the objects. Requests share the client-side rate limiter of
+	// the dynamic client, so a client configured with a low QPS limits the
+	// effective concurrency. Client-side rate limiting can be disabled when
+	// the server enforces flow control (see the flowcontrol package).
+	ApplyConcurrency int
+
+	// PruneConcurrency defines the maximum number of objects to prune
+	// concurrently within each prune phase. If less than two, objects are
+	// pruned one at a time. Prune filters are still evaluated for each
+	// object and the events of each object are sent in the order of the
+	// objects.
+	PruneConcurrency int
+
+	// RetryPolicy defines how applying and pruning an object is retried when
+	// the server returns a transient error, like a 429, a 5xx, a conflict, or
+	// an admission webhook timeout. Each failed attempt that will be retried
+	// is reported with a RetryEvent. The zero value does not retry.
+	RetryPolicy retry.Policy
+
+	// StabilityWindow defines how long applied objects must remain Current
+	// before the applier considers them reconciled. Objects that stop being
+	// Current restart their window. The time remaining is reported with
+	// WaitEvents with the ReconcileStabilizing status. Can be overridden for
+	// each object with the config.kubernetes.io/stability-window annotation.
+	StabilityWindow time.Duration
+
+	// InferReferences enables the dependencies inferred from object
+	// references, like from a Deployment to the ConfigMaps, Secrets,
+	// ServiceAccount and PriorityClass used by its pod template, from a
+	// RoleBinding to its Role and ServiceAccounts, and from an Ingress to its
+	// Services. Inferred dependencies are reported in the InitEvent.
+	InferReferences bool
+
+	// AllowExternalDependencies allows depends-on annotations to reference
+	// objects that are not in the package, like objects applied with another
+	// inventory. Before the dependents are applied, the applier waits for
+	// the live external dependencies to become Current, and reports their
+	// status with WaitEvents. If an external dependency does not become
+	// Current before the ExternalDependencyTimeout, its dependents are
+	// skipped. Otherwise, external dependencies are validation errors.
+	AllowExternalDependencies bool
+
+	// ExternalDependencyTimeout defines how long to wait for the external
+	// dependencies to become Current. If zero, the ReconcileTimeout is used.
+	ExternalDependencyTimeout time.Duration
+
+	// LockInventory defines whether a lease on the inventory should be held
+	// for the duration of the run, to prevent concurrent applies and destroys
+	// of the same inventory. If the lease is held by someone else, the run
+	// fails with an ErrorEvent. Ignored for dry runs.
+	LockInventory bool
+
+	// LockOptions configures the inventory lease, if LockInventory is true.
+	LockOptions inventory.LockOptions
 }
 
 // setDefaults set the options to the default values if they
@@ -305,6 +546,60 @@ func setDefaults(o *ApplierOptions) {
 	}
 }
 
+// lockInventory acquires the inventory lock. It returns a context that is
+// cancelled if the lock is lost, with the LockLostError as the cause, and a
+// function that releases the lock, even if the context was cancelled.
+func lockInventory(ctx context.Context, client dynamic.Interface, invInfo inventory.Info,
+	opts inventory.LockOptions) (context.Context, func(), error) {
+	lock, err := inventory.AcquireLock(ctx, client, invInfo, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	lockCtx, cancel := context.WithCancelCause(ctx)
+	go func() {
+		select {
+		case <-lock.Lost():
+			cancel(lock.Err())
+		case <-lockCtx.Done():
+		}
+	}()
+	unlock := func() {
+		cancel(nil)
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+			klog.Warningf("failed to release inventory lock: %v", err)
+		}
+	}
+	return lockCtx, unlock, nil
+}
+
+// runError returns the error to report for a run that returned err. If the
+// inventory lock was lost, the LockLostError is returned instead, because
+// the run was cancelled or raced with another holder.
+func runError(ctx context.Context, err error) error {
+	var lostErr *inventory.LockLostError
+	if errors.As(context.Cause(ctx), &lostErr) {
+		return lostErr
+	}
+	return err
+}
+
+// inferredDependencies returns the dependencies of the graph that were
+// inferred from object references.
+func inferredDependencies(g *graph.Graph) []event.InferredDependency {
+	if g == nil {
+		return nil
+	}
+	var deps []event.InferredDependency
+	for _, ref := range g.References() {
+		deps = append(deps, event.InferredDependency{
+			Object:     ref.From,
+			Dependency: ref.To,
+			Field:      ref.Field,
+		})
+	}
+	return deps
+}
+
 func handleError(eventChannel chan event.Event, err error) {
 	eventChannel <- event.Event{
 		Type: event.ErrorType,
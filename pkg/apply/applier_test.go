@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/multierror"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
@@ -1972,6 +1973,8 @@ func TestReadAndPrepareObjects(t *testing.T) {
 
 	obj1 := testutil.Unstructured(t, resources["obj1"])
 	obj2 := testutil.Unstructured(t, resources["obj2"])
+	obj2Hook := testutil.Unstructured(t, resources["obj2"],
+		testutil.AddHook(hook.PostApply, hook.DeleteNever))
 	clusterScopedObj := testutil.Unstructured(t, resources["clusterScopedObj"])
 
 	testCases := map[string]struct {
@@ -2065,6 +2068,21 @@ func TestReadAndPrepareObjects(t *testing.T) {
 			applyObjs: object.UnstructuredSet{obj1, obj2, clusterScopedObj},
 			pruneObjs: object.UnstructuredSet{},
 		},
+		"former member is now a hook, apply all": {
+			clusterObjs: object.UnstructuredSet{obj2},
+			invObj: newInventoryObj(
+				inventory.NewSingleObjectInfo("test-app-label", types.NamespacedName{
+					Name:      "test-inventory-obj",
+					Namespace: "test-namespace",
+				}),
+				object.ObjMetadataSet{
+					object.UnstructuredToObjMetadata(obj2),
+				},
+			),
+			resources: object.UnstructuredSet{obj1, obj2Hook},
+			applyObjs: object.UnstructuredSet{obj1, obj2Hook},
+			pruneObjs: object.UnstructuredSet{},
+		},
 	}
 
 	for name, tc := range testCases {
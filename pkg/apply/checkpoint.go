@@ -0,0 +1,128 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/mutation"
+)
+
+// checkpointedObjects returns the statuses of the apply objects that were
+// applied and reconciled by a previous run, according to the object statuses
+// stored in the inventory, and that have not changed since.
+func (a *Applier) checkpointedObjects(ctx context.Context, inv inventory.Inventory, applyObjs object.UnstructuredSet) (object.ObjectStatusSet, error) {
+	return a.unchangedObjects(ctx, inv, applyObjs, true)
+}
+
+// unchangedCheckConcurrency is the maximum number of objects to get from the
+// cluster at once, when checking whether they have changed. The requests
+// also share the client's rate limiter.
+const unchangedCheckConcurrency = 16
+
+// unchangedObjects returns the statuses of the apply objects that were
+// successfully applied by a previous run, according to the object statuses
+// stored in the inventory, and that have not changed since. An object has not
+// changed if the local object has the same hash, and the object in the
+// cluster has the same UID and generation. If reconciled is true, the objects
+// must also have reconciled.
+//
+// Objects with apply-time mutations are never considered unchanged, because
+// the mutations depend on the state of other objects.
+func (a *Applier) unchangedObjects(ctx context.Context, inv inventory.Inventory, applyObjs object.UnstructuredSet,
+	reconciled bool) (object.ObjectStatusSet, error) {
+	prevStatuses := make(map[object.ObjMetadata]actuation.ObjectStatus)
+	for _, objStatus := range inv.GetObjectStatuses() {
+		prevStatuses[inventory.ObjMetadataFromObjectReference(objStatus.ObjectReference)] = objStatus
+	}
+
+	// Only get the objects from the cluster that have not changed locally.
+	var candidates object.ObjectStatusSet
+	for _, obj := range applyObjs {
+		id := object.UnstructuredToObjMetadata(obj)
+		prevStatus, found := prevStatuses[id]
+		if !found ||
+			prevStatus.Strategy != actuation.ActuationStrategyApply ||
+			prevStatus.Actuation != actuation.ActuationSucceeded ||
+			(reconciled && prevStatus.Reconcile != actuation.ReconcileSucceeded) ||
+			prevStatus.UID == "" || prevStatus.Hash == "" {
+			continue
+		}
+		if mutation.HasAnnotation(obj) {
+			continue
+		}
+		hash, err := object.Hash(obj)
+		if err != nil {
+			return nil, err
+		}
+		if hash != prevStatus.Hash {
+			klog.V(4).Infof("unchanged check ignored (object changed): %s", id)
+			continue
+		}
+		candidates = append(candidates, prevStatus)
+	}
+
+	// Get the objects concurrently, keeping the results in order.
+	unchangedInCluster := make([]bool, len(candidates))
+	errs := make([]error, len(candidates))
+	workers := make(chan struct{}, unchangedCheckConcurrency)
+	var wg sync.WaitGroup
+	for i, prevStatus := range candidates {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			unchangedInCluster[i], errs[i] = a.unchangedInCluster(ctx, prevStatus)
+		}()
+	}
+	wg.Wait()
+
+	var unchanged object.ObjectStatusSet
+	for i, prevStatus := range candidates {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if unchangedInCluster[i] {
+			unchanged = append(unchanged, prevStatus)
+		}
+	}
+	return unchanged, nil
+}
+
+// unchangedInCluster returns true if the object in the cluster has the same
+// UID and generation as the previous status.
+func (a *Applier) unchangedInCluster(ctx context.Context, prevStatus actuation.ObjectStatus) (bool, error) {
+	id := inventory.ObjMetadataFromObjectReference(prevStatus.ObjectReference)
+	mapping, err := a.mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		// The type may have been removed since the previous run.
+		klog.V(4).Infof("unchanged check ignored (unknown type): %s: %v", id, err)
+		return false, nil
+	}
+	clusterObj, err := a.metadataClient.Resource(mapping.Resource).Namespace(id.Namespace).
+		Get(ctx, id.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		klog.V(4).Infof("unchanged check ignored (object not found): %s", id)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get current object from cluster: %w", err)
+	}
+	if clusterObj.GetUID() != prevStatus.UID || clusterObj.GetGeneration() != prevStatus.Generation {
+		klog.V(4).Infof("unchanged check ignored (object modified in cluster): %s", id)
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/metadata"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestCheckpointedObjects(t *testing.T) {
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	secret := testutil.Unstructured(t, resources["secret"])
+
+	reconciledStatus := func(u *unstructured.Unstructured) actuation.ObjectStatus {
+		hash, err := object.Hash(u)
+		require.NoError(t, err)
+		return actuation.ObjectStatus{
+			ObjectReference: inventory.ObjectReferenceFromObjMetadata(object.UnstructuredToObjMetadata(u)),
+			Strategy:        actuation.ActuationStrategyApply,
+			Actuation:       actuation.ActuationSucceeded,
+			Reconcile:       actuation.ReconcileSucceeded,
+			UID:             u.GetUID(),
+			Generation:      u.GetGeneration(),
+			Hash:            hash,
+		}
+	}
+
+	testCases := map[string]struct {
+		applyObjs    object.UnstructuredSet
+		clusterObjs  object.UnstructuredSet
+		prevStatuses object.ObjectStatusSet
+		expected     object.ObjectStatusSet
+	}{
+		"no previous status": {
+			applyObjs:   object.UnstructuredSet{deployment},
+			clusterObjs: object.UnstructuredSet{deployment},
+		},
+		"unchanged objects": {
+			applyObjs:   object.UnstructuredSet{deployment, secret},
+			clusterObjs: object.UnstructuredSet{deployment, secret},
+			prevStatuses: object.ObjectStatusSet{
+				reconciledStatus(deployment),
+				reconciledStatus(secret),
+			},
+			expected: object.ObjectStatusSet{
+				reconciledStatus(deployment),
+				reconciledStatus(secret),
+			},
+		},
+		"not reconciled": {
+			applyObjs:   object.UnstructuredSet{deployment},
+			clusterObjs: object.UnstructuredSet{deployment},
+			prevStatuses: object.ObjectStatusSet{
+				func() actuation.ObjectStatus {
+					s := reconciledStatus(deployment)
+					s.Reconcile = actuation.ReconcileTimeout
+					return s
+				}(),
+			},
+		},
+		"changed locally": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"],
+					testutil.AddOwningInv(t, "test")),
+			},
+			clusterObjs: object.UnstructuredSet{deployment},
+			prevStatuses: object.ObjectStatusSet{
+				reconciledStatus(deployment),
+			},
+		},
+		"changed in cluster": {
+			applyObjs: object.UnstructuredSet{deployment},
+			clusterObjs: object.UnstructuredSet{
+				func() *unstructured.Unstructured {
+					u := deployment.DeepCopy()
+					u.SetGeneration(2)
+					return u
+				}(),
+			},
+			prevStatuses: object.ObjectStatusSet{
+				reconciledStatus(deployment),
+			},
+		},
+		"deleted from cluster": {
+			applyObjs: object.UnstructuredSet{deployment},
+			prevStatuses: object.ObjectStatusSet{
+				reconciledStatus(deployment),
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var clusterObjs []runtime.Object
+			for _, u := range tc.clusterObjs {
+				objMeta := &metav1.PartialObjectMetadata{}
+				objMeta.APIVersion = u.GetAPIVersion()
+				objMeta.Kind = u.GetKind()
+				objMeta.Name = u.GetName()
+				objMeta.Namespace = u.GetNamespace()
+				objMeta.UID = u.GetUID()
+				objMeta.Generation = u.GetGeneration()
+				clusterObjs = append(clusterObjs, objMeta)
+			}
+			applier := &Applier{
+				mapper: testutil.NewFakeRESTMapper(
+					appsv1.SchemeGroupVersion.WithKind("Deployment"),
+					v1.SchemeGroupVersion.WithKind("Secret"),
+				),
+				metadataClient: metadatafake.NewSimpleMetadataClient(scheme.Scheme, clusterObjs...),
+			}
+			inv := &inventory.FakeInventory{
+				InventoryContents: inventory.InventoryContents{
+					ObjectStatuses: tc.prevStatuses,
+				},
+			}
+
+			checkpoint, err := applier.checkpointedObjects(t.Context(), inv, tc.applyObjs)
+			require.NoError(t, err)
+			testutil.AssertEqual(t, tc.expected, checkpoint)
+		})
+	}
+}
+
+func TestUnchangedObjects_Concurrent(t *testing.T) {
+	var applyObjs object.UnstructuredSet
+	var prevStatuses, expected object.ObjectStatusSet
+	var clusterObjs []runtime.Object
+	for i := range 3 * unchangedCheckConcurrency {
+		u := testutil.Unstructured(t, resources["secret"])
+		u.SetName(fmt.Sprintf("secret-%d", i))
+		u.SetUID(types.UID(fmt.Sprintf("uid-%d", i)))
+		hash, err := object.Hash(u)
+		require.NoError(t, err)
+		status := actuation.ObjectStatus{
+			ObjectReference: inventory.ObjectReferenceFromObjMetadata(object.UnstructuredToObjMetadata(u)),
+			Strategy:        actuation.ActuationStrategyApply,
+			Actuation:       actuation.ActuationSucceeded,
+			Reconcile:       actuation.ReconcileSucceeded,
+			UID:             u.GetUID(),
+			Hash:            hash,
+		}
+		applyObjs = append(applyObjs, u)
+		prevStatuses = append(prevStatuses, status)
+		objMeta := &metav1.PartialObjectMetadata{}
+		objMeta.APIVersion = u.GetAPIVersion()
+		objMeta.Kind = u.GetKind()
+		objMeta.Name = u.GetName()
+		objMeta.Namespace = u.GetNamespace()
+		objMeta.UID = u.GetUID()
+		// Every other object was modified in the cluster.
+		if i%2 == 1 {
+			objMeta.UID = "replaced"
+		} else {
+			expected = append(expected, status)
+		}
+		clusterObjs = append(clusterObjs, objMeta)
+	}
+
+	// Block the first requests until the maximum number of requests are in
+	// flight, which fails if the objects are not read concurrently.
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	allInFlight := make(chan struct{})
+	var closeOnce sync.Once
+	waitCtx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	metadataClient := &hookedMetadataClient{
+		Interface: metadatafake.NewSimpleMetadataClient(scheme.Scheme, clusterObjs...),
+		onGet: func() {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			if inFlight == unchangedCheckConcurrency {
+				closeOnce.Do(func() { close(allInFlight) })
+			}
+			mu.Unlock()
+			select {
+			case <-allInFlight:
+			case <-waitCtx.Done():
+			}
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		},
+	}
+
+	applier := &Applier{
+		mapper:         testutil.NewFakeRESTMapper(v1.SchemeGroupVersion.WithKind("Secret")),
+		metadataClient: metadataClient,
+	}
+	inv := &inventory.FakeInventory{
+		InventoryContents: inventory.InventoryContents{
+			ObjectStatuses: prevStatuses,
+		},
+	}
+
+	unchanged, err := applier.unchangedObjects(t.Context(), inv, applyObjs, false)
+	require.NoError(t, err)
+	testutil.AssertEqual(t, expected, unchanged)
+	assert.Equal(t, unchangedCheckConcurrency, maxInFlight)
+}
+
+// hookedMetadataClient is a metadata client that calls onGet before each Get.
+// Unlike fake client reactors, onGet may block without blocking other
+// requests.
+type hookedMetadataClient struct {
+	metadata.Interface
+	onGet func()
+}
+
+func (c *hookedMetadataClient) Resource(gvr schema.GroupVersionResource) metadata.Getter {
+	return &hookedMetadataGetter{Getter: c.Interface.Resource(gvr), onGet: c.onGet}
+}
+
+type hookedMetadataGetter struct {
+	metadata.Getter
+	onGet func()
+}
+
+func (g *hookedMetadataGetter) Namespace(ns string) metadata.ResourceInterface {
+	return &hookedMetadataResource{ResourceInterface: g.Getter.Namespace(ns), onGet: g.onGet}
+}
+
+type hookedMetadataResource struct {
+	metadata.ResourceInterface
+	onGet func()
+}
+
+func (r *hookedMetadataResource) Get(ctx context.Context, name string, opts metav1.GetOptions,
+	subresources ...string) (*metav1.PartialObjectMetadata, error) {
+	r.onGet()
+	return r.ResourceInterface.Get(ctx, name, opts, subresources...)
+}
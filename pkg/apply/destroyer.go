@@ -11,8 +11,10 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
@@ -20,25 +22,28 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/apply/solver"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 )
 
 // Destroyer performs the step of grabbing all the previous inventory objects and
 // prune them. This also deletes all the previous inventory objects
 type Destroyer struct {
-	pruner        *prune.Pruner
-	statusWatcher watcher.StatusWatcher
-	invClient     inventory.Client
-	mapper        meta.RESTMapper
-	client        dynamic.Interface
-	openAPIGetter discovery.OpenAPISchemaInterface
-	infoHelper    info.Helper
+	pruner         *prune.Pruner
+	statusWatcher  watcher.StatusWatcher
+	invClient      inventory.Client
+	mapper         meta.RESTMapper
+	client         dynamic.Interface
+	metadataClient metadata.Interface
+	openAPIGetter  discovery.OpenAPISchemaInterface
+	infoHelper     info.Helper
 }
 
 type DestroyerOptions struct {
@@ -53,6 +58,12 @@ type DestroyerOptions struct {
 	// to be fully deleted.
 	DeleteTimeout time.Duration
 
+	// DeleteTimeouts overrides the DeleteTimeout for all resources of a
+	// GroupKind. The timeout of each resource can also be overridden with
+	// the config.kubernetes.io/reconcile-timeout annotation, which takes
+	// precedence.
+	DeleteTimeouts map[schema.GroupKind]time.Duration
+
 	// DeletePropagationPolicy defines the deletion propagation policy
 	// that should be used. If this is not provided, the default is to
 	// use the Background policy.
@@ -64,6 +75,55 @@ type DestroyerOptions struct {
 
 	// ValidationPolicy defines how to handle invalid objects.
 	ValidationPolicy validation.Policy
+
+	// ErrorPolicy defines how to handle objects that fail to be deleted, or
+	// time out waiting to be deleted. With FailFast, the remaining objects
+	// are skipped, but the inventory is still updated.
+	ErrorPolicy ErrorPolicy
+
+	// ParallelPhases defines whether independent sets of objects should be
+	// deleted concurrently. If true, objects only wait for their own
+	// dependents to be deleted, instead of waiting for every object in the
+	// previous phase.
+	ParallelPhases bool
+
+	// DeleteConcurrency defines the maximum number of objects to delete
+	// concurrently within each delete phase. If less than two, objects are
+	// deleted one at a time. Prune filters are still evaluated for each
+	// object and the events of each object are sent in the order of the
+	// objects.
+	DeleteConcurrency int
+
+	// RetryPolicy defines how deleting an object is retried when the server
+	// returns a transient error. Each failed attempt that will be retried is
+	// reported with a RetryEvent. The zero value does not retry.
+	RetryPolicy retry.Policy
+
+	// InferReferences enables the dependencies inferred from object
+	// references, which make objects get deleted before the objects they
+	// reference. Inferred dependencies are reported in the InitEvent.
+	InferReferences bool
+
+	// AllowExternalDependencies allows depends-on annotations to reference
+	// objects that are not in the inventory, so that the objects with them
+	// are deleted. Otherwise, external dependencies are validation errors.
+	AllowExternalDependencies bool
+
+	// LockInventory defines whether a lease on the inventory should be held
+	// for the duration of the run, to prevent concurrent applies and destroys
+	// of the same inventory. If the lease is held by someone else, the run
+	// fails with an ErrorEvent. Ignored for dry runs.
+	LockInventory bool
+
+	// LockOptions configures the inventory lease, if LockInventory is true.
+	LockOptions inventory.LockOptions
+
+	// Hooks are the lifecycle hooks to run. Only the post-destroy hooks are
+	// applied, after all the objects have been deleted, and waited on until
+	// they complete. Other objects are ignored. Use the
+	// config.kubernetes.io/reconcile-timeout annotation to limit how long to
+	// wait for a hook.
+	Hooks object.UnstructuredSet
 }
 
 func setDestroyerDefaults(o *DestroyerOptions) {
@@ -80,6 +140,19 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 	setDestroyerDefaults(&options)
 	go func() {
 		defer close(eventChannel)
+		// Lock the inventory for the duration of the run, so that concurrent
+		// runs do not race on the inventory.
+		// If the lock is lost, the run is cancelled.
+		if options.LockInventory && !options.DryRunStrategy.ClientOrServerDryRun() {
+			lockCtx, unlock, err := lockInventory(ctx, d.client, invInfo, options.LockOptions)
+			if err != nil {
+				handleError(eventChannel, err)
+				return
+			}
+			defer unlock()
+			ctx = lockCtx
+		}
+
 		inv, err := d.invClient.Get(ctx, invInfo, inventory.GetOptions{})
 		if apierrors.IsNotFound(err) {
 			inv, err = d.invClient.NewInventory(invInfo)
@@ -114,6 +187,23 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 		}
 		validator.Validate(deleteObjs)
 
+		// Only the post-destroy hooks are applied, but the others are
+		// validated too.
+		hookObjs, _ := hook.SplitHooks(options.Hooks)
+		validator.Validate(hookObjs)
+		for _, hookObj := range hookObjs {
+			inventory.AddInventoryIDAnnotation(hookObj, inv.Info().GetID())
+		}
+
+		// Find the hooks that are still in the cluster from a previous run,
+		// so they are deleted before they are applied again.
+		prevHookObjs, err := previousHooks(ctx, d.client, d.mapper, invInfo, options.InventoryPolicy, hookObjs)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		klog.V(4).Infof("replacing %d previous hooks", len(prevHookObjs))
+
 		// Build a TaskContext for passing info between tasks
 		resourceCache := cache.NewResourceCacheMap()
 		taskContext := taskrunner.NewTaskContext(ctx, eventChannel, resourceCache)
@@ -131,6 +221,31 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 				DryRunStrategy:    options.DryRunStrategy,
 			},
 		}
+		hookFilters := []filter.ValidationFilter{
+			filter.InventoryPolicyApplyFilter{
+				Client:    d.metadataClient,
+				Mapper:    d.mapper,
+				Inv:       invInfo,
+				InvPolicy: options.InventoryPolicy,
+			},
+			filter.HookFilter{
+				TaskContext: taskContext,
+			},
+		}
+		hookReplaceFilters := []filter.ValidationFilter{
+			filter.HookFilter{
+				TaskContext: taskContext,
+			},
+		}
+		// Skip the remaining objects after any object fails.
+		if options.ErrorPolicy == FailFast {
+			failFastFilter := filter.FailFastFilter{
+				TaskContext: taskContext,
+			}
+			deleteFilters = append([]filter.ValidationFilter{failFastFilter}, deleteFilters...)
+			hookFilters = append([]filter.ValidationFilter{failFastFilter}, hookFilters...)
+			hookReplaceFilters = append([]filter.ValidationFilter{failFastFilter}, hookReplaceFilters...)
+		}
 		taskBuilder := &solver.TaskQueueBuilder{
 			Pruner:        d.pruner,
 			DynamicClient: d.client,
@@ -140,7 +255,10 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 			InvClient:     d.invClient,
 			Inventory:     inv,
 			Collector:     vCollector,
+			ApplyFilters:  hookFilters,
 			PruneFilters:  deleteFilters,
+
+			HookReplaceFilters: hookReplaceFilters,
 		}
 		opts := solver.Options{
 			Destroy:                true,
@@ -148,12 +266,21 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 			DryRunStrategy:         options.DryRunStrategy,
 			PrunePropagationPolicy: options.DeletePropagationPolicy,
 			PruneTimeout:           options.DeleteTimeout,
+			PruneTimeouts:          options.DeleteTimeouts,
 			InventoryPolicy:        options.InventoryPolicy,
+			ParallelPhases:         options.ParallelPhases,
+			PruneConcurrency:       options.DeleteConcurrency,
+			RetryPolicy:            options.RetryPolicy,
+			InferReferences:        options.InferReferences,
+			// Dependencies of deleted objects are not waited for.
+			AllowExternalDependencies: options.AllowExternalDependencies,
 		}
 
 		// Build the ordered set of tasks to execute.
 		taskQueue := taskBuilder.
 			WithPruneObjects(deleteObjs).
+			WithHookObjects(hookObjs).
+			WithPreviousHookObjects(prevHookObjs).
 			Build(taskContext, opts)
 
 		klog.V(4).Infof("validation errors: %d", len(vCollector.Errors))
@@ -186,12 +313,15 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 		eventChannel <- event.Event{
 			Type: event.InitType,
 			InitEvent: event.InitEvent{
-				ActionGroups: taskQueue.ToActionGroups(),
+				ActionGroups:         taskQueue.ToActionGroups(),
+				InferredDependencies: inferredDependencies(taskContext.Graph()),
 			},
 		}
 		// Create a new TaskStatusRunner to execute the taskQueue.
 		klog.V(4).Infoln("destroyer building TaskStatusRunner...")
 		deleteIDs := object.UnstructuredSetToObjMetadataSet(deleteObjs)
+		// Watch the hooks too, to wait for them.
+		deleteIDs = deleteIDs.Union(taskContext.HookObjects())
 		statusWatcher := d.statusWatcher
 		// Disable watcher for dry runs
 		if opts.DryRunStrategy.ClientOrServerDryRun() {
@@ -199,10 +329,10 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 		}
 		runner := taskrunner.NewTaskStatusRunner(deleteIDs, statusWatcher)
 		klog.V(4).Infoln("destroyer running TaskStatusRunner...")
-		err = runner.Run(ctx, taskContext, taskQueue.ToChannel(), taskrunner.Options{
+		err = runner.RunGraph(ctx, taskContext, taskQueue.ToGraph(), taskrunner.Options{
 			EmitStatusEvents: options.EmitStatusEvents,
 		})
-		if err != nil {
+		if err := runError(ctx, err); err != nil {
 			handleError(eventChannel, err)
 			return
 		}
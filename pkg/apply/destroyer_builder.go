@@ -8,6 +8,7 @@ import (
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
@@ -38,12 +39,13 @@ func (b *DestroyerBuilder) Build() (*Destroyer, error) {
 			Client:    bx.client,
 			Mapper:    bx.mapper,
 		},
-		statusWatcher: bx.statusWatcher,
-		invClient:     bx.invClient,
-		mapper:        bx.mapper,
-		client:        bx.client,
-		openAPIGetter: bx.discoClient,
-		infoHelper:    info.NewHelper(bx.mapper, bx.unstructuredClientForMapping),
+		statusWatcher:  bx.statusWatcher,
+		invClient:      bx.invClient,
+		mapper:         bx.mapper,
+		client:         bx.client,
+		metadataClient: bx.metadataClient,
+		openAPIGetter:  bx.discoClient,
+		infoHelper:     info.NewHelper(bx.mapper, bx.unstructuredClientForMapping),
 	}, nil
 }
 
@@ -62,6 +64,11 @@ func (b *DestroyerBuilder) WithDynamicClient(client dynamic.Interface) *Destroye
 	return b
 }
 
+func (b *DestroyerBuilder) WithMetadataClient(client metadata.Interface) *DestroyerBuilder {
+	b.metadataClient = client
+	return b
+}
+
 func (b *DestroyerBuilder) WithDiscoveryClient(discoClient discovery.CachedDiscoveryInterface) *DestroyerBuilder {
 	b.discoClient = discoClient
 	return b
@@ -0,0 +1,23 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+// ErrorPolicy defines how to handle objects that fail to be applied, pruned,
+// deleted, or reconciled.
+//
+//go:generate stringer -type=ErrorPolicy
+type ErrorPolicy int
+
+const (
+	// ContinueOnError policy applies and deletes all the remaining objects
+	// after an object fails. Only the dependents of the failed object are
+	// skipped.
+	ContinueOnError ErrorPolicy = iota
+
+	// FailFast policy skips applying and deleting all the remaining objects
+	// after any object fails to be applied or deleted, fails to reconcile, or
+	// times out. The inventory is still updated, so the skipped objects stay
+	// in the inventory.
+	FailFast
+)
@@ -12,11 +12,12 @@ func _() {
 	_ = x[ApplySuccessful-1]
 	_ = x[ApplySkipped-2]
 	_ = x[ApplyFailed-3]
+	_ = x[ApplyUnchanged-4]
 }
 
-const _ApplyEventStatus_name = "PendingSuccessfulSkippedFailed"
+const _ApplyEventStatus_name = "PendingSuccessfulSkippedFailedUnchanged"
 
-var _ApplyEventStatus_index = [...]uint8{0, 7, 17, 24, 30}
+var _ApplyEventStatus_index = [...]uint8{0, 7, 17, 24, 30, 39}
 
 func (i ApplyEventStatus) String() string {
 	if i < 0 || i >= ApplyEventStatus(len(_ApplyEventStatus_index)-1) {
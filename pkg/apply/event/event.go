@@ -6,6 +6,7 @@ package event
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
@@ -27,6 +28,10 @@ const (
 	DeleteType
 	WaitType
 	ValidationType
+	RollbackType
+	OwnershipType
+	RetryType
+	HookDeleteType
 )
 
 // Event is the type of the objects that will be returned through
@@ -70,6 +75,22 @@ type Event struct {
 
 	// ValidationEvent contains information about validation errors.
 	ValidationEvent ValidationEvent
+
+	// RollbackEvent contains information about objects that have been
+	// restored to their previous state, after failing to reconcile.
+	RollbackEvent RollbackEvent
+
+	// OwnershipEvent contains information about objects that have been
+	// adopted or abandoned by an inventory.
+	OwnershipEvent OwnershipEvent
+
+	// RetryEvent contains information about failed attempts to apply or
+	// delete an object that will be retried.
+	RetryEvent RetryEvent
+
+	// HookDeleteEvent contains information about lifecycle hooks that have
+	// been deleted.
+	HookDeleteEvent HookDeleteEvent
 }
 
 // String returns a string suitable for logging
@@ -94,28 +115,61 @@ func (e Event) String() string {
 		sb.WriteString(e.WaitEvent.String())
 	case ValidationType:
 		sb.WriteString(e.ValidationEvent.String())
+	case RollbackType:
+		sb.WriteString(e.RollbackEvent.String())
+	case OwnershipType:
+		sb.WriteString(e.OwnershipEvent.String())
+	case RetryType:
+		sb.WriteString(e.RetryEvent.String())
+	case HookDeleteType:
+		sb.WriteString(e.HookDeleteEvent.String())
 	}
 	return sb.String()
 }
 
 type InitEvent struct {
 	ActionGroups ActionGroupList
+	// InferredDependencies are the dependencies that were inferred from
+	// object references, rather than declared with annotations.
+	InferredDependencies []InferredDependency
 }
 
 // String returns a string suitable for logging
 func (ie InitEvent) String() string {
-	return fmt.Sprintf("InitEvent{ ActionGroups: %s }", ie.ActionGroups)
+	return fmt.Sprintf("InitEvent{ ActionGroups: %s, InferredDependencies: %v }",
+		ie.ActionGroups, ie.InferredDependencies)
+}
+
+// InferredDependency is a dependency of an object on another object that it
+// references.
+type InferredDependency struct {
+	// Object is the object that depends on the Dependency.
+	Object object.ObjMetadata
+	// Dependency is the object that is applied before, and pruned after,
+	// the Object.
+	Dependency object.ObjMetadata
+	// Field is the path of the field of the Object that references the
+	// Dependency.
+	Field string
+}
+
+// String returns a string suitable for logging
+func (id InferredDependency) String() string {
+	return fmt.Sprintf("InferredDependency{ Object: %s, Dependency: %s, Field: %q }",
+		id.Object, id.Dependency, id.Field)
 }
 
 //go:generate stringer -type=ResourceAction -linecomment
 type ResourceAction int
 
 const (
-	ApplyAction     ResourceAction = iota // Apply
-	PruneAction                           // Prune
-	DeleteAction                          // Delete
-	WaitAction                            // Wait
-	InventoryAction                       // Inventory
+	ApplyAction      ResourceAction = iota // Apply
+	PruneAction                            // Prune
+	DeleteAction                           // Delete
+	WaitAction                             // Wait
+	InventoryAction                        // Inventory
+	HookDeleteAction                       // HookDelete
+	RollbackAction                         // Rollback
 )
 
 type ActionGroupList []ActionGroup
@@ -140,6 +194,11 @@ type ActionGroup struct {
 	Name        string
 	Action      ResourceAction
 	Identifiers object.ObjMetadataSet
+	// DependsOn lists the names of the action groups that must finish before
+	// this action group starts. It is only set when independent action groups
+	// run concurrently. Otherwise, action groups run one after another, in
+	// the order they are listed.
+	DependsOn []string
 }
 
 // String returns a string suitable for logging
@@ -161,21 +220,30 @@ func (ee ErrorEvent) String() string {
 type WaitEventStatus int
 
 const (
-	ReconcilePending    WaitEventStatus = iota // Pending
-	ReconcileSuccessful                        // Successful
-	ReconcileSkipped                           // Skipped
-	ReconcileTimeout                           // Timeout
-	ReconcileFailed                            // Failed
+	ReconcilePending     WaitEventStatus = iota // Pending
+	ReconcileSuccessful                         // Successful
+	ReconcileSkipped                            // Skipped
+	ReconcileTimeout                            // Timeout
+	ReconcileFailed                             // Failed
+	ReconcileStabilizing                        // Stabilizing
 )
 
 type WaitEvent struct {
 	GroupName  string
 	Identifier object.ObjMetadata
 	Status     WaitEventStatus
+	// Remaining is the time the object must remain reconciled before its
+	// stability window has passed. Only set when Status is
+	// ReconcileStabilizing.
+	Remaining time.Duration
 }
 
 // String returns a string suitable for logging
 func (we WaitEvent) String() string {
+	if we.Status == ReconcileStabilizing {
+		return fmt.Sprintf("WaitEvent{ GroupName: %q, Status: %q, Identifier: %q, Remaining: %q }",
+			we.GroupName, we.Status, we.Identifier, we.Remaining)
+	}
 	return fmt.Sprintf("WaitEvent{ GroupName: %q, Status: %q, Identifier: %q }",
 		we.GroupName, we.Status, we.Identifier)
 }
@@ -208,6 +276,7 @@ const (
 	ApplySuccessful                         // Successful
 	ApplySkipped                            // Skipped
 	ApplyFailed                             // Failed
+	ApplyUnchanged                          // Unchanged
 )
 
 type ApplyEvent struct {
@@ -309,6 +378,36 @@ func (de DeleteEvent) String() string {
 		de.GroupName, de.Status, de.Identifier)
 }
 
+//go:generate stringer -type=HookDeleteEventStatus -linecomment
+type HookDeleteEventStatus int
+
+const (
+	HookDeleteSuccessful HookDeleteEventStatus = iota // Successful
+	HookDeleteSkipped                                 // Skipped
+	HookDeleteFailed                                  // Failed
+)
+
+// HookDeleteEvent reports the result of deleting a lifecycle hook. Hooks are
+// not inventory members, so their deletion is reported separately from
+// prune and delete events.
+type HookDeleteEvent struct {
+	GroupName  string
+	Identifier object.ObjMetadata
+	Status     HookDeleteEventStatus
+	Object     *unstructured.Unstructured
+	Error      error
+}
+
+// String returns a string suitable for logging
+func (he HookDeleteEvent) String() string {
+	if he.Error != nil {
+		return fmt.Sprintf("HookDeleteEvent{ GroupName: %q, Status: %q, Identifier: %q, Error: %q }",
+			he.GroupName, he.Status, he.Identifier, he.Error)
+	}
+	return fmt.Sprintf("HookDeleteEvent{ GroupName: %q, Status: %q, Identifier: %q }",
+		he.GroupName, he.Status, he.Identifier)
+}
+
 type ValidationEvent struct {
 	Identifiers object.ObjMetadataSet
 	Error       error
@@ -323,3 +422,89 @@ func (ve ValidationEvent) String() string {
 	return fmt.Sprintf("ValidationEvent{ Identifiers: %+v }",
 		ve.Identifiers)
 }
+
+//go:generate stringer -type=RollbackEventStatus -linecomment
+type RollbackEventStatus int
+
+const (
+	RollbackPending    RollbackEventStatus = iota // Pending
+	RollbackSuccessful                            // Successful
+	RollbackSkipped                               // Skipped
+	RollbackFailed                                // Failed
+)
+
+// RollbackEvent reports the result of restoring an applied object to the
+// state it had before it was applied. Object is the restored object, or nil
+// if the object did not exist before and was deleted.
+type RollbackEvent struct {
+	Identifier object.ObjMetadata
+	Status     RollbackEventStatus
+	Object     *unstructured.Unstructured
+	Error      error
+}
+
+// String returns a string suitable for logging
+func (re RollbackEvent) String() string {
+	if re.Error != nil {
+		return fmt.Sprintf("RollbackEvent{ Status: %q, Identifier: %q, Error: %q }",
+			re.Status, re.Identifier, re.Error)
+	}
+	return fmt.Sprintf("RollbackEvent{ Status: %q, Identifier: %q }",
+		re.Status, re.Identifier)
+}
+
+//go:generate stringer -type=OwnershipOperation -linecomment
+type OwnershipOperation int
+
+const (
+	OwnershipAdopt   OwnershipOperation = iota // Adopt
+	OwnershipAbandon                           // Abandon
+)
+
+//go:generate stringer -type=OwnershipEventStatus -linecomment
+type OwnershipEventStatus int
+
+const (
+	OwnershipSuccessful OwnershipEventStatus = iota // Successful
+	OwnershipSkipped                                // Skipped
+	OwnershipFailed                                 // Failed
+)
+
+// OwnershipEvent reports the result of changing the inventory that owns an
+// object. Owner is the owning-inventory annotation value before the change.
+type OwnershipEvent struct {
+	Identifier object.ObjMetadata
+	Operation  OwnershipOperation
+	Status     OwnershipEventStatus
+	Owner      string
+	Error      error
+}
+
+// String returns a string suitable for logging
+func (oe OwnershipEvent) String() string {
+	if oe.Error != nil {
+		return fmt.Sprintf("OwnershipEvent{ Operation: %q, Status: %q, Identifier: %q, Owner: %q, Error: %q }",
+			oe.Operation, oe.Status, oe.Identifier, oe.Owner, oe.Error)
+	}
+	return fmt.Sprintf("OwnershipEvent{ Operation: %q, Status: %q, Identifier: %q, Owner: %q }",
+		oe.Operation, oe.Status, oe.Identifier, oe.Owner)
+}
+
+// RetryEvent reports a failed attempt to apply, prune, or delete an object,
+// that will be retried after Delay. Attempt is the number of the failed
+// attempt, starting at 1.
+type RetryEvent struct {
+	GroupName   string
+	Identifier  object.ObjMetadata
+	Action      ResourceAction
+	Attempt     int
+	MaxAttempts int
+	Delay       time.Duration
+	Error       error
+}
+
+// String returns a string suitable for logging
+func (re RetryEvent) String() string {
+	return fmt.Sprintf("RetryEvent{ GroupName: %q, Action: %q, Identifier: %q, Attempt: %d/%d, Delay: %s, Error: %q }",
+		re.GroupName, re.Action, re.Identifier, re.Attempt, re.MaxAttempts, re.Delay, re.Error)
+}
@@ -17,11 +17,15 @@ func _() {
 	_ = x[DeleteType-6]
 	_ = x[WaitType-7]
 	_ = x[ValidationType-8]
+	_ = x[RollbackType-9]
+	_ = x[OwnershipType-10]
+	_ = x[RetryType-11]
+	_ = x[HookDeleteType-12]
 }
 
-const _Type_name = "InitTypeErrorTypeActionGroupTypeApplyTypeStatusTypePruneTypeDeleteTypeWaitTypeValidationType"
+const _Type_name = "InitTypeErrorTypeActionGroupTypeApplyTypeStatusTypePruneTypeDeleteTypeWaitTypeValidationTypeRollbackTypeOwnershipTypeRetryTypeHookDeleteType"
 
-var _Type_index = [...]uint8{0, 8, 17, 32, 41, 51, 60, 70, 78, 92}
+var _Type_index = [...]uint8{0, 8, 17, 32, 41, 51, 60, 70, 78, 92, 104, 117, 126, 140}
 
 func (i Type) String() string {
 	if i < 0 || i >= Type(len(_Type_index)-1) {
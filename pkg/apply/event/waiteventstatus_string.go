@@ -13,11 +13,12 @@ func _() {
 	_ = x[ReconcileSkipped-2]
 	_ = x[ReconcileTimeout-3]
 	_ = x[ReconcileFailed-4]
+	_ = x[ReconcileStabilizing-5]
 }
 
-const _WaitEventStatus_name = "PendingSuccessfulSkippedTimeoutFailed"
+const _WaitEventStatus_name = "PendingSuccessfulSkippedTimeoutFailedStabilizing"
 
-var _WaitEventStatus_index = [...]uint8{0, 7, 17, 24, 31, 37}
+var _WaitEventStatus_index = [...]uint8{0, 7, 17, 24, 31, 37, 48}
 
 func (i WaitEventStatus) String() string {
 	if i < 0 || i >= WaitEventStatus(len(_WaitEventStatus_index)-1) {
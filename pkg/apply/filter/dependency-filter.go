@@ -51,6 +51,7 @@ func (dnrf DependencyFilter) Name() string {
 // Typed Errors:
 // - DependencyPreventedActuationError
 // - DependencyActuationMismatchError
+// - ExternalDependencyPreventedActuationError
 func (dnrf DependencyFilter) Filter(_ context.Context, obj *unstructured.Unstructured) error {
 	id := object.UnstructuredToObjMetadata(obj)
 
@@ -63,6 +64,12 @@ func (dnrf DependencyFilter) Filter(_ context.Context, obj *unstructured.Unstruc
 				return err
 			}
 		}
+		for _, depID := range dnrf.TaskContext.Graph().ExternalDependencies(id) {
+			err := dnrf.filterByExternalDependency(id, depID)
+			if err != nil {
+				return err
+			}
+		}
 	case actuation.ActuationStrategyDelete:
 		// For delete, check dependents (incoming)
 		for _, depID := range dnrf.TaskContext.Graph().Dependents(id) {
@@ -181,6 +188,43 @@ func (dnrf DependencyFilter) filterByRelationship(aID, bID object.ObjMetadata, r
 	return nil
 }
 
+// filterByExternalDependency returns an error if the external dependency of
+// the object being applied did not reconcile.
+func (dnrf DependencyFilter) filterByExternalDependency(id, depID object.ObjMetadata) error {
+	status, found := dnrf.TaskContext.ExternalReconcileStatus(depID)
+	if !found {
+		// External dependencies are registered during planning.
+		return NewFatalError(fmt.Errorf("unknown external dependency: %s", depID))
+	}
+
+	// DryRun skips WaitTasks, so reconcile status can be ignored
+	if dnrf.DryRunStrategy.ClientOrServerDryRun() {
+		// Don't skip!
+		return nil
+	}
+
+	switch status {
+	case actuation.ReconcilePending:
+		// If reconcile is still pending, the wait task is probably missing.
+		return NewFatalError(fmt.Errorf("premature apply: external dependency reconcile %s: %s",
+			strings.ToLower(status.String()), depID))
+	case actuation.ReconcileSkipped, actuation.ReconcileFailed, actuation.ReconcileTimeout:
+		// Skip!
+		return &ExternalDependencyPreventedActuationError{
+			Object:                  id,
+			Relation:                depID,
+			RelationReconcileStatus: status,
+		}
+	case actuation.ReconcileSucceeded:
+		// Don't skip!
+		return nil
+	default:
+		// Should never happen
+		return NewFatalError(fmt.Errorf("invalid external dependency reconcile status %q: %s",
+			strings.ToLower(status.String()), depID))
+	}
+}
+
 type DependencyPreventedActuationError struct {
 	Object       object.ObjMetadata
 	Strategy     actuation.ActuationStrategy
@@ -260,3 +304,31 @@ func (e *DependencyActuationMismatchError) Is(err error) bool {
 		e.Relation == tErr.Relation &&
 		e.RelationStrategy == tErr.RelationStrategy
 }
+
+// ExternalDependencyPreventedActuationError is returned when an object is not
+// applied, because a dependency outside the package did not become Current.
+type ExternalDependencyPreventedActuationError struct {
+	Object object.ObjMetadata
+
+	Relation                object.ObjMetadata
+	RelationReconcileStatus actuation.ReconcileStatus
+}
+
+func (e *ExternalDependencyPreventedActuationError) Error() string {
+	return fmt.Sprintf("external dependency reconcile %s: %s",
+		strings.ToLower(e.RelationReconcileStatus.String()),
+		e.Relation)
+}
+
+func (e *ExternalDependencyPreventedActuationError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*ExternalDependencyPreventedActuationError)
+	if !ok {
+		return false
+	}
+	return e.Object == tErr.Object &&
+		e.Relation == tErr.Relation &&
+		e.RelationReconcileStatus == tErr.RelationReconcileStatus
+}
@@ -525,6 +525,55 @@ func TestDependencyFilter(t *testing.T) {
 			id:            idB,
 			expectedError: nil,
 		},
+		"apply A (A -> external B) after B reconcile succeeded": {
+			actuationStrategy: actuation.ActuationStrategyApply,
+			contextSetup: func(taskContext *taskrunner.TaskContext) {
+				taskContext.Graph().AddExternalEdge(idA, idB)
+				taskContext.InventoryManager().AddPendingApply(idA)
+				taskContext.AddExternalObject(idB)
+				_ = taskContext.SetExternalReconcileStatus(idB, actuation.ReconcileSucceeded)
+			},
+			id:            idA,
+			expectedError: nil,
+		},
+		"apply A (A -> external B) after B reconcile timeout": {
+			actuationStrategy: actuation.ActuationStrategyApply,
+			contextSetup: func(taskContext *taskrunner.TaskContext) {
+				taskContext.Graph().AddExternalEdge(idA, idB)
+				taskContext.InventoryManager().AddPendingApply(idA)
+				taskContext.AddExternalObject(idB)
+				_ = taskContext.SetExternalReconcileStatus(idB, actuation.ReconcileTimeout)
+			},
+			id: idA,
+			expectedError: &ExternalDependencyPreventedActuationError{
+				Object:                  idA,
+				Relation:                idB,
+				RelationReconcileStatus: actuation.ReconcileTimeout,
+			},
+		},
+		"apply A (A -> external B) before B is reconciled": {
+			actuationStrategy: actuation.ActuationStrategyApply,
+			contextSetup: func(taskContext *taskrunner.TaskContext) {
+				taskContext.Graph().AddExternalEdge(idA, idB)
+				taskContext.InventoryManager().AddPendingApply(idA)
+				taskContext.AddExternalObject(idB)
+			},
+			id: idA,
+			expectedError: testutil.EqualError(
+				NewFatalError(fmt.Errorf("premature apply: external dependency reconcile pending: %s", idB)),
+			),
+		},
+		"DryRun: apply A (A -> external B) when B reconcile pending": {
+			dryRunStrategy:    common.DryRunClient,
+			actuationStrategy: actuation.ActuationStrategyApply,
+			contextSetup: func(taskContext *taskrunner.TaskContext) {
+				taskContext.Graph().AddExternalEdge(idA, idB)
+				taskContext.InventoryManager().AddPendingApply(idA)
+				taskContext.AddExternalObject(idB)
+			},
+			id:            idA,
+			expectedError: nil,
+		},
 	}
 
 	for name, tc := range tests {
@@ -0,0 +1,55 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+)
+
+// FailFastFilter implements ValidationFilter interface to prevent objects
+// from being applied or deleted after any object failed to be applied or
+// deleted, failed to reconcile, or timed out.
+type FailFastFilter struct {
+	TaskContext *taskrunner.TaskContext
+}
+
+// Name returns a filter identifier for logging.
+func (fff FailFastFilter) Name() string {
+	return "FailFastFilter"
+}
+
+// Filter returns a FailFastPreventedActuationError if the object apply or
+// delete should be skipped.
+func (fff FailFastFilter) Filter(_ context.Context, _ *unstructured.Unstructured) error {
+	im := fff.TaskContext.InventoryManager()
+	failed := len(im.FailedApplies()) + len(im.FailedDeletes()) +
+		len(im.FailedReconciles()) + len(im.TimeoutReconciles())
+	if failed > 0 {
+		return &FailFastPreventedActuationError{Count: failed}
+	}
+	return nil
+}
+
+type FailFastPreventedActuationError struct {
+	Count int
+}
+
+func (e *FailFastPreventedActuationError) Error() string {
+	return fmt.Sprintf("%d objects failed to actuate or reconcile", e.Count)
+}
+
+func (e *FailFastPreventedActuationError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*FailFastPreventedActuationError)
+	if !ok {
+		return false
+	}
+	return e.Count == tErr.Count
+}
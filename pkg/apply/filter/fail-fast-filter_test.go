@@ -0,0 +1,90 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestFailFastFilter(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      "test-cm",
+				"namespace": "test-namespace",
+			},
+		},
+	}
+	tests := map[string]struct {
+		failedApplies []string
+		failedDeletes []string
+		reconciles    map[string]actuation.ReconcileStatus
+		expectedError error
+	}{
+		"No actuated objects, object is not filtered": {},
+		"All objects actuated and reconciled, object is not filtered": {
+			reconciles: map[string]actuation.ReconcileStatus{
+				"name-a": actuation.ReconcileSucceeded,
+				"name-b": actuation.ReconcileSkipped,
+			},
+		},
+		"Object failed to apply, object is filtered": {
+			failedApplies: []string{"name-a"},
+			expectedError: &FailFastPreventedActuationError{Count: 1},
+		},
+		"Object failed to delete, object is filtered": {
+			failedDeletes: []string{"name-a"},
+			expectedError: &FailFastPreventedActuationError{Count: 1},
+		},
+		"Objects timed out and failed to reconcile, object is filtered": {
+			reconciles: map[string]actuation.ReconcileStatus{
+				"name-a": actuation.ReconcileTimeout,
+				"name-b": actuation.ReconcileFailed,
+				"name-c": actuation.ReconcileSucceeded,
+			},
+			expectedError: &FailFastPreventedActuationError{Count: 2},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+			im := taskContext.InventoryManager()
+			for _, objName := range tc.failedApplies {
+				id := idA
+				id.Name = objName
+				im.AddFailedApply(id)
+			}
+			for _, objName := range tc.failedDeletes {
+				id := idA
+				id.Name = objName
+				im.AddFailedDelete(id)
+			}
+			for objName, reconcile := range tc.reconciles {
+				id := idA
+				id.Name = objName
+				im.SetObjectStatus(actuation.ObjectStatus{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(id),
+					Strategy:        actuation.ActuationStrategyApply,
+					Actuation:       actuation.ActuationSucceeded,
+					Reconcile:       reconcile,
+				})
+			}
+
+			filter := FailFastFilter{
+				TaskContext: taskContext,
+			}
+			err := filter.Filter(t.Context(), obj.DeepCopy())
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
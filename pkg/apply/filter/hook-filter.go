@@ -0,0 +1,59 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// HookFilter implements ValidationFilter interface to prevent objects from
+// being applied or deleted after any lifecycle hook failed to be applied,
+// failed to complete, or timed out, or after the previous instance of a hook
+// failed to be deleted.
+type HookFilter struct {
+	TaskContext *taskrunner.TaskContext
+}
+
+// Name returns a filter identifier for logging.
+func (hf HookFilter) Name() string {
+	return "HookFilter"
+}
+
+// Filter returns a HookPreventedActuationError if the object apply or delete
+// should be skipped.
+func (hf HookFilter) Filter(_ context.Context, _ *unstructured.Unstructured) error {
+	im := hf.TaskContext.InventoryManager()
+	for _, id := range hf.TaskContext.HookObjects() {
+		if im.IsFailedApply(id) || im.IsSkippedApply(id) ||
+			im.IsFailedReconcile(id) || im.IsTimeoutReconcile(id) ||
+			im.IsFailedDelete(id) {
+			return &HookPreventedActuationError{HookID: id}
+		}
+	}
+	return nil
+}
+
+type HookPreventedActuationError struct {
+	HookID object.ObjMetadata
+}
+
+func (e *HookPreventedActuationError) Error() string {
+	return fmt.Sprintf("hook failed to complete: %q", e.HookID)
+}
+
+func (e *HookPreventedActuationError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*HookPreventedActuationError)
+	if !ok {
+		return false
+	}
+	return e.HookID == tErr.HookID
+}
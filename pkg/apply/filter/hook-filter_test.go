@@ -0,0 +1,122 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestHookFilter(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      "test-cm",
+				"namespace": "test-namespace",
+			},
+		},
+	}
+	hookID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "batch", Kind: "Job"},
+		Name:      "test-hook",
+		Namespace: "test-namespace",
+	}
+	tests := map[string]struct {
+		hookStatus    *actuation.ObjectStatus
+		failedApplies object.ObjMetadataSet
+		expectedError error
+	}{
+		"Hook pending, object is not filtered": {
+			hookStatus: &actuation.ObjectStatus{
+				Strategy:  actuation.ActuationStrategyApply,
+				Actuation: actuation.ActuationPending,
+				Reconcile: actuation.ReconcilePending,
+			},
+		},
+		"Hook completed, object is not filtered": {
+			hookStatus: &actuation.ObjectStatus{
+				Strategy:  actuation.ActuationStrategyApply,
+				Actuation: actuation.ActuationSucceeded,
+				Reconcile: actuation.ReconcileSucceeded,
+			},
+		},
+		"Other object failed, object is not filtered": {
+			hookStatus: &actuation.ObjectStatus{
+				Strategy:  actuation.ActuationStrategyApply,
+				Actuation: actuation.ActuationSucceeded,
+				Reconcile: actuation.ReconcileSucceeded,
+			},
+			failedApplies: object.ObjMetadataSet{idA},
+		},
+		"Hook failed to apply, object is filtered": {
+			hookStatus: &actuation.ObjectStatus{
+				Strategy:  actuation.ActuationStrategyApply,
+				Actuation: actuation.ActuationFailed,
+				Reconcile: actuation.ReconcileSkipped,
+			},
+			expectedError: &HookPreventedActuationError{HookID: hookID},
+		},
+		"Hook skipped, object is filtered": {
+			hookStatus: &actuation.ObjectStatus{
+				Strategy:  actuation.ActuationStrategyApply,
+				Actuation: actuation.ActuationSkipped,
+				Reconcile: actuation.ReconcileSkipped,
+			},
+			expectedError: &HookPreventedActuationError{HookID: hookID},
+		},
+		"Hook failed to complete, object is filtered": {
+			hookStatus: &actuation.ObjectStatus{
+				Strategy:  actuation.ActuationStrategyApply,
+				Actuation: actuation.ActuationSucceeded,
+				Reconcile: actuation.ReconcileFailed,
+			},
+			expectedError: &HookPreventedActuationError{HookID: hookID},
+		},
+		"Hook timed out, object is filtered": {
+			hookStatus: &actuation.ObjectStatus{
+				Strategy:  actuation.ActuationStrategyApply,
+				Actuation: actuation.ActuationSucceeded,
+				Reconcile: actuation.ReconcileTimeout,
+			},
+			expectedError: &HookPreventedActuationError{HookID: hookID},
+		},
+		"Previous hook failed to be deleted, object is filtered": {
+			hookStatus: &actuation.ObjectStatus{
+				Strategy:  actuation.ActuationStrategyDelete,
+				Actuation: actuation.ActuationFailed,
+				Reconcile: actuation.ReconcileSkipped,
+			},
+			expectedError: &HookPreventedActuationError{HookID: hookID},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+			taskContext.AddHookObject(hookID)
+			im := taskContext.InventoryManager()
+			hookStatus := *tc.hookStatus
+			hookStatus.ObjectReference = inventory.ObjectReferenceFromObjMetadata(hookID)
+			im.SetObjectStatus(hookStatus)
+			for _, id := range tc.failedApplies {
+				im.AddFailedApply(id)
+			}
+
+			filter := HookFilter{
+				TaskContext: taskContext,
+			}
+			err := filter.Filter(t.Context(), obj.DeepCopy())
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+)
+
+// ReconcileFailureFilter implements ValidationFilter interface to prevent
+// objects from being pruned after any applied object failed to reconcile or
+// timed out. This keeps the pruned objects around, so the previous state can
+// be restored by rolling back the apply.
+type ReconcileFailureFilter struct {
+	TaskContext *taskrunner.TaskContext
+}
+
+// Name returns a filter identifier for logging.
+func (rff ReconcileFailureFilter) Name() string {
+	return "ReconcileFailureFilter"
+}
+
+// Filter returns a ReconcileFailurePreventedDeletionError if the object
+// prune/delete should be skipped.
+func (rff ReconcileFailureFilter) Filter(_ context.Context, _ *unstructured.Unstructured) error {
+	im := rff.TaskContext.InventoryManager()
+	failed := len(im.FailedReconciles()) + len(im.TimeoutReconciles())
+	if failed > 0 {
+		return &ReconcileFailurePreventedDeletionError{Count: failed}
+	}
+	return nil
+}
+
+type ReconcileFailurePreventedDeletionError struct {
+	Count int
+}
+
+func (e *ReconcileFailurePreventedDeletionError) Error() string {
+	return fmt.Sprintf("%d applied objects failed to reconcile", e.Count)
+}
+
+func (e *ReconcileFailurePreventedDeletionError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*ReconcileFailurePreventedDeletionError)
+	if !ok {
+		return false
+	}
+	return e.Count == tErr.Count
+}
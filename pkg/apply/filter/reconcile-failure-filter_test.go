@@ -0,0 +1,75 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestReconcileFailureFilter(t *testing.T) {
+	pruneObj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      "test-cm",
+				"namespace": "test-namespace",
+			},
+		},
+	}
+	tests := map[string]struct {
+		reconciles    map[string]actuation.ReconcileStatus
+		expectedError error
+	}{
+		"No applied objects, object is not filtered": {},
+		"All applied objects reconciled, object is not filtered": {
+			reconciles: map[string]actuation.ReconcileStatus{
+				"name-a": actuation.ReconcileSucceeded,
+				"name-b": actuation.ReconcileSkipped,
+			},
+		},
+		"Applied object failed to reconcile, object is filtered": {
+			reconciles: map[string]actuation.ReconcileStatus{
+				"name-a": actuation.ReconcileSucceeded,
+				"name-b": actuation.ReconcileFailed,
+			},
+			expectedError: &ReconcileFailurePreventedDeletionError{Count: 1},
+		},
+		"Applied objects timed out and failed, object is filtered": {
+			reconciles: map[string]actuation.ReconcileStatus{
+				"name-a": actuation.ReconcileTimeout,
+				"name-b": actuation.ReconcileFailed,
+			},
+			expectedError: &ReconcileFailurePreventedDeletionError{Count: 2},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+			for objName, reconcile := range tc.reconciles {
+				id := idA
+				id.Name = objName
+				taskContext.InventoryManager().SetObjectStatus(actuation.ObjectStatus{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(id),
+					Strategy:        actuation.ActuationStrategyApply,
+					Actuation:       actuation.ActuationSucceeded,
+					Reconcile:       reconcile,
+				})
+			}
+
+			filter := ReconcileFailureFilter{
+				TaskContext: taskContext,
+			}
+			err := filter.Filter(t.Context(), pruneObj.DeepCopy())
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
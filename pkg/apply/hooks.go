@@ -0,0 +1,50 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// previousHooks returns the previous instances of the lifecycle hooks that
+// are still in the cluster, so that they can be deleted before the hooks are
+// applied again. Applying an unchanged hook that already completed would not
+// run it again. Hooks that the inventory is not allowed to delete, according
+// to the inventory policy, are ignored.
+func previousHooks(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper,
+	invInfo inventory.Info, policy inventory.Policy, hookObjs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	var prevObjs object.UnstructuredSet
+	for _, obj := range hookObjs {
+		id := object.UnstructuredToObjMetadata(obj)
+		mapping, err := mapper.RESTMapping(id.GroupKind)
+		if err != nil {
+			// Unknown types are reported when the hook is applied.
+			klog.V(4).Infof("previous hook ignored (unknown type): %s: %v", id, err)
+			continue
+		}
+		clusterObj, err := client.Resource(mapping.Resource).Namespace(id.Namespace).
+			Get(ctx, id.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get previous hook from cluster: %w", err)
+		}
+		if canPrune, _ := inventory.CanPrune(invInfo, clusterObj, policy); !canPrune {
+			klog.V(4).Infof("previous hook ignored (not owned by inventory): %s", id)
+			continue
+		}
+		prevObjs = append(prevObjs, clusterObj)
+	}
+	return prevObjs, nil
+}
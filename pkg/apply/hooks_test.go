@@ -0,0 +1,200 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var hookPod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: hook
+  namespace: test-namespace
+  uid: hook-uid
+  annotations:
+    config.kubernetes.io/hook: pre-apply
+spec:
+  restartPolicy: Never
+`
+
+func TestApplierRunHooksTwice(t *testing.T) {
+	invObj := newInventoryObj(
+		inventory.NewSingleObjectInfo("test-app-label", types.NamespacedName{
+			Name:      "test-inventory-obj",
+			Namespace: "test-namespace",
+		}),
+		nil,
+	)
+	invInfo, err := inventory.ConfigMapToInventoryInfo(invObj)
+	require.NoError(t, err)
+	hookObj := testutil.Unstructured(t, hookPod)
+	hookID := object.UnstructuredToObjMetadata(hookObj)
+
+	// The first apply creates the hook, which is kept after it completes,
+	// because of the default delete policy.
+	events := runHooks(t, invObj, hookObj, nil)
+	testutil.AssertEqual(t, []testutil.ExpEvent{
+		{
+			EventType: event.ApplyType,
+			ApplyEvent: &testutil.ExpApplyEvent{
+				GroupName:  "apply-0",
+				Status:     event.ApplySuccessful,
+				Identifier: hookID,
+			},
+		},
+		{
+			EventType: event.WaitType,
+			WaitEvent: &testutil.ExpWaitEvent{
+				GroupName:  "wait-0",
+				Status:     event.ReconcileSuccessful,
+				Identifier: hookID,
+			},
+		},
+	}, hookEvents(events))
+
+	// The second apply deletes the hook from the first apply, so that
+	// applying the hook again runs it again.
+	prevHookObj := hookObj.DeepCopy()
+	inventory.AddInventoryIDAnnotation(prevHookObj, invInfo.GetID())
+	events = runHooks(t, invObj, hookObj, object.UnstructuredSet{prevHookObj})
+	testutil.AssertEqual(t, []testutil.ExpEvent{
+		{
+			EventType: event.HookDeleteType,
+			HookDeleteEvent: &testutil.ExpHookDeleteEvent{
+				GroupName:  "hook-delete-0",
+				Status:     event.HookDeleteSuccessful,
+				Identifier: hookID,
+			},
+		},
+		{
+			EventType: event.WaitType,
+			WaitEvent: &testutil.ExpWaitEvent{
+				GroupName:  "wait-0",
+				Status:     event.ReconcileSuccessful,
+				Identifier: hookID,
+			},
+		},
+		{
+			EventType: event.ApplyType,
+			ApplyEvent: &testutil.ExpApplyEvent{
+				GroupName:  "apply-0",
+				Status:     event.ApplySuccessful,
+				Identifier: hookID,
+			},
+		},
+		{
+			EventType: event.WaitType,
+			WaitEvent: &testutil.ExpWaitEvent{
+				GroupName:  "wait-1",
+				Status:     event.ReconcileSuccessful,
+				Identifier: hookID,
+			},
+		},
+	}, hookEvents(events))
+}
+
+// runHooks applies the hook and returns the events. The hook is reported as
+// deleted when a hook delete task finishes, and as completed when the apply
+// task finishes.
+func runHooks(t *testing.T, invObj, hookObj *unstructured.Unstructured, clusterObjs object.UnstructuredSet) []event.Event {
+	statusWatcher := &chanWatcher{events: make(chan pollevent.Event, 10)}
+	objs := object.UnstructuredSet{hookObj}
+	applier := newTestApplier(t, invObj, objs, clusterObjs, statusWatcher)
+	invInfo, err := inventory.ConfigMapToInventoryInfo(invObj)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	var events []event.Event
+	for e := range applier.Run(ctx, invInfo, objs, ApplierOptions{}) {
+		events = append(events, e)
+		if e.Type != event.ActionGroupType || e.ActionGroupEvent.Status != event.Finished {
+			continue
+		}
+		switch e.ActionGroupEvent.Action {
+		case event.HookDeleteAction:
+			statusWatcher.events <- pollevent.Event{
+				Type: pollevent.ResourceUpdateEvent,
+				Resource: &pollevent.ResourceStatus{
+					Identifier: object.UnstructuredToObjMetadata(hookObj),
+					Status:     status.NotFoundStatus,
+				},
+			}
+		case event.ApplyAction:
+			completed := hookObj.DeepCopy()
+			require.NoError(t, unstructured.SetNestedField(completed.Object, "Succeeded", "status", "phase"))
+			statusWatcher.events <- pollevent.Event{
+				Type: pollevent.ResourceUpdateEvent,
+				Resource: &pollevent.ResourceStatus{
+					Identifier: object.UnstructuredToObjMetadata(hookObj),
+					Status:     status.CurrentStatus,
+					Resource:   completed,
+				},
+			}
+		}
+	}
+	require.NoError(t, ctx.Err())
+	return events
+}
+
+// hookEvents returns the apply, wait and hook delete events.
+func hookEvents(events []event.Event) []testutil.ExpEvent {
+	var expEvents []testutil.ExpEvent
+	for _, e := range testutil.EventsToExpEvents(events) {
+		switch e.EventType {
+		case event.ApplyType, event.HookDeleteType:
+			expEvents = append(expEvents, e)
+		case event.WaitType:
+			if e.WaitEvent.Status != event.ReconcilePending {
+				expEvents = append(expEvents, e)
+			}
+		}
+	}
+	return expEvents
+}
+
+// chanWatcher is a StatusWatcher that sends the status events that are sent
+// on its events channel.
+type chanWatcher struct {
+	events chan pollevent.Event
+}
+
+var _ watcher.StatusWatcher = &chanWatcher{}
+
+func (w *chanWatcher) Watch(ctx context.Context, _ object.ObjMetadataSet, _ watcher.Options) <-chan pollevent.Event {
+	eventChannel := make(chan pollevent.Event)
+	go func() {
+		defer close(eventChannel)
+		// send sync event immediately
+		eventChannel <- pollevent.Event{Type: pollevent.SyncEvent}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-w.events:
+				select {
+				case <-ctx.Done():
+					return
+				case eventChannel <- e:
+				}
+			}
+		}
+	}()
+	return eventChannel
+}
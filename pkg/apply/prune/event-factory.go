@@ -5,6 +5,8 @@
 package prune
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
@@ -16,6 +18,7 @@ type EventFactory interface {
 	CreateSuccessEvent(obj *unstructured.Unstructured) event.Event
 	CreateSkippedEvent(obj *unstructured.Unstructured, err error) event.Event
 	CreateFailedEvent(id object.ObjMetadata, err error) event.Event
+	CreateRetryEvent(id object.ObjMetadata, attempt, maxAttempts int, delay time.Duration, err error) event.Event
 }
 
 // CreateEventFactory returns the correct concrete version of
@@ -76,6 +79,22 @@ func (pef PruneEventFactory) CreateFailedEvent(id object.ObjMetadata, err error)
 	}
 }
 
+func (pef PruneEventFactory) CreateRetryEvent(id object.ObjMetadata, attempt, maxAttempts int,
+	delay time.Duration, err error) event.Event {
+	return event.Event{
+		Type: event.RetryType,
+		RetryEvent: event.RetryEvent{
+			GroupName:   pef.groupName,
+			Identifier:  id,
+			Action:      event.PruneAction,
+			Attempt:     attempt,
+			MaxAttempts: maxAttempts,
+			Delay:       delay,
+			Error:       err,
+		},
+	}
+}
+
 // DeleteEventFactory implements EventFactory interface as a concrete
 // representation of for delete events.
 type DeleteEventFactory struct {
@@ -118,3 +137,19 @@ func (def DeleteEventFactory) CreateFailedEvent(id object.ObjMetadata, err error
 		},
 	}
 }
+
+func (def DeleteEventFactory) CreateRetryEvent(id object.ObjMetadata, attempt, maxAttempts int,
+	delay time.Duration, err error) event.Event {
+	return event.Event{
+		Type: event.RetryType,
+		RetryEvent: event.RetryEvent{
+			GroupName:   def.groupName,
+			Identifier:  id,
+			Action:      event.DeleteAction,
+			Attempt:     attempt,
+			MaxAttempts: maxAttempts,
+			Delay:       delay,
+			Error:       err,
+		},
+	}
+}
@@ -14,6 +14,7 @@ package prune
 import (
 	"context"
 	"errors"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -22,7 +23,9 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
@@ -68,6 +71,16 @@ type Options struct {
 	// True if we are destroying, which deletes the inventory object
 	// as well (possibly) the inventory namespace.
 	Destroy bool
+
+	// Concurrency is the maximum number of objects to delete concurrently.
+	// Prune filters are evaluated per object, as when deleting sequentially,
+	// and events are still sent in object order, except retry events,
+	// which are sent immediately. Zero or one deletes objects sequentially.
+	Concurrency int
+
+	// RetryPolicy defines how deleting an object is retried when the server
+	// returns a transient error. The zero value does not retry.
+	RetryPolicy retry.Policy
 }
 
 // Prune deletes the set of passed objects. A prune skip/failure is
@@ -96,88 +109,112 @@ func (p *Pruner) Prune(
 	eventFactory := CreateEventFactory(opts.Destroy, taskName)
 	// Iterate through objects to prune (delete). If an object is not pruned
 	// and we need to keep it in the inventory, we must capture the prune failure.
+	if opts.Concurrency > 1 {
+		taskContext.RunConcurrently(opts.Concurrency, objs,
+			func(obj *unstructured.Unstructured, eventChannel chan<- event.Event) {
+				p.pruneObject(taskContext, obj, pruneFilters, eventFactory, opts, eventChannel)
+			})
+		return nil
+	}
 	for _, obj := range objs {
-		id := object.UnstructuredToObjMetadata(obj)
-		klog.V(5).Infof("evaluating prune filters (object: %q)", id)
+		p.pruneObject(taskContext, obj, pruneFilters, eventFactory, opts, taskContext.EventChannel())
+	}
+	return nil
+}
 
-		// UID will change if the object is deleted and re-created.
-		uid := obj.GetUID()
-		if uid == "" {
-			err := object.NotFound([]any{"metadata", "uid"}, "")
-			if klog.V(4).Enabled() {
-				// only log event emitted errors if the verbosity > 4
-				klog.Errorf("prune uid lookup errored (object: %s): %v", id, err)
-			}
-			taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
-			taskContext.InventoryManager().AddFailedDelete(id)
-			continue
+// pruneObject evaluates the prune filters for one object and deletes it if
+// permitted, sending its events to the event channel and recording the
+// result in the inventory manager.
+func (p *Pruner) pruneObject(
+	taskContext *taskrunner.TaskContext,
+	obj *unstructured.Unstructured,
+	pruneFilters []filter.ValidationFilter,
+	eventFactory EventFactory,
+	opts Options,
+	eventChannel chan<- event.Event,
+) {
+	id := object.UnstructuredToObjMetadata(obj)
+	klog.V(5).Infof("evaluating prune filters (object: %q)", id)
+
+	// UID will change if the object is deleted and re-created.
+	uid := obj.GetUID()
+	if uid == "" {
+		err := object.NotFound([]any{"metadata", "uid"}, "")
+		if klog.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			klog.Errorf("prune uid lookup errored (object: %s): %v", id, err)
 		}
+		eventChannel <- eventFactory.CreateFailedEvent(id, err)
+		taskContext.InventoryManager().AddFailedDelete(id)
+		return
+	}
 
-		// Check filters to see if we're prevented from pruning/deleting object.
-		var filterErr error
-		for _, pruneFilter := range pruneFilters {
-			klog.V(6).Infof("prune filter evaluating (filter: %s, object: %s)", pruneFilter.Name(), id)
-			filterErr = pruneFilter.Filter(taskContext.Context(), obj)
-			if filterErr != nil {
-				var fatalErr *filter.FatalError
-				if errors.As(filterErr, &fatalErr) {
-					if klog.V(4).Enabled() {
-						// only log event emitted errors if the verbosity > 4
-						klog.Errorf("prune filter errored (filter: %s, object: %s): %v", pruneFilter.Name(), id, fatalErr.Err)
-					}
-					taskContext.SendEvent(eventFactory.CreateFailedEvent(id, fatalErr.Err))
-					taskContext.InventoryManager().AddFailedDelete(id)
-					break
+	// Check filters to see if we're prevented from pruning/deleting object.
+	var filterErr error
+	for _, pruneFilter := range pruneFilters {
+		klog.V(6).Infof("prune filter evaluating (filter: %s, object: %s)", pruneFilter.Name(), id)
+		filterErr = pruneFilter.Filter(taskContext.Context(), obj)
+		if filterErr != nil {
+			var fatalErr *filter.FatalError
+			if errors.As(filterErr, &fatalErr) {
+				if klog.V(4).Enabled() {
+					// only log event emitted errors if the verbosity > 4
+					klog.Errorf("prune filter errored (filter: %s, object: %s): %v", pruneFilter.Name(), id, fatalErr.Err)
 				}
-				klog.V(4).Infof("prune filtered (filter: %s, object: %s): %v", pruneFilter.Name(), id, filterErr)
+				eventChannel <- eventFactory.CreateFailedEvent(id, fatalErr.Err)
+				taskContext.InventoryManager().AddFailedDelete(id)
+				break
+			}
+			klog.V(4).Infof("prune filtered (filter: %s, object: %s): %v", pruneFilter.Name(), id, filterErr)
 
-				// Remove the inventory annotation if deletion was prevented.
-				// This abandons the object so it won't be pruned by future applier runs.
-				var abandonErr *filter.AnnotationPreventedDeletionError
-				if errors.As(filterErr, &abandonErr) {
-					if !opts.DryRunStrategy.ClientOrServerDryRun() {
-						var err error
-						obj, err = p.removeInventoryAnnotation(taskContext.Context(), obj)
-						if err != nil {
-							if klog.V(4).Enabled() {
-								// only log event emitted errors if the verbosity > 4
-								klog.Errorf("error removing annotation (object: %q, annotation: %q): %v", id, inventory.OwningInventoryKey, err)
-							}
-							taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
-							taskContext.InventoryManager().AddFailedDelete(id)
-							break
+			// Remove the inventory annotation if deletion was prevented.
+			// This abandons the object so it won't be pruned by future applier runs.
+			var abandonErr *filter.AnnotationPreventedDeletionError
+			if errors.As(filterErr, &abandonErr) {
+				if !opts.DryRunStrategy.ClientOrServerDryRun() {
+					var err error
+					obj, err = p.removeInventoryAnnotation(taskContext.Context(), obj)
+					if err != nil {
+						if klog.V(4).Enabled() {
+							// only log event emitted errors if the verbosity > 4
+							klog.Errorf("error removing annotation (object: %q, annotation: %q): %v", id, inventory.OwningInventoryKey, err)
 						}
-						// Inventory annotation was successfully removed from the object.
-						// Register for removal from the inventory.
-						taskContext.AddAbandonedObject(id)
+						eventChannel <- eventFactory.CreateFailedEvent(id, err)
+						taskContext.InventoryManager().AddFailedDelete(id)
+						break
 					}
+					// Inventory annotation was successfully removed from the object.
+					// Register for removal from the inventory.
+					taskContext.AddAbandonedObject(id)
 				}
+			}
 
-				// Remove the object from inventory if it was determined that the object should not be pruned,
-				// because it had recently been applied. This probably means that the object is in the inventory
-				// more than one time with a different group (e.g. kind Ingress and apiGroups networking.k8s.io & extensions)
-				// due to being cohabitated: https://github.com/kubernetes/kubernetes/blob/v1.25.0/pkg/kubeapiserver/default_storage_factory_builder.go#L124-L131
-				var deleteAfterApplyErr *filter.ApplyPreventedDeletionError
-				if errors.As(filterErr, &deleteAfterApplyErr) {
-					if !opts.DryRunStrategy.ClientOrServerDryRun() {
-						// Register for removal from the inventory.
-						taskContext.AddAbandonedObject(id)
-					}
+			// Remove the object from inventory if it was determined that the object should not be pruned,
+			// because it had recently been applied. This probably means that the object is in the inventory
+			// more than one time with a different group (e.g. kind Ingress and apiGroups networking.k8s.io & extensions)
+			// due to being cohabitated: https://github.com/kubernetes/kubernetes/blob/v1.25.0/pkg/kubeapiserver/default_storage_factory_builder.go#L124-L131
+			var deleteAfterApplyErr *filter.ApplyPreventedDeletionError
+			if errors.As(filterErr, &deleteAfterApplyErr) {
+				if !opts.DryRunStrategy.ClientOrServerDryRun() {
+					// Register for removal from the inventory.
+					taskContext.AddAbandonedObject(id)
 				}
-
-				taskContext.SendEvent(eventFactory.CreateSkippedEvent(obj, filterErr))
-				taskContext.InventoryManager().AddSkippedDelete(id)
-				break
 			}
+
+			eventChannel <- eventFactory.CreateSkippedEvent(obj, filterErr)
+			taskContext.InventoryManager().AddSkippedDelete(id)
+			break
 		}
-		if filterErr != nil {
-			continue
-		}
+	}
+	if filterErr != nil {
+		return
+	}
 
-		// Filters passed--actually delete object if not dry run.
-		if !opts.DryRunStrategy.ClientOrServerDryRun() {
-			klog.V(4).Infof("deleting object (object: %q)", id)
-			err := p.deleteObject(taskContext.Context(), id, metav1.DeleteOptions{
+	// Filters passed--actually delete object if not dry run.
+	if !opts.DryRunStrategy.ClientOrServerDryRun() {
+		klog.V(4).Infof("deleting object (object: %q)", id)
+		err := opts.RetryPolicy.Do(taskContext.Context(), func() error {
+			return p.deleteObject(taskContext.Context(), id, metav1.DeleteOptions{
 				// Only delete the resource if it hasn't already been deleted
 				// and recreated since the last GET. Otherwise error.
 				Preconditions: &metav1.Preconditions{
@@ -185,25 +222,27 @@ func (p *Pruner) Prune(
 				},
 				PropagationPolicy: &opts.PropagationPolicy,
 			})
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					klog.Warningf("error deleting object (object: %q): object not found: object may have been deleted asynchronously by another client", id)
-					// treat this as successful idempotent deletion
-				} else {
-					if klog.V(4).Enabled() {
-						// only log event emitted errors if the verbosity > 4
-						klog.Errorf("error deleting object (object: %q): %v", id, err)
-					}
-					taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
-					taskContext.InventoryManager().AddFailedDelete(id)
-					continue
+		}, func(attempt int, delay time.Duration, err error) {
+			klog.V(4).Infof("delete failed, retrying in %s (object: %q, attempt: %d): %v", delay, id, attempt, err)
+			eventChannel <- eventFactory.CreateRetryEvent(id, attempt, opts.RetryPolicy.MaxAttempts, delay, err)
+		})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				klog.Warningf("error deleting object (object: %q): object not found: object may have been deleted asynchronously by another client", id)
+				// treat this as successful idempotent deletion
+			} else {
+				if klog.V(4).Enabled() {
+					// only log event emitted errors if the verbosity > 4
+					klog.Errorf("error deleting object (object: %q): %v", id, err)
 				}
+				eventChannel <- eventFactory.CreateFailedEvent(id, err)
+				taskContext.InventoryManager().AddFailedDelete(id)
+				return
 			}
 		}
-		taskContext.InventoryManager().AddSuccessfulDelete(id, obj.GetUID())
-		taskContext.SendEvent(eventFactory.CreateSuccessEvent(obj))
 	}
-	return nil
+	taskContext.InventoryManager().AddSuccessfulDelete(id, obj.GetUID())
+	eventChannel <- eventFactory.CreateSuccessEvent(obj)
 }
 
 // removeInventoryAnnotation removes the `config.k8s.io/owning-inventory` annotation from pruneObj.
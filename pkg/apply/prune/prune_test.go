@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
@@ -565,76 +567,81 @@ func TestPrune(t *testing.T) {
 	}
 
 	for name, tc := range tests {
-		t.Run(name, func(t *testing.T) {
-			// Set up the fake dynamic client to recognize all objects, and the RESTMapper.
-			clusterObjs := make([]runtime.Object, 0, len(tc.clusterObjs))
-			for _, obj := range tc.clusterObjs {
-				clusterObjs = append(clusterObjs, obj)
-			}
-			pruneIDs := object.UnstructuredSetToObjMetadataSet(tc.pruneObjs)
-			po := Pruner{
-				InvClient: inventory.NewFakeClient(pruneIDs),
-				Client:    fake.NewSimpleDynamicClient(scheme.Scheme, clusterObjs...),
-				Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
-					scheme.Scheme.PrioritizedVersionsAllGroups()...),
-			}
-			// The event channel can not block; make sure its bigger than all
-			// the events that can be put on it.
-			eventChannel := make(chan event.Event, len(tc.pruneObjs)+1)
-			resourceCache := cache.NewResourceCacheMap()
-			taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
-			taskName := "test-0"
-			err := func() error {
-				defer close(eventChannel)
-				// Run the prune and validate.
-				return po.Prune(taskContext, tc.pruneObjs, tc.pruneFilters, taskName, tc.options)
-			}()
+		// Every case must behave the same when deleting concurrently.
+		for _, concurrency := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%s/concurrency=%d", name, concurrency), func(t *testing.T) {
+				// Set up the fake dynamic client to recognize all objects, and the RESTMapper.
+				clusterObjs := make([]runtime.Object, 0, len(tc.clusterObjs))
+				for _, obj := range tc.clusterObjs {
+					clusterObjs = append(clusterObjs, obj)
+				}
+				pruneIDs := object.UnstructuredSetToObjMetadataSet(tc.pruneObjs)
+				po := Pruner{
+					InvClient: inventory.NewFakeClient(pruneIDs),
+					Client:    fake.NewSimpleDynamicClient(scheme.Scheme, clusterObjs...),
+					Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+						scheme.Scheme.PrioritizedVersionsAllGroups()...),
+				}
+				// The event channel can not block; make sure its bigger than all
+				// the events that can be put on it.
+				eventChannel := make(chan event.Event, len(tc.pruneObjs)+1)
+				resourceCache := cache.NewResourceCacheMap()
+				taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
+				taskName := "test-0"
+				opts := tc.options
+				opts.Concurrency = concurrency
+				err := func() error {
+					defer close(eventChannel)
+					// Run the prune and validate.
+					return po.Prune(taskContext, tc.pruneObjs, tc.pruneFilters, taskName, opts)
+				}()
 
-			if err != nil {
-				t.Fatalf("Unexpected error during Prune(): %#v", err)
-			}
-			var actualEvents []event.Event
-			for e := range eventChannel {
-				actualEvents = append(actualEvents, e)
-			}
-			// Inject expected GroupName for event comparison
-			for i := range tc.expectedEvents {
-				switch tc.expectedEvents[i].Type {
-				case event.ApplyType:
-					tc.expectedEvents[i].ApplyEvent.GroupName = taskName
-				case event.DeleteType:
-					tc.expectedEvents[i].DeleteEvent.GroupName = taskName
-				case event.PruneType:
-					tc.expectedEvents[i].PruneEvent.GroupName = taskName
+				if err != nil {
+					t.Fatalf("Unexpected error during Prune(): %#v", err)
 				}
-			}
-			// Validate the expected/actual events
-			testutil.AssertEqual(t, tc.expectedEvents, actualEvents)
+				var actualEvents []event.Event
+				for e := range eventChannel {
+					actualEvents = append(actualEvents, e)
+				}
+				// Inject expected GroupName for event comparison
+				for i := range tc.expectedEvents {
+					switch tc.expectedEvents[i].Type {
+					case event.ApplyType:
+						tc.expectedEvents[i].ApplyEvent.GroupName = taskName
+					case event.DeleteType:
+						tc.expectedEvents[i].DeleteEvent.GroupName = taskName
+					case event.PruneType:
+						tc.expectedEvents[i].PruneEvent.GroupName = taskName
+					}
+				}
+				// Validate the expected/actual events
+				testutil.AssertEqual(t, tc.expectedEvents, actualEvents)
 
-			im := taskContext.InventoryManager()
+				im := taskContext.InventoryManager()
 
-			// validate record of failed prunes
-			for _, id := range tc.expectedFailed {
-				assert.Truef(t, im.IsFailedDelete(id), "Prune() should mark object as failed: %s", id)
-			}
-			for _, id := range pruneIDs.Diff(tc.expectedFailed) {
-				assert.Falsef(t, im.IsFailedDelete(id), "Prune() should NOT mark object as failed: %s", id)
-			}
-			// validate record of skipped prunes
-			for _, id := range tc.expectedSkipped {
-				assert.Truef(t, im.IsSkippedDelete(id), "Prune() should mark object as skipped: %s", id)
-			}
-			for _, id := range pruneIDs.Diff(tc.expectedSkipped) {
-				assert.Falsef(t, im.IsSkippedDelete(id), "Prune() should NOT mark object as skipped: %s", id)
-			}
-			// validate record of abandoned objects
-			for _, id := range tc.expectedAbandoned {
-				assert.Truef(t, taskContext.IsAbandonedObject(id), "Prune() should mark object as abandoned: %s", id)
-			}
-			for _, id := range pruneIDs.Diff(tc.expectedAbandoned) {
-				assert.Falsef(t, taskContext.IsAbandonedObject(id), "Prune() should NOT mark object as abandoned: %s", id)
-			}
-		})
+				// validate record of failed prunes
+				for _, id := range tc.expectedFailed {
+					assert.Truef(t, im.IsFailedDelete(id), "Prune() should mark object as failed: %s", id)
+				}
+				for _, id := range pruneIDs.Diff(tc.expectedFailed) {
+					assert.Falsef(t, im.IsFailedDelete(id), "Prune() should NOT mark object as failed: %s", id)
+				}
+				// validate record of skipped prunes
+				for _, id := range tc.expectedSkipped {
+					assert.Truef(t, im.IsSkippedDelete(id), "Prune() should mark object as skipped: %s", id)
+				}
+				for _, id := range pruneIDs.Diff(tc.expectedSkipped) {
+					assert.Falsef(t, im.IsSkippedDelete(id), "Prune() should NOT mark object as skipped: %s", id)
+				}
+				// validate record of abandoned objects
+				for _, id := range tc.expectedAbandoned {
+					assert.Truef(t, taskContext.IsAbandonedObject(id), "Prune() should mark object as abandoned: %s", id)
+				}
+				for _, id := range pruneIDs.Diff(tc.expectedAbandoned) {
+					assert.Falsef(t, taskContext.IsAbandonedObject(id), "Prune() should NOT mark object as abandoned: %s", id)
+				}
+			})
+		}
 	}
 }
 
@@ -987,6 +994,124 @@ func TestPrune_PropagationPolicy(t *testing.T) {
 	}
 }
 
+// flakyNamespaceClient fails the first deletes with the given errors.
+type flakyNamespaceClient struct {
+	dynamic.ResourceInterface
+	errs    []error
+	deletes int
+}
+
+func (c *flakyNamespaceClient) Delete(_ context.Context, _ string, _ metav1.DeleteOptions, _ ...string) error {
+	c.deletes++
+	if c.deletes <= len(c.errs) {
+		return c.errs[c.deletes-1]
+	}
+	return nil
+}
+
+func TestPrune_Retry(t *testing.T) {
+	transient := apierrors.NewTooManyRequests("slow down", 0)
+	pdbID := object.UnstructuredToObjMetadata(pdb)
+	testCases := map[string]struct {
+		errs            []error
+		expectedDeletes int
+		expectedEvents  []testutil.ExpEvent
+	}{
+		"success after retry": {
+			errs:            []error{transient},
+			expectedDeletes: 2,
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.RetryType,
+					RetryEvent: &testutil.ExpRetryEvent{
+						Action:     event.DeleteAction,
+						Identifier: pdbID,
+						Attempt:    1,
+						Error:      transient,
+					},
+				},
+				{
+					EventType: event.DeleteType,
+					DeleteEvent: &testutil.ExpDeleteEvent{
+						Identifier: pdbID,
+						Status:     event.DeleteSuccessful,
+					},
+				},
+			},
+		},
+		"max attempts reached": {
+			errs:            []error{transient, transient, transient},
+			expectedDeletes: 2,
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.RetryType,
+					RetryEvent: &testutil.ExpRetryEvent{
+						Action:     event.DeleteAction,
+						Identifier: pdbID,
+						Attempt:    1,
+						Error:      transient,
+					},
+				},
+				{
+					EventType: event.DeleteType,
+					DeleteEvent: &testutil.ExpDeleteEvent{
+						Identifier: pdbID,
+						Status:     event.DeleteFailed,
+						Error:      transient,
+					},
+				},
+			},
+		},
+		"error not retryable": {
+			errs:            []error{fmt.Errorf("expected delete error")},
+			expectedDeletes: 1,
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.DeleteType,
+					DeleteEvent: &testutil.ExpDeleteEvent{
+						Identifier: pdbID,
+						Status:     event.DeleteFailed,
+						Error:      fmt.Errorf("expected delete error"),
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			flakyClient := &flakyNamespaceClient{errs: tc.errs}
+			po := Pruner{
+				InvClient: inventory.NewFakeClient(object.ObjMetadataSet{pdbID}),
+				Client: &fakeDynamicClient{
+					resourceInterface: flakyClient,
+				},
+				Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+					scheme.Scheme.PrioritizedVersionsAllGroups()...),
+			}
+
+			eventChannel := make(chan event.Event, len(tc.expectedEvents))
+			resourceCache := cache.NewResourceCacheMap()
+			taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
+			opts := defaultOptionsDestroy
+			opts.RetryPolicy = retry.Policy{
+				MaxAttempts:    2,
+				InitialBackoff: time.Millisecond,
+			}
+			err := po.Prune(taskContext, []*unstructured.Unstructured{pdb}, []filter.ValidationFilter{}, "test-0", opts)
+			require.NoError(t, err)
+			close(eventChannel)
+
+			var actualEvents []event.Event
+			for e := range eventChannel {
+				actualEvents = append(actualEvents, e)
+			}
+			require.Len(t, actualEvents, len(tc.expectedEvents))
+			assert.NoError(t, testutil.VerifyEvents(tc.expectedEvents, actualEvents))
+			assert.Equal(t, tc.expectedDeletes, flakyClient.deletes)
+		})
+	}
+}
+
 type fakeDynamicClient struct {
 	resourceInterface dynamic.ResourceInterface
 }
@@ -0,0 +1,198 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retry defines the policy used to retry applying and deleting
+// objects when the server returns a transient error.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultInitialBackoff is the default delay before the first retry.
+	DefaultInitialBackoff = time.Second
+	// DefaultMaxBackoff is the default maximum delay between retries.
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// ErrorClass identifies a class of transient errors that can be retried.
+type ErrorClass string
+
+const (
+	// TooManyRequests matches 429 errors returned when the server, or the
+	// server's flow control, throttles requests.
+	TooManyRequests ErrorClass = "TooManyRequests"
+	// ServerError matches 5xx errors.
+	ServerError ErrorClass = "ServerError"
+	// Conflict matches 409 errors returned when an object was modified
+	// concurrently. Server-side apply field ownership conflicts and failed
+	// preconditions are not matched, because they fail again when retried.
+	Conflict ErrorClass = "Conflict"
+	// Timeout matches server timeouts, and admission webhooks that could not
+	// be called in time.
+	Timeout ErrorClass = "Timeout"
+)
+
+// ErrorClasses returns all the error classes.
+func ErrorClasses() []ErrorClass {
+	return []ErrorClass{TooManyRequests, ServerError, Conflict, Timeout}
+}
+
+// ParseErrorClass returns the error class with the given name, ignoring
+// case.
+func ParseErrorClass(name string) (ErrorClass, error) {
+	for _, class := range ErrorClasses() {
+		if strings.EqualFold(name, string(class)) {
+			return class, nil
+		}
+	}
+	return "", fmt.Errorf("unknown retry error class %q: must be one of %v", name, ErrorClasses())
+}
+
+// Matches returns true if the error belongs to the class.
+func (c ErrorClass) Matches(err error) bool {
+	switch c {
+	case TooManyRequests:
+		return apierrors.IsTooManyRequests(err)
+	case ServerError:
+		code := statusCode(err)
+		return code >= 500 && code < 600
+	case Conflict:
+		return isConcurrentModification(err)
+	case Timeout:
+		return apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || isWebhookTimeout(err)
+	default:
+		return false
+	}
+}
+
+// Policy defines how applying or deleting an object is retried when the
+// server returns a transient error. The zero value does not retry.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Zero or one disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. The delay doubles
+	// with each retry. Defaults to DefaultInitialBackoff. If the server
+	// suggests a longer delay, like with the Retry-After header, the
+	// suggested delay is used instead.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries. Defaults to
+	// DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Errors lists the classes of errors to retry. Defaults to all classes.
+	Errors []ErrorClass
+}
+
+// Enabled returns true if the policy retries failed attempts.
+func (p Policy) Enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// Retryable returns true if the error belongs to one of the error classes
+// of the policy.
+func (p Policy) Retryable(err error) bool {
+	classes := p.Errors
+	if len(classes) == 0 {
+		classes = ErrorClasses()
+	}
+	for _, class := range classes {
+		if class.Matches(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns the delay after the given failed attempt, starting at 1.
+func (p Policy) Backoff(attempt int, err error) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = DefaultInitialBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+	delay := initial
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxBackoff)
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+		delay = max(delay, time.Duration(seconds)*time.Second)
+	}
+	return delay
+}
+
+// Do calls fn until it succeeds, returns an error that is not retryable, or
+// the maximum number of attempts is reached. Before each retry, onRetry is
+// called with the number of the failed attempt, the delay before the next
+// attempt, and the error. Returns the error of the last attempt, including
+// when the context is done while waiting to retry.
+func (p Policy) Do(ctx context.Context, fn func() error, onRetry func(attempt int, delay time.Duration, err error)) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !p.Retryable(err) {
+			return err
+		}
+		delay := p.Backoff(attempt, err)
+		if onRetry != nil {
+			onRetry(attempt, delay, err)
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func statusCode(err error) int32 {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		return status.Status().Code
+	}
+	return 0
+}
+
+// isConcurrentModification returns true if the error is a conflict caused by
+// an optimistic concurrency check, like a resourceVersion mismatch. Conflicts
+// with the field managers of a server-side apply, and failed preconditions,
+// like the UID precondition of a delete, are excluded.
+func isConcurrentModification(err error) bool {
+	if !apierrors.IsConflict(err) {
+		return false
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type == metav1.CauseTypeFieldManagerConflict {
+				return false
+			}
+		}
+	}
+	return !strings.Contains(err.Error(), "Precondition failed")
+}
+
+// isWebhookTimeout returns true if the error is an admission webhook call
+// that timed out. The server reports these as internal errors.
+func isWebhookTimeout(err error) bool {
+	if !apierrors.IsInternalError(err) {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "failed calling webhook") &&
+		(strings.Contains(msg, "deadline exceeded") || strings.Contains(msg, "timeout"))
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var podGR = schema.GroupResource{Resource: "pods"}
+
+func TestErrorClassMatches(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected []ErrorClass
+	}{
+		"too many requests": {
+			err:      apierrors.NewTooManyRequests("slow down", 1),
+			expected: []ErrorClass{TooManyRequests},
+		},
+		"service unavailable": {
+			err:      apierrors.NewServiceUnavailable("unavailable"),
+			expected: []ErrorClass{ServerError},
+		},
+		"server timeout": {
+			err:      apierrors.NewServerTimeout(podGR, "create", 1),
+			expected: []ErrorClass{ServerError, Timeout},
+		},
+		"conflict": {
+			err:      apierrors.NewConflict(podGR, "test", errors.New("modified")),
+			expected: []ErrorClass{Conflict},
+		},
+		"webhook timeout": {
+			err: apierrors.NewInternalError(errors.New(`failed calling webhook "validate.example.com": ` +
+				`context deadline exceeded`)),
+			expected: []ErrorClass{ServerError, Timeout},
+		},
+		"apply conflict": {
+			err: apierrors.NewApplyConflict([]metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "kubectl"`,
+				Field:   ".spec.replicas",
+			}}, `Apply failed with 1 conflict: conflict with "kubectl": .spec.replicas`),
+		},
+		"precondition failed": {
+			err: apierrors.NewConflict(podGR, "test", errors.New(
+				"Precondition failed: UID in precondition: 123, UID in object meta: 456")),
+		},
+		"wrapped conflict": {
+			err:      fmt.Errorf("apply failed: %w", apierrors.NewConflict(podGR, "test", errors.New("modified"))),
+			expected: []ErrorClass{Conflict},
+		},
+		"not found": {
+			err: apierrors.NewNotFound(podGR, "test"),
+		},
+		"invalid": {
+			err: apierrors.NewBadRequest("invalid"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var matched []ErrorClass
+			for _, class := range ErrorClasses() {
+				if class.Matches(tc.err) {
+					matched = append(matched, class)
+				}
+			}
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestPolicyBackoff(t *testing.T) {
+	p := Policy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	err := errors.New("error")
+	assert.Equal(t, time.Second, p.Backoff(1, err))
+	assert.Equal(t, 2*time.Second, p.Backoff(2, err))
+	assert.Equal(t, 4*time.Second, p.Backoff(3, err))
+	assert.Equal(t, 5*time.Second, p.Backoff(4, err))
+	assert.Equal(t, 5*time.Second, p.Backoff(100, err))
+	// The delay suggested by the server takes precedence if longer.
+	assert.Equal(t, 10*time.Second, p.Backoff(1, apierrors.NewTooManyRequests("slow down", 10)))
+}
+
+func TestPolicyDo(t *testing.T) {
+	transient := apierrors.NewServiceUnavailable("unavailable")
+	tests := map[string]struct {
+		policy           Policy
+		errs             []error
+		expectedAttempts int
+		expectedRetries  []int
+		expectedErr      error
+	}{
+		"zero policy does not retry": {
+			errs:             []error{transient, nil},
+			expectedAttempts: 1,
+			expectedErr:      transient,
+		},
+		"success after retries": {
+			policy:           Policy{MaxAttempts: 3},
+			errs:             []error{transient, transient, nil},
+			expectedAttempts: 3,
+			expectedRetries:  []int{1, 2},
+		},
+		"max attempts reached": {
+			policy:           Policy{MaxAttempts: 2},
+			errs:             []error{transient, transient, nil},
+			expectedAttempts: 2,
+			expectedRetries:  []int{1},
+			expectedErr:      transient,
+		},
+		"error not retryable": {
+			policy:           Policy{MaxAttempts: 3},
+			errs:             []error{apierrors.NewNotFound(podGR, "test"), nil},
+			expectedAttempts: 1,
+			expectedErr:      apierrors.NewNotFound(podGR, "test"),
+		},
+		"error class not enabled": {
+			policy:           Policy{MaxAttempts: 3, Errors: []ErrorClass{Conflict}},
+			errs:             []error{transient, nil},
+			expectedAttempts: 1,
+			expectedErr:      transient,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.policy.InitialBackoff = time.Millisecond
+			attempts := 0
+			var retries []int
+			err := tc.policy.Do(context.Background(), func() error {
+				err := tc.errs[attempts]
+				attempts++
+				return err
+			}, func(attempt int, _ time.Duration, err error) {
+				assert.Error(t, err)
+				retries = append(retries, attempt)
+			})
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedAttempts, attempts)
+			assert.Equal(t, tc.expectedRetries, retries)
+		})
+	}
+}
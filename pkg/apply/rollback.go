@@ -0,0 +1,88 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Rollback applies the objects of a previous revision stored in the
+// inventory, and prunes the objects that were applied since. If revision is
+// zero, the newest successful revision before the latest revision is used.
+// Rollback requires an inventory client that stores revisions. Rolling back
+// adds a new revision to the inventory, like any other apply.
+//
+// Progress and errors are reported on the returned event channel, the same
+// way as Run.
+func (a *Applier) Rollback(ctx context.Context, invInfo inventory.Info, revision int64, options ApplierOptions) <-chan event.Event {
+	eventChannel := make(chan event.Event)
+	go func() {
+		defer close(eventChannel)
+		objs, err := a.revisionObjects(ctx, invInfo, revision)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		for e := range a.Run(ctx, invInfo, objs, options) {
+			eventChannel <- e
+		}
+	}()
+	return eventChannel
+}
+
+// revisionObjects returns the objects of the specified revision of the
+// inventory.
+func (a *Applier) revisionObjects(ctx context.Context, invInfo inventory.Info, revision int64) (object.UnstructuredSet, error) {
+	inv, err := a.invClient.Get(ctx, invInfo, inventory.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+	revInv, ok := inv.(inventory.RevisionInventory)
+	if !ok {
+		return nil, fmt.Errorf("inventory does not support revisions: %T", inv)
+	}
+	rev, err := inventory.FindRevision(revInv.GetRevisions(), revision)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(4).Infof("rolling back to revision %d (%d objects)", rev.Number, len(rev.Objects))
+	objs := make(object.UnstructuredSet, len(rev.Objects))
+	for i, obj := range rev.Objects {
+		objs[i] = obj.DeepCopy()
+		if inventory.HasRedactedSecretData(objs[i]) {
+			if err := a.restoreSecretData(ctx, objs[i]); err != nil {
+				return nil, fmt.Errorf("failed to roll back %s to revision %d: %w",
+					object.UnstructuredToObjMetadata(obj), rev.Number, err)
+			}
+		}
+	}
+	return objs, nil
+}
+
+// restoreSecretData copies the data of the live Secret to the Secret from a
+// revision, which does not store it.
+func (a *Applier) restoreSecretData(ctx context.Context, obj *unstructured.Unstructured) error {
+	id := object.UnstructuredToObjMetadata(obj)
+	mapping, err := a.mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		return err
+	}
+	liveObj, err := a.client.Resource(mapping.Resource).Namespace(id.Namespace).Get(ctx, id.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("the Secret data is not stored in revisions, and the Secret was deleted")
+		}
+		return fmt.Errorf("failed to get: %w", err)
+	}
+	return inventory.RestoreSecretData(obj, liveObj)
+}
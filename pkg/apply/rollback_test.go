@@ -0,0 +1,107 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestRevisionObjects(t *testing.T) {
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	secret := testutil.Unstructured(t, resources["secret"])
+
+	revisions := []inventory.Revision{
+		{Number: 1, Succeeded: true, Objects: object.UnstructuredSet{deployment}},
+		{Number: 2, Succeeded: true, Objects: object.UnstructuredSet{deployment, secret}},
+		{Number: 3, Succeeded: false, Objects: object.UnstructuredSet{secret}},
+	}
+
+	tests := map[string]struct {
+		revisions []inventory.Revision
+		revision  int64
+		expected  object.UnstructuredSet
+		hasError  bool
+	}{
+		"previous successful revision": {
+			revisions: revisions,
+			expected:  object.UnstructuredSet{deployment, secret},
+		},
+		"specific revision": {
+			revisions: revisions,
+			revision:  1,
+			expected:  object.UnstructuredSet{deployment},
+		},
+		"missing revision": {
+			revisions: revisions,
+			revision:  4,
+			hasError:  true,
+		},
+		"no revisions": {
+			hasError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			invClient := inventory.NewFakeClient(nil)
+			invClient.Inv.(*inventory.FakeInventory).SetRevisions(tc.revisions)
+			applier := &Applier{invClient: invClient}
+
+			objs, err := applier.revisionObjects(t.Context(), invClient.Inv.Info(), tc.revision)
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			testutil.AssertEqual(t, tc.expected, objs)
+		})
+	}
+}
+
+func TestRevisionObjectsSecretData(t *testing.T) {
+	secret := testutil.Unstructured(t, resources["secret"])
+	require.NoError(t, unstructured.SetNestedStringMap(secret.Object,
+		map[string]string{"password": "c2VjcmV0"}, "data"))
+	revision := inventory.NewRevision(nil, object.UnstructuredSet{secret}, true)
+
+	tests := map[string]struct {
+		clusterObjs []runtime.Object
+		hasError    bool
+	}{
+		"data restored from the live secret": {
+			clusterObjs: []runtime.Object{secret},
+		},
+		"deleted secret": {
+			hasError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			invClient := inventory.NewFakeClient(nil)
+			invClient.Inv.(*inventory.FakeInventory).SetRevisions([]inventory.Revision{revision})
+			applier := &Applier{
+				invClient: invClient,
+				client:    dynamicfake.NewSimpleDynamicClient(scheme.Scheme, tc.clusterObjs...),
+				mapper:    testutil.NewFakeRESTMapper(v1.SchemeGroupVersion.WithKind("Secret")),
+			}
+
+			objs, err := applier.revisionObjects(t.Context(), invClient.Inv.Info(), 1)
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			testutil.AssertEqual(t, object.UnstructuredSet{secret}, objs)
+		})
+	}
+}
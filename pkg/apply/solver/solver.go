@@ -16,24 +16,31 @@ package solver
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
 	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/apply/task"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/dependson"
 	"sigs.k8s.io/cli-utils/pkg/object/graph"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
+	"sigs.k8s.io/cli-utils/pkg/object/reconcile"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 )
 
@@ -51,18 +58,58 @@ type TaskQueueBuilder struct {
 	ApplyFilters  []filter.ValidationFilter
 	ApplyMutators []mutator.Interface
 	PruneFilters  []filter.ValidationFilter
+	// HookReplaceFilters skip the deletion of previous instances of hooks
+	// that will not be applied again.
+	HookReplaceFilters []filter.ValidationFilter
 
 	// The accumulated tasks and counter variables to name tasks.
-	applyCounter int
-	pruneCounter int
-	waitCounter  int
+	applyCounter      int
+	pruneCounter      int
+	waitCounter       int
+	checkpointCounter int
+	hookDeleteCounter int
+	rollbackCounter   int
+	// externalWaitTasks maps the external dependencies to the task that
+	// waits for them.
+	externalWaitTasks map[object.ObjMetadata]taskrunner.Task
 
 	applyObjs object.UnstructuredSet
 	pruneObjs object.UnstructuredSet
+	hookObjs  object.UnstructuredSet
+	// previousHookObjs are the previous instances of the hooks that are
+	// still in the cluster.
+	previousHookObjs object.UnstructuredSet
+	// checkpoint maps the objects that were applied and reconciled by a
+	// previous run to their status.
+	checkpoint map[object.ObjMetadata]actuation.ObjectStatus
+	// unchanged maps the objects that have not changed since they were last
+	// applied to their status.
+	unchanged map[object.ObjMetadata]actuation.ObjectStatus
 }
 
 type TaskQueue struct {
 	tasks []taskrunner.Task
+	// dependencies maps each task to the tasks that must complete before it
+	// can be started. Nil if the tasks must be executed sequentially.
+	dependencies map[taskrunner.Task][]taskrunner.Task
+	// rollbackTasks are the tasks that roll back the apply phases, in the
+	// order they are run.
+	rollbackTasks []*task.RollbackTask
+}
+
+// add appends a task to the queue. The dependencies are ignored, unless the
+// queue was built for parallel execution.
+func (tq *TaskQueue) add(t taskrunner.Task, dependencies ...taskrunner.Task) {
+	tq.tasks = append(tq.tasks, t)
+	if tq.dependencies != nil {
+		tq.dependencies[t] = dependencies
+	}
+}
+
+// RollbackTasks returns the tasks that roll back the apply phases, in the
+// order they are run.
+func (tq *TaskQueue) RollbackTasks() []*task.RollbackTask {
+	return tq.rollbackTasks
 }
 
 func (tq *TaskQueue) ToChannel() chan taskrunner.Task {
@@ -73,14 +120,36 @@ func (tq *TaskQueue) ToChannel() chan taskrunner.Task {
 	return taskQueue
 }
 
+// ToGraph returns the tasks as a TaskGraph. If the queue was not built for
+// parallel execution, each task depends on the task before it.
+func (tq *TaskQueue) ToGraph() *taskrunner.TaskGraph {
+	g := taskrunner.NewTaskGraph()
+	for i, t := range tq.tasks {
+		switch {
+		case tq.dependencies != nil:
+			g.AddTask(t, tq.dependencies[t]...)
+		case i > 0:
+			g.AddTask(t, tq.tasks[i-1])
+		default:
+			g.AddTask(t)
+		}
+	}
+	return g
+}
+
 func (tq *TaskQueue) ToActionGroups() []event.ActionGroup {
 	var ags []event.ActionGroup
 
 	for _, t := range tq.tasks {
+		var dependsOn []string
+		for _, dep := range tq.dependencies[t] {
+			dependsOn = append(dependsOn, dep.Name())
+		}
 		ags = append(ags, event.ActionGroup{
 			Name:        t.Name(),
 			Action:      t.Action(),
 			Identifiers: t.Identifiers(),
+			DependsOn:   dependsOn,
 		})
 	}
 	return ags
@@ -98,6 +167,52 @@ type Options struct {
 	PrunePropagationPolicy metav1.DeletionPropagation
 	PruneTimeout           time.Duration
 	InventoryPolicy        inventory.Policy
+	// True if independent sets of objects should be applied and pruned
+	// concurrently. Otherwise, all tasks are executed sequentially.
+	ParallelPhases bool
+	// True if the object statuses should be persisted to the inventory
+	// after each apply phase has reconciled, so that an interrupted apply
+	// can be resumed.
+	Checkpoint bool
+	// True if the UID, generation and hash of each applied object should be
+	// persisted to the inventory, so that a later run can detect the
+	// objects that have not changed. Otherwise, only the actuation and
+	// reconcile statuses are persisted.
+	StoreAppliedState bool
+	// True if the live state of each object should be recorded before it
+	// is applied, and restored by rollback tasks if any applied object
+	// fails to reconcile. The rollback tasks run in reverse apply order,
+	// before the inventory is updated.
+	RollbackOnFailure bool
+	// The maximum number of objects to apply concurrently within each apply
+	// task. If less than two, objects are applied one at a time.
+	ApplyConcurrency int
+	// The maximum number of objects to delete concurrently within each
+	// prune task. If less than two, objects are deleted one at a time.
+	PruneConcurrency int
+	// The policy used to retry applying and deleting objects when the server
+	// returns a transient error.
+	RetryPolicy retry.Policy
+	// The minimum duration that applied objects must remain reconciled
+	// before they are considered reconciled. Can be overridden for each
+	// object with the stability-window annotation.
+	StabilityWindow time.Duration
+	// Per-GroupKind overrides of the ReconcileTimeout. Can be overridden
+	// for each object with the reconcile-timeout annotation.
+	ReconcileTimeouts map[schema.GroupKind]time.Duration
+	// Per-GroupKind overrides of the PruneTimeout. Can be overridden for
+	// each object with the reconcile-timeout annotation.
+	PruneTimeouts map[schema.GroupKind]time.Duration
+	// True if dependencies should be inferred from object references.
+	InferReferences bool
+	// True if depends-on annotations may reference objects that are not
+	// applied or pruned. Before the dependents are applied, a wait task
+	// waits for the live external dependencies to become Current.
+	AllowExternalDependencies bool
+	// ExternalDependencyTimeout is how long to wait for the external
+	// dependencies to become Current. If zero, the ReconcileTimeout is
+	// used.
+	ExternalDependencyTimeout time.Duration
 }
 
 // WithApplyObjects sets the apply objects and returns the builder for chaining.
@@ -112,14 +227,62 @@ func (t *TaskQueueBuilder) WithPruneObjects(pruneObjs object.UnstructuredSet) *T
 	return t
 }
 
+// WithHookObjects sets the lifecycle hooks and returns the builder for
+// chaining. Only the hooks of the phases that are run by the task queue are
+// applied: pre-apply, post-apply and pre-prune hooks when applying, and
+// post-destroy hooks when destroying.
+func (t *TaskQueueBuilder) WithHookObjects(hookObjs object.UnstructuredSet) *TaskQueueBuilder {
+	t.hookObjs = hookObjs
+	return t
+}
+
+// WithPreviousHookObjects sets the previous instances of the lifecycle hooks
+// that are still in the cluster and returns the builder for chaining. They are
+// deleted, and waited on until they are gone, before the hooks of their phase
+// are applied again, so that the hooks run again, even if they are unchanged.
+func (t *TaskQueueBuilder) WithPreviousHookObjects(previousHookObjs object.UnstructuredSet) *TaskQueueBuilder {
+	t.previousHookObjs = previousHookObjs
+	return t
+}
+
+// WithUnchangedObjects sets the statuses of the objects that have not
+// changed since they were last applied and returns the builder for chaining.
+// The apply tasks skip these objects, instead of sending them to the server.
+func (t *TaskQueueBuilder) WithUnchangedObjects(objStatuses object.ObjectStatusSet) *TaskQueueBuilder {
+	t.unchanged = make(map[object.ObjMetadata]actuation.ObjectStatus, len(objStatuses))
+	for _, objStatus := range objStatuses {
+		t.unchanged[inventory.ObjMetadataFromObjectReference(objStatus.ObjectReference)] = objStatus
+	}
+	return t
+}
+
+// WithCheckpointedObjects sets the statuses of the objects that were applied
+// and reconciled by a previous run and returns the builder for chaining.
+// Phases of apply objects that are all checkpointed are skipped, and their
+// statuses are restored instead.
+func (t *TaskQueueBuilder) WithCheckpointedObjects(objStatuses object.ObjectStatusSet) *TaskQueueBuilder {
+	t.checkpoint = make(map[object.ObjMetadata]actuation.ObjectStatus, len(objStatuses))
+	for _, objStatus := range objStatuses {
+		t.checkpoint[inventory.ObjMetadataFromObjectReference(objStatus.ObjectReference)] = objStatus
+	}
+	return t
+}
+
 // Build returns the queue of tasks that have been created
 func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options) *TaskQueue {
-	var tasks []taskrunner.Task
+	tq := &TaskQueue{}
+	if o.ParallelPhases {
+		tq.dependencies = make(map[taskrunner.Task][]taskrunner.Task)
+	}
 
 	// reset counters
 	t.applyCounter = 0
 	t.pruneCounter = 0
 	t.waitCounter = 0
+	t.checkpointCounter = 0
+	t.hookDeleteCounter = 0
+	t.rollbackCounter = 0
+	t.externalWaitTasks = make(map[object.ObjMetadata]taskrunner.Task)
 
 	// Filter objects that failed earlier validation
 	applyObjs := t.Collector.FilterInvalidObjects(t.applyObjs)
@@ -131,7 +294,16 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 	allObjs := make(object.UnstructuredSet, 0, len(applyObjs)+len(pruneObjs))
 	allObjs = append(allObjs, applyObjs...)
 	allObjs = append(allObjs, pruneObjs...)
-	g, err := graph.DependencyGraph(allObjs)
+	// Apply waves and references only order objects with the same
+	// actuation strategy.
+	g, err := graph.DependencyGraphWithOptions(allObjs, graph.Options{
+		ActuationGroups: []object.ObjMetadataSet{
+			object.UnstructuredSetToObjMetadataSet(applyObjs),
+			object.UnstructuredSetToObjMetadataSet(pruneObjs),
+		},
+		InferReferences:           o.InferReferences,
+		AllowExternalDependencies: o.AllowExternalDependencies,
+	})
 	if err != nil {
 		t.Collector.Collect(err)
 	}
@@ -148,10 +320,33 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 	applyObjs = t.Collector.FilterInvalidObjects(applyObjs)
 	pruneObjs = t.Collector.FilterInvalidObjects(pruneObjs)
 
+	// Hooks are not graphed, because they are run before or after all the
+	// other objects of an apply or destroy.
+	hooks := t.hookPhases(taskContext)
+	if o.Destroy {
+		hooks[hook.PreApply] = nil
+		hooks[hook.PostApply] = nil
+		hooks[hook.PrePrune] = nil
+	} else {
+		hooks[hook.PostDestroy] = nil
+	}
+	if !o.Prune || len(pruneObjs) == 0 {
+		hooks[hook.PrePrune] = nil
+	}
+	for _, hookObjs := range hooks {
+		// Register actuation plan in the inventory
+		for _, id := range object.UnstructuredSetToObjMetadataSet(hookObjs) {
+			taskContext.InventoryManager().AddPendingApply(id)
+		}
+	}
+
+	// rootTasks are the tasks that all apply and prune tasks depend on.
+	var rootTasks []taskrunner.Task
+
 	if !o.Destroy {
 		// InvAddTask creates the inventory and adds any objects being applied
 		klog.V(2).Infof("adding inventory add task (%d objects)", len(applyObjs))
-		tasks = append(tasks, &task.InvAddTask{
+		invAddTask := &task.InvAddTask{
 			TaskName:      "inventory-add-0",
 			InvClient:     t.InvClient,
 			DynamicClient: t.DynamicClient,
@@ -159,54 +354,157 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 			Inventory:     t.Inventory,
 			Objects:       applyObjs,
 			DryRun:        o.DryRunStrategy,
-		})
+		}
+		tq.add(invAddTask)
+		rootTasks = append(rootTasks, invAddTask)
+	}
+
+	if lastTask := t.addHookTasks(tq, hooks[hook.PreApply], o, rootTasks...); lastTask != nil {
+		rootTasks = []taskrunner.Task{lastTask}
 	}
 
+	// rollbackSets are the applied phases, to roll back in reverse order.
+	var rollbackSets []object.UnstructuredSet
+
 	if len(applyObjs) > 0 {
 		// Register actuation plan in the inventory
 		for _, id := range object.UnstructuredSetToObjMetadataSet(applyObjs) {
 			taskContext.InventoryManager().AddPendingApply(id)
 		}
 
-		// Filter idSetList down to just apply objects
-		applySets := graph.HydrateSetList(idSetList, applyObjs)
-
-		for _, applySet := range applySets {
-			tasks = append(tasks,
-				t.newApplyTask(applySet, t.ApplyFilters, t.ApplyMutators, o))
-			// dry-run skips wait tasks
-			if !o.DryRunStrategy.ClientOrServerDryRun() {
-				applyIDs := object.UnstructuredSetToObjMetadataSet(applySet)
-				tasks = append(tasks,
-					t.newWaitTask(applyIDs, taskrunner.AllCurrent, o.ReconcileTimeout))
+		if o.ParallelPhases {
+			// Split idSetList into groups of apply objects that don't
+			// depend on each other.
+			applyGroups := phaseGroups(g, idSetList, applyObjs)
+			// Apply tasks depend on the tasks that apply (and wait for) the
+			// dependencies of their objects.
+			dependencies := func(id object.ObjMetadata) object.ObjMetadataSet {
+				return append(g.Dependencies(id), g.ExternalDependencies(id)...)
+			}
+			applyTasks := t.addGroupTasks(tq, applyGroups, dependencies, rootTasks,
+				func(applySet object.UnstructuredSet) (taskrunner.Task, taskrunner.Task) {
+					if t.restoreCheckpoint(taskContext, applySet) {
+						return nil, nil
+					}
+					// External dependencies are waited for concurrently
+					// with the other tasks. The apply task depends on the
+					// wait task through the external edges.
+					if externalWaitTask := t.newExternalWaitTask(taskContext, g, applySet, o); externalWaitTask != nil {
+						tq.add(externalWaitTask, rootTasks...)
+					}
+					applyTask := t.newApplyTask(applySet, t.ApplyFilters, t.ApplyMutators, o)
+					// dry-run skips wait tasks
+					if o.DryRunStrategy.ClientOrServerDryRun() {
+						return applyTask, nil
+					}
+					rollbackSets = append(rollbackSets, applySet)
+					return applyTask, t.newApplyWaitTask(g, applySet, o)
+				})
+			if o.Checkpoint && !o.DryRunStrategy.ClientOrServerDryRun() {
+				// Checkpoint tasks depend on each other, so that the
+				// inventory is never updated concurrently.
+				var prevCheckpointTask taskrunner.Task
+				for _, waitTask := range applyTasks {
+					deps := []taskrunner.Task{waitTask}
+					if prevCheckpointTask != nil {
+						deps = append(deps, prevCheckpointTask)
+					}
+					prevCheckpointTask = t.newCheckpointTask(o)
+					tq.add(prevCheckpointTask, deps...)
+				}
+			}
+			// If every apply was restored from the checkpoint, there are no
+			// apply tasks to wait for.
+			if len(applyTasks) > 0 {
+				rootTasks = applyTasks
+			}
+		} else {
+			// Filter idSetList down to just apply objects
+			applySets := graph.HydrateSetList(idSetList, applyObjs)
+
+			for _, applySet := range applySets {
+				if t.restoreCheckpoint(taskContext, applySet) {
+					continue
+				}
+				if externalWaitTask := t.newExternalWaitTask(taskContext, g, applySet, o); externalWaitTask != nil {
+					tq.add(externalWaitTask)
+				}
+				tq.add(t.newApplyTask(applySet, t.ApplyFilters, t.ApplyMutators, o))
+				// dry-run skips wait tasks
+				if !o.DryRunStrategy.ClientOrServerDryRun() {
+					rollbackSets = append(rollbackSets, applySet)
+					tq.add(t.newApplyWaitTask(g, applySet, o))
+					if o.Checkpoint {
+						tq.add(t.newCheckpointTask(o))
+					}
+				}
 			}
 		}
 	}
 
+	if lastTask := t.addHookTasks(tq, hooks[hook.PostApply], o, rootTasks...); lastTask != nil {
+		rootTasks = []taskrunner.Task{lastTask}
+	}
+	if lastTask := t.addHookTasks(tq, hooks[hook.PrePrune], o, rootTasks...); lastTask != nil {
+		rootTasks = []taskrunner.Task{lastTask}
+	}
+
 	if o.Prune && len(pruneObjs) > 0 {
 		// Register actuation plan in the inventory
 		for _, id := range object.UnstructuredSetToObjMetadataSet(pruneObjs) {
 			taskContext.InventoryManager().AddPendingDelete(id)
 		}
 
-		// Filter idSetList down to just prune objects
-		pruneSets := graph.HydrateSetList(idSetList, pruneObjs)
+		if o.ParallelPhases {
+			// Split idSetList into groups of prune objects that don't
+			// depend on each other, in reverse apply order.
+			pruneGroups := phaseGroups(g, idSetList, pruneObjs)
+			graph.ReverseSetList(pruneGroups)
+			// Prune tasks wait for all applies to finish, and depend on the
+			// tasks that prune (and wait for) the dependents of their objects.
+			pruneTasks := t.addGroupTasks(tq, pruneGroups, g.Dependents, rootTasks,
+				func(pruneSet object.UnstructuredSet) (taskrunner.Task, taskrunner.Task) {
+					pruneTask := t.newPruneTask(pruneSet, t.PruneFilters, o)
+					// dry-run skips wait tasks
+					if o.DryRunStrategy.ClientOrServerDryRun() {
+						return pruneTask, nil
+					}
+					return pruneTask, t.newPruneWaitTask(pruneSet, o)
+				})
+			if len(pruneTasks) > 0 {
+				rootTasks = pruneTasks
+			}
+		} else {
+			// Filter idSetList down to just prune objects
+			pruneSets := graph.HydrateSetList(idSetList, pruneObjs)
 
-		// Reverse apply order to get prune order
-		graph.ReverseSetList(pruneSets)
+			// Reverse apply order to get prune order
+			graph.ReverseSetList(pruneSets)
 
-		for _, pruneSet := range pruneSets {
-			tasks = append(tasks,
-				t.newPruneTask(pruneSet, t.PruneFilters, o))
-			// dry-run skips wait tasks
-			if !o.DryRunStrategy.ClientOrServerDryRun() {
-				pruneIDs := object.UnstructuredSetToObjMetadataSet(pruneSet)
-				tasks = append(tasks,
-					t.newWaitTask(pruneIDs, taskrunner.AllNotFound, o.PruneTimeout))
+			for _, pruneSet := range pruneSets {
+				tq.add(t.newPruneTask(pruneSet, t.PruneFilters, o))
+				// dry-run skips wait tasks
+				if !o.DryRunStrategy.ClientOrServerDryRun() {
+					tq.add(t.newPruneWaitTask(pruneSet, o))
+				}
 			}
 		}
 	}
 
+	t.addHookTasks(tq, hooks[hook.PostDestroy], o, rootTasks...)
+
+	if o.RollbackOnFailure {
+		// The phases are rolled back one at a time, in reverse apply order,
+		// after all the other tasks, but before the inventory is updated.
+		deps := slices.Clone(tq.tasks)
+		for i := len(rollbackSets) - 1; i >= 0; i-- {
+			rollbackTask := t.newRollbackTask(rollbackSets[i], o)
+			tq.add(rollbackTask, deps...)
+			tq.rollbackTasks = append(tq.rollbackTasks, rollbackTask)
+			deps = []taskrunner.Task{rollbackTask}
+		}
+	}
+
 	klog.V(2).Infoln("adding delete/update inventory task")
 	var taskName string
 	if o.Destroy {
@@ -214,15 +512,253 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 	} else {
 		taskName = "inventory-set-0"
 	}
-	tasks = append(tasks, &task.DeleteOrUpdateInvTask{
-		TaskName:  taskName,
-		Inventory: t.Inventory,
-		InvClient: t.InvClient,
-		DryRun:    o.DryRunStrategy,
-		Destroy:   o.Destroy,
-	})
+	// The inventory is updated after all other tasks have completed.
+	allTasks := make([]taskrunner.Task, len(tq.tasks))
+	copy(allTasks, tq.tasks)
+	tq.add(&task.DeleteOrUpdateInvTask{
+		TaskName:          taskName,
+		Inventory:         t.Inventory,
+		InvClient:         t.InvClient,
+		DryRun:            o.DryRunStrategy,
+		Destroy:           o.Destroy,
+		Objects:           t.applyObjs,
+		StoreAppliedState: o.StoreAppliedState,
+	}, allTasks...)
 
-	return &TaskQueue{tasks: tasks}
+	return tq
+}
+
+// addGroupTasks adds an actuation task and an optional wait task for each
+// group of objects, in order. Each actuation task depends on the rootTasks and
+// on the last task of every previous group that contains a relation of its
+// objects, as returned by the relations function, or on the task that waits
+// for the relation, if it is an external dependency. Groups for which
+// newTasks returns no tasks are skipped. Returns the last task of every group.
+func (t *TaskQueueBuilder) addGroupTasks(
+	tq *TaskQueue,
+	groups []object.UnstructuredSet,
+	relations func(object.ObjMetadata) object.ObjMetadataSet,
+	rootTasks []taskrunner.Task,
+	newTasks func(object.UnstructuredSet) (taskrunner.Task, taskrunner.Task),
+) []taskrunner.Task {
+	// map of object to the last task of its group
+	lastTaskByID := make(map[object.ObjMetadata]taskrunner.Task)
+	var lastTasks []taskrunner.Task
+	for _, group := range groups {
+		// newTasks may add the tasks that wait for external dependencies.
+		actuationTask, waitTask := newTasks(group)
+		if actuationTask == nil {
+			continue
+		}
+		deps := make([]taskrunner.Task, len(rootTasks))
+		copy(deps, rootTasks)
+		ids := object.UnstructuredSetToObjMetadataSet(group)
+		for _, id := range ids {
+			for _, relID := range relations(id) {
+				relTask, found := lastTaskByID[relID]
+				if !found {
+					relTask, found = t.externalWaitTasks[relID]
+				}
+				if found && !slices.Contains(deps, relTask) {
+					deps = append(deps, relTask)
+				}
+			}
+		}
+		tq.add(actuationTask, deps...)
+		lastTask := actuationTask
+		if waitTask != nil {
+			tq.add(waitTask, actuationTask)
+			lastTask = waitTask
+		}
+		for _, id := range ids {
+			lastTaskByID[id] = lastTask
+		}
+		lastTasks = append(lastTasks, lastTask)
+	}
+	return lastTasks
+}
+
+// hookPhases returns the valid hooks of each phase. Hooks with invalid hook
+// annotations are treated as validation errors. All the valid hooks are
+// registered in the TaskContext, so that they are excluded from the
+// inventory, even if their phase is not run.
+func (t *TaskQueueBuilder) hookPhases(taskContext *taskrunner.TaskContext) map[hook.Type]object.UnstructuredSet {
+	hooks := make(map[hook.Type]object.UnstructuredSet)
+	for _, obj := range t.Collector.FilterInvalidObjects(t.hookObjs) {
+		id := object.UnstructuredToObjMetadata(obj)
+		hookType, err := hook.ReadAnnotation(obj)
+		if err != nil {
+			t.Collector.Collect(validation.NewError(err, id))
+			continue
+		}
+		if _, err := hook.ReadDeletePolicy(obj); err != nil {
+			t.Collector.Collect(validation.NewError(err, id))
+			continue
+		}
+		taskContext.AddHookObject(id)
+		hooks[hookType] = append(hooks[hookType], obj)
+	}
+	return hooks
+}
+
+// addHookTasks adds the tasks that delete the previous instances of the
+// hooks, apply the hooks, wait for them to complete, and delete the hooks
+// whose delete policy allows it. The first task depends on the passed
+// dependencies. Returns the last task, or nil if there are no hooks.
+func (t *TaskQueueBuilder) addHookTasks(tq *TaskQueue, hookObjs object.UnstructuredSet, o Options,
+	dependencies ...taskrunner.Task) taskrunner.Task {
+	if len(hookObjs) == 0 {
+		return nil
+	}
+	// dry-run skips replace, wait and delete tasks
+	dryRun := o.DryRunStrategy.ClientOrServerDryRun()
+	if !dryRun {
+		if replaceTask := t.newHookReplaceTask(hookObjs, o); replaceTask != nil {
+			tq.add(replaceTask, dependencies...)
+			replaceWaitTask := t.newPruneWaitTask(replaceTask.Objects, o)
+			tq.add(replaceWaitTask, replaceTask)
+			dependencies = []taskrunner.Task{replaceWaitTask}
+		}
+	}
+	// Hooks are not rolled back, so their previous state is not needed.
+	hookOptions := o
+	hookOptions.RollbackOnFailure = false
+	applyTask := t.newApplyTask(hookObjs, t.ApplyFilters, t.ApplyMutators, hookOptions)
+	tq.add(applyTask, dependencies...)
+	if dryRun {
+		return applyTask
+	}
+	waitTask := t.newHookWaitTask(hookObjs, o)
+	tq.add(waitTask, applyTask)
+	deleteTask := t.newHookDeleteTask(hookObjs, o)
+	if deleteTask == nil {
+		return waitTask
+	}
+	tq.add(deleteTask, waitTask)
+	return deleteTask
+}
+
+// newHookReplaceTask returns a task that deletes the previous instances of
+// the hooks, or nil if none of the hooks have a previous instance.
+func (t *TaskQueueBuilder) newHookReplaceTask(hookObjs object.UnstructuredSet, o Options) *task.HookDeleteTask {
+	hookIDs := object.UnstructuredSetToObjMetadataSet(hookObjs)
+	var replaceObjs object.UnstructuredSet
+	for _, obj := range t.previousHookObjs {
+		if hookIDs.Contains(object.UnstructuredToObjMetadata(obj)) {
+			replaceObjs = append(replaceObjs, obj)
+		}
+	}
+	if len(replaceObjs) == 0 {
+		return nil
+	}
+	klog.V(2).Infof("adding hook replace task (%d objects)", len(replaceObjs))
+	replaceTask := &task.HookDeleteTask{
+		TaskName:      fmt.Sprintf("hook-delete-%d", t.hookDeleteCounter),
+		DynamicClient: t.DynamicClient,
+		Mapper:        t.Mapper,
+		Objects:       replaceObjs,
+		Replace:       true,
+		Filters:       t.HookReplaceFilters,
+		RetryPolicy:   o.RetryPolicy,
+	}
+	t.hookDeleteCounter++
+	return replaceTask
+}
+
+// newHookWaitTask returns a task that waits for the hooks to complete.
+func (t *TaskQueueBuilder) newHookWaitTask(hookObjs object.UnstructuredSet, o Options) taskrunner.Task {
+	hookIDs := object.UnstructuredSetToObjMetadataSet(hookObjs)
+	waitTask := t.newWaitTask(hookIDs, taskrunner.AllCurrent, o.ReconcileTimeout)
+	waitTask.Conditions = make(map[object.ObjMetadata][]taskrunner.ObjectCondition, len(waitTask.IDs))
+	for _, id := range waitTask.IDs {
+		waitTask.Conditions[id] = []taskrunner.ObjectCondition{{Kind: taskrunner.Completed}}
+	}
+	waitTask.Timeouts = objectTimeouts(hookObjs, o.ReconcileTimeouts)
+	return waitTask
+}
+
+// newHookDeleteTask returns a task that deletes the hooks after they
+// complete, or nil if none of the hooks have a delete policy that allows it.
+func (t *TaskQueueBuilder) newHookDeleteTask(hookObjs object.UnstructuredSet, o Options) taskrunner.Task {
+	var deleteObjs object.UnstructuredSet
+	for _, obj := range hookObjs {
+		// Invalid annotations are rejected by hookPhases
+		if policy, _ := hook.ReadDeletePolicy(obj); policy != hook.DeleteNever {
+			deleteObjs = append(deleteObjs, obj)
+		}
+	}
+	if len(deleteObjs) == 0 {
+		return nil
+	}
+	klog.V(2).Infof("adding hook delete task (%d objects)", len(deleteObjs))
+	deleteTask := &task.HookDeleteTask{
+		TaskName:      fmt.Sprintf("hook-delete-%d", t.hookDeleteCounter),
+		DynamicClient: t.DynamicClient,
+		Mapper:        t.Mapper,
+		Objects:       deleteObjs,
+		RetryPolicy:   o.RetryPolicy,
+	}
+	t.hookDeleteCounter++
+	return deleteTask
+}
+
+// restoreCheckpoint returns true if all the objects were applied and
+// reconciled by a previous run. If so, their statuses are restored, so that
+// the objects that depend on them can still be applied.
+func (t *TaskQueueBuilder) restoreCheckpoint(taskContext *taskrunner.TaskContext, objs object.UnstructuredSet) bool {
+	if len(t.checkpoint) == 0 {
+		return false
+	}
+	ids := object.UnstructuredSetToObjMetadataSet(objs)
+	for _, id := range ids {
+		if _, found := t.checkpoint[id]; !found {
+			return false
+		}
+	}
+	klog.V(2).Infof("skipping apply of %d objects reconciled by a previous run", len(ids))
+	for _, id := range ids {
+		taskContext.InventoryManager().SetObjectStatus(t.checkpoint[id])
+	}
+	return true
+}
+
+// phaseGroups filters idSetList down to the specified objects and splits each
+// phase into groups of objects in the same connected component of the graph.
+// Objects in different groups of the same phase don't depend on each other,
+// directly or indirectly. Objects missing from the graph are in a group of
+// their own.
+func phaseGroups(g *graph.Graph, idSetList []object.ObjMetadataSet, objs object.UnstructuredSet) []object.UnstructuredSet {
+	components := g.Components()
+	componentIndex := make(map[object.ObjMetadata]int)
+	for i, component := range components {
+		for _, id := range component {
+			componentIndex[id] = i
+		}
+	}
+	nextIndex := len(components)
+	var groups []object.UnstructuredSet
+	for _, objSet := range graph.HydrateSetList(idSetList, objs) {
+		// Preserve the apply order of the set
+		var order []int
+		byComponent := make(map[int]object.UnstructuredSet)
+		for _, obj := range objSet {
+			id := object.UnstructuredToObjMetadata(obj)
+			i, found := componentIndex[id]
+			if !found {
+				i = nextIndex
+				componentIndex[id] = i
+				nextIndex++
+			}
+			if _, found := byComponent[i]; !found {
+				order = append(order, i)
+			}
+			byComponent[i] = append(byComponent[i], obj)
+		}
+		for _, i := range order {
+			groups = append(groups, byComponent[i])
+		}
+	}
+	return groups
 }
 
 // AppendApplyTask appends a task to the task queue to apply the passed objects
@@ -232,25 +768,45 @@ func (t *TaskQueueBuilder) newApplyTask(applyObjs object.UnstructuredSet,
 	applyObjs = t.Collector.FilterInvalidObjects(applyObjs)
 	klog.V(2).Infof("adding apply task (%d objects)", len(applyObjs))
 	task := &task.ApplyTask{
-		TaskName:          fmt.Sprintf("apply-%d", t.applyCounter),
-		Objects:           applyObjs,
-		Filters:           applyFilters,
-		Mutators:          applyMutators,
-		ServerSideOptions: o.ServerSideOptions,
-		DryRunStrategy:    o.DryRunStrategy,
+		TaskName:            fmt.Sprintf("apply-%d", t.applyCounter),
+		Objects:             applyObjs,
+		Filters:             applyFilters,
+		Mutators:            applyMutators,
+		ServerSideOptions:   o.ServerSideOptions,
+		DryRunStrategy:      o.DryRunStrategy,
+		DynamicClient:       t.DynamicClient,
+		OpenAPIGetter:       t.OpenAPIGetter,
+		InfoHelper:          t.InfoHelper,
+		Mapper:              t.Mapper,
+		RecordPreviousState: o.RollbackOnFailure,
+		Unchanged:           t.unchanged,
+		Concurrency:         o.ApplyConcurrency,
+		RetryPolicy:         o.RetryPolicy,
+	}
+	t.applyCounter++
+	return task
+}
+
+// newRollbackTask returns a task that rolls back the applied objects, if any
+// applied object fails to reconcile.
+func (t *TaskQueueBuilder) newRollbackTask(applyObjs object.UnstructuredSet, o Options) *task.RollbackTask {
+	applyObjs = t.Collector.FilterInvalidObjects(applyObjs)
+	klog.V(2).Infof("adding rollback task (%d objects)", len(applyObjs))
+	task := &task.RollbackTask{
+		TaskName:          fmt.Sprintf("rollback-%d", t.rollbackCounter),
 		DynamicClient:     t.DynamicClient,
-		OpenAPIGetter:     t.OpenAPIGetter,
-		InfoHelper:        t.InfoHelper,
 		Mapper:            t.Mapper,
+		Objects:           object.UnstructuredSetToObjMetadataSet(applyObjs),
+		PropagationPolicy: o.PrunePropagationPolicy,
 	}
-	t.applyCounter++
+	t.rollbackCounter++
 	return task
 }
 
 // AppendWaitTask appends a task to wait on the passed objects to the task queue.
 // Returns a pointer to the Builder to chain function calls.
 func (t *TaskQueueBuilder) newWaitTask(waitIDs object.ObjMetadataSet, condition taskrunner.Condition,
-	waitTimeout time.Duration) taskrunner.Task {
+	waitTimeout time.Duration) *taskrunner.WaitTask {
 	waitIDs = t.Collector.FilterInvalidIds(waitIDs)
 	klog.V(2).Infoln("adding wait task")
 	task := taskrunner.NewWaitTask(
@@ -264,6 +820,148 @@ func (t *TaskQueueBuilder) newWaitTask(waitIDs object.ObjMetadataSet, condition
 	return task
 }
 
+// newApplyWaitTask returns a task that waits for the applied objects to
+// reconcile, for at least their stability window, and to meet the conditions
+// required by their dependents.
+func (t *TaskQueueBuilder) newApplyWaitTask(g *graph.Graph, applySet object.UnstructuredSet, o Options) taskrunner.Task {
+	applyIDs := object.UnstructuredSetToObjMetadataSet(applySet)
+	waitTask := t.newWaitTask(applyIDs, taskrunner.AllCurrent, o.ReconcileTimeout)
+	waitTask.Conditions = t.waitConditions(g, waitTask.IDs)
+	waitTask.Timeouts = objectTimeouts(applySet, o.ReconcileTimeouts)
+	waitTask.StabilityWindow = o.StabilityWindow
+	for i, obj := range applySet {
+		// Invalid annotations are rejected by validation
+		window, found, err := reconcile.ReadStabilityWindow(obj)
+		if err != nil || !found {
+			continue
+		}
+		if waitTask.StabilityWindows == nil {
+			waitTask.StabilityWindows = make(map[object.ObjMetadata]time.Duration)
+		}
+		waitTask.StabilityWindows[applyIDs[i]] = window
+	}
+	return waitTask
+}
+
+// newExternalWaitTask returns a task that waits for the external dependencies
+// of the objects to become Current, and to meet the conditions required by
+// their dependents, or nil if there are none that are not already waited for.
+// The external dependencies are registered in the TaskContext, even if not
+// waited for in dry-run.
+func (t *TaskQueueBuilder) newExternalWaitTask(taskContext *taskrunner.TaskContext, g *graph.Graph,
+	objs object.UnstructuredSet, o Options) *taskrunner.WaitTask {
+	var externalIDs object.ObjMetadataSet
+	for _, id := range object.UnstructuredSetToObjMetadataSet(objs) {
+		for _, depID := range g.ExternalDependencies(id) {
+			if _, found := t.externalWaitTasks[depID]; found || externalIDs.Contains(depID) {
+				continue
+			}
+			taskContext.AddExternalObject(depID)
+			externalIDs = append(externalIDs, depID)
+		}
+	}
+	// dry-run skips wait tasks
+	if len(externalIDs) == 0 || o.DryRunStrategy.ClientOrServerDryRun() {
+		return nil
+	}
+	timeout := o.ExternalDependencyTimeout
+	if timeout == 0 {
+		timeout = o.ReconcileTimeout
+	}
+	klog.V(2).Infof("adding external dependency wait task (%d objects)", len(externalIDs))
+	waitTask := t.newWaitTask(externalIDs, taskrunner.AllCurrent, timeout)
+	waitTask.Conditions = t.waitConditions(g, waitTask.IDs)
+	waitTask.External = true
+	for _, id := range externalIDs {
+		t.externalWaitTasks[id] = waitTask
+	}
+	return waitTask
+}
+
+// waitConditions returns the conditions, other than Current, that the
+// dependents of the objects require them to meet, or nil if there are none.
+// Unsupported conditions are treated as validation errors of the dependency.
+func (t *TaskQueueBuilder) waitConditions(g *graph.Graph, ids object.ObjMetadataSet) map[object.ObjMetadata][]taskrunner.ObjectCondition {
+	var conds map[object.ObjMetadata][]taskrunner.ObjectCondition
+	for _, id := range ids {
+		for _, c := range g.DependentConditions(id) {
+			cond, err := waitCondition(c)
+			if err != nil {
+				t.Collector.Collect(validation.NewError(err, id))
+				continue
+			}
+			if conds == nil {
+				conds = make(map[object.ObjMetadata][]taskrunner.ObjectCondition)
+			}
+			conds[id] = append(conds[id], cond)
+		}
+	}
+	return conds
+}
+
+// waitCondition returns the wait task ObjectCondition of a dependency
+// condition other than Current.
+func waitCondition(c dependson.Condition) (taskrunner.ObjectCondition, error) {
+	switch c.Type {
+	case dependson.ConditionComplete:
+		return taskrunner.ObjectCondition{Kind: taskrunner.Completed}, nil
+	case dependson.ConditionTrue:
+		return taskrunner.ObjectCondition{Kind: taskrunner.ConditionTrue, Type: c.Value}, nil
+	case dependson.ConditionFieldNotEmpty:
+		return taskrunner.ObjectCondition{Kind: taskrunner.FieldNotEmpty, JSONPath: c.Value}, nil
+	default:
+		return taskrunner.ObjectCondition{}, fmt.Errorf("unsupported dependency condition: %q", c)
+	}
+}
+
+// newPruneWaitTask returns a task that waits for the pruned objects to be
+// deleted.
+func (t *TaskQueueBuilder) newPruneWaitTask(pruneSet object.UnstructuredSet, o Options) taskrunner.Task {
+	pruneIDs := object.UnstructuredSetToObjMetadataSet(pruneSet)
+	waitTask := t.newWaitTask(pruneIDs, taskrunner.AllNotFound, o.PruneTimeout)
+	waitTask.Timeouts = objectTimeouts(pruneSet, o.PruneTimeouts)
+	return waitTask
+}
+
+// objectTimeouts returns the timeouts of the objects that override the
+// default timeout, either with the reconcile-timeout annotation or by
+// GroupKind. The annotation takes precedence.
+func objectTimeouts(objs object.UnstructuredSet, timeoutsByGK map[schema.GroupKind]time.Duration) map[object.ObjMetadata]time.Duration {
+	var timeouts map[object.ObjMetadata]time.Duration
+	for _, obj := range objs {
+		timeout, found, err := reconcile.ReadTimeout(obj)
+		if err != nil {
+			// Apply objects are validated, but prune objects are not.
+			klog.Warningf("ignoring invalid annotation: %v", err)
+		}
+		if !found {
+			timeout, found = timeoutsByGK[obj.GroupVersionKind().GroupKind()]
+		}
+		if !found {
+			continue
+		}
+		if timeouts == nil {
+			timeouts = make(map[object.ObjMetadata]time.Duration)
+		}
+		timeouts[object.UnstructuredToObjMetadata(obj)] = timeout
+	}
+	return timeouts
+}
+
+// newCheckpointTask returns a task that persists the object statuses to the
+// inventory.
+func (t *TaskQueueBuilder) newCheckpointTask(o Options) taskrunner.Task {
+	klog.V(2).Infoln("adding inventory checkpoint task")
+	task := &task.InvCheckpointTask{
+		TaskName:  fmt.Sprintf("inventory-checkpoint-%d", t.checkpointCounter),
+		Inventory: t.Inventory,
+		InvClient: t.InvClient,
+		DryRun:    o.DryRunStrategy,
+	}
+	t.checkpointCounter++
+	return task
+}
+
 // AppendPruneTask appends a task to delete objects from the cluster to the task queue.
 // Returns a pointer to the Builder to chain function calls.
 func (t *TaskQueueBuilder) newPruneTask(pruneObjs object.UnstructuredSet,
@@ -278,6 +976,8 @@ func (t *TaskQueueBuilder) newPruneTask(pruneObjs object.UnstructuredSet,
 		PropagationPolicy: o.PrunePropagationPolicy,
 		DryRunStrategy:    o.DryRunStrategy,
 		Destroy:           o.Destroy,
+		Concurrency:       o.PruneConcurrency,
+		RetryPolicy:       o.RetryPolicy,
 	}
 	t.pruneCounter++
 	return pruneTask
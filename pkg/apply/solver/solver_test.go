@@ -4,13 +4,17 @@
 package solver
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
 	"sigs.k8s.io/cli-utils/pkg/apply/task"
@@ -18,12 +22,21 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/dependson"
 	"sigs.k8s.io/cli-utils/pkg/object/graph"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
+	"sigs.k8s.io/cli-utils/pkg/object/reconcile"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
 var (
+	externalSecretID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "Secret"},
+		Name:      "shared",
+		Namespace: "shared-namespace",
+	}
+
 	pruner    = &prune.Pruner{}
 	resources = map[string]string{
 		"pod": `
@@ -750,6 +763,7 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					typedTask.Inventory = inventoryObj
 				case *task.DeleteOrUpdateInvTask:
 					typedTask.Inventory = inventoryObj
+					typedTask.Objects = tc.applyObjs
 				}
 			}
 
@@ -1711,6 +1725,7 @@ func TestTaskQueueBuilder_ApplyPruneBuild(t *testing.T) {
 					typedTask.Inventory = inventoryObj
 				case *task.DeleteOrUpdateInvTask:
 					typedTask.Inventory = inventoryObj
+					typedTask.Objects = tc.applyObjs
 				}
 			}
 
@@ -1780,3 +1795,1072 @@ func inventoryInfoComparer() cmp.Option {
 			x.GetNamespace() == y.GetNamespace()
 	})
 }
+
+func TestTaskQueueBuilder_ParallelBuild(t *testing.T) {
+	// actionGroup is a subset of event.ActionGroup, to simplify comparison
+	type actionGroup struct {
+		Name      string
+		DependsOn []string
+	}
+
+	uObj := newInvObject("abc-123", "default", "test")
+
+	testCases := map[string]struct {
+		applyObjs      []*unstructured.Unstructured
+		pruneObjs      []*unstructured.Unstructured
+		options        Options
+		expectedGroups []actionGroup
+	}{
+		"independent objects apply concurrently": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			options: Options{ParallelPhases: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				// namespace
+				{Name: "apply-0", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-0", DependsOn: []string{"apply-0"}},
+				// default-pod
+				{Name: "apply-1", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-1", DependsOn: []string{"apply-1"}},
+				// pod, in namespace
+				{Name: "apply-2", DependsOn: []string{"inventory-add-0", "wait-0"}},
+				{Name: "wait-2", DependsOn: []string{"apply-2"}},
+				{Name: "inventory-set-0", DependsOn: []string{
+					"inventory-add-0", "apply-0", "wait-0", "apply-1", "wait-1", "apply-2", "wait-2",
+				}},
+			},
+		},
+		"dry-run skips wait tasks": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			options: Options{
+				ParallelPhases: true,
+				DryRunStrategy: common.DryRunClient,
+			},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0", DependsOn: []string{"inventory-add-0"}},
+				{Name: "apply-1", DependsOn: []string{"inventory-add-0"}},
+				{Name: "apply-2", DependsOn: []string{"inventory-add-0", "apply-0"}},
+				{Name: "inventory-set-0", DependsOn: []string{
+					"inventory-add-0", "apply-0", "apply-1", "apply-2",
+				}},
+			},
+		},
+		"prune waits for apply and for dependents to be pruned": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			pruneObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			options: Options{
+				ParallelPhases: true,
+				Prune:          true,
+			},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				// default-pod
+				{Name: "apply-0", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-0", DependsOn: []string{"apply-0"}},
+				// pod, in namespace
+				{Name: "prune-0", DependsOn: []string{"wait-0"}},
+				{Name: "wait-1", DependsOn: []string{"prune-0"}},
+				// namespace
+				{Name: "prune-1", DependsOn: []string{"wait-0", "wait-1"}},
+				{Name: "wait-2", DependsOn: []string{"prune-1"}},
+				{Name: "inventory-set-0", DependsOn: []string{
+					"inventory-add-0", "apply-0", "wait-0", "prune-0", "wait-1", "prune-1", "wait-2",
+				}},
+			},
+		},
+		"external dependencies are waited for concurrently": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+				testutil.Unstructured(t, resources["default-pod"],
+					testutil.AddDependsOn(t, externalSecretID)),
+			},
+			options: Options{
+				ParallelPhases:            true,
+				AllowExternalDependencies: true,
+			},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				// namespace
+				{Name: "apply-0", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-0", DependsOn: []string{"apply-0"}},
+				// external secret
+				{Name: "wait-1", DependsOn: []string{"inventory-add-0"}},
+				// default-pod, after the external secret
+				{Name: "apply-1", DependsOn: []string{"inventory-add-0", "wait-1"}},
+				{Name: "wait-2", DependsOn: []string{"apply-1"}},
+				// pod, in namespace
+				{Name: "apply-2", DependsOn: []string{"inventory-add-0", "wait-0"}},
+				{Name: "wait-3", DependsOn: []string{"apply-2"}},
+				{Name: "inventory-set-0", DependsOn: []string{
+					"inventory-add-0", "apply-0", "wait-0", "wait-1", "apply-1", "wait-2", "apply-2", "wait-3",
+				}},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mapper := testutil.NewFakeRESTMapper()
+			inventoryObj := inventory.NewSingleObjectInventory(uObj)
+			applyIDs := object.UnstructuredSetToObjMetadataSet(tc.applyObjs)
+			fakeInvClient := inventory.NewFakeClient(applyIDs)
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    mapper,
+				Inventory: inventoryObj,
+				InvClient: fakeInvClient,
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+			tq := tqb.WithApplyObjects(tc.applyObjs).
+				WithPruneObjects(tc.pruneObjs).
+				Build(taskContext, tc.options)
+			assert.NoError(t, vCollector.ToError())
+
+			var groups []actionGroup
+			for _, ag := range tq.ToActionGroups() {
+				groups = append(groups, actionGroup{
+					Name:      ag.Name,
+					DependsOn: ag.DependsOn,
+				})
+			}
+			testutil.AssertEqual(t, tc.expectedGroups, groups)
+
+			// The task graph must contain the same tasks, in the same order.
+			taskGraph := tq.ToGraph()
+			assert.Equal(t, tq.tasks, taskGraph.Tasks())
+		})
+	}
+}
+
+func TestTaskQueueBuilder_WaitTaskOptions(t *testing.T) {
+	uObj := newInvObject("abc-123", "default", "test")
+
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	deployment.SetAnnotations(map[string]string{
+		reconcile.TimeoutAnnotation:         "20m",
+		reconcile.StabilityWindowAnnotation: "1m",
+	})
+	secret := testutil.Unstructured(t, resources["secret"])
+	pod := testutil.Unstructured(t, resources["pod"])
+	namespace := testutil.Unstructured(t, resources["namespace"])
+	namespace.SetAnnotations(map[string]string{
+		reconcile.TimeoutAnnotation: "10m",
+	})
+	crontab := testutil.Unstructured(t, resources["crontab1"])
+
+	mapper := testutil.NewFakeRESTMapper()
+	inventoryObj := inventory.NewSingleObjectInventory(uObj)
+	applyObjs := []*unstructured.Unstructured{deployment, secret, pod}
+	pruneObjs := []*unstructured.Unstructured{namespace, crontab}
+	fakeInvClient := inventory.NewFakeClient(object.UnstructuredSetToObjMetadataSet(applyObjs))
+	vCollector := &validation.Collector{}
+	tqb := TaskQueueBuilder{
+		Pruner:    pruner,
+		Mapper:    mapper,
+		Inventory: inventoryObj,
+		InvClient: fakeInvClient,
+		Collector: vCollector,
+	}
+	taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+	tq := tqb.WithApplyObjects(applyObjs).
+		WithPruneObjects(pruneObjs).
+		Build(taskContext, Options{
+			Prune:            true,
+			ReconcileTimeout: time.Minute,
+			ReconcileTimeouts: map[schema.GroupKind]time.Duration{
+				// The annotation takes precedence
+				{Group: "apps", Kind: "Deployment"}: time.Hour,
+				{Kind: "Secret"}:                    30 * time.Second,
+			},
+			PruneTimeout: 5 * time.Minute,
+			PruneTimeouts: map[schema.GroupKind]time.Duration{
+				{Kind: "Namespace"}: time.Hour,
+			},
+			StabilityWindow: 10 * time.Second,
+		})
+	require.NoError(t, vCollector.ToError())
+
+	var waitTasks []*taskrunner.WaitTask
+	for _, tsk := range tq.tasks {
+		if waitTask, ok := tsk.(*taskrunner.WaitTask); ok {
+			waitTasks = append(waitTasks, waitTask)
+		}
+	}
+	require.Len(t, waitTasks, 3)
+
+	applyWait := waitTasks[0]
+	assert.Equal(t, time.Minute, applyWait.Timeout)
+	assert.Equal(t, map[object.ObjMetadata]time.Duration{
+		object.UnstructuredToObjMetadata(deployment): 20 * time.Minute,
+		object.UnstructuredToObjMetadata(secret):     30 * time.Second,
+	}, applyWait.Timeouts)
+	assert.Equal(t, 10*time.Second, applyWait.StabilityWindow)
+	assert.Equal(t, map[object.ObjMetadata]time.Duration{
+		object.UnstructuredToObjMetadata(deployment): time.Minute,
+	}, applyWait.StabilityWindows)
+
+	// The namespace is pruned after the CronTab in it
+	crontabWait := waitTasks[1]
+	assert.Equal(t, 5*time.Minute, crontabWait.Timeout)
+	assert.Nil(t, crontabWait.Timeouts)
+	namespaceWait := waitTasks[2]
+	assert.Equal(t, 5*time.Minute, namespaceWait.Timeout)
+	assert.Equal(t, map[object.ObjMetadata]time.Duration{
+		object.UnstructuredToObjMetadata(namespace): 10 * time.Minute,
+	}, namespaceWait.Timeouts)
+	assert.Zero(t, namespaceWait.StabilityWindow)
+}
+
+func TestTaskQueueBuilder_ExternalDependencies(t *testing.T) {
+	uObj := newInvObject("abc-123", "default", "test")
+
+	deployment := testutil.Unstructured(t, resources["deployment"],
+		testutil.AddDependsOn(t, externalSecretID))
+	secret := testutil.Unstructured(t, resources["secret"])
+	pod := testutil.Unstructured(t, resources["pod"],
+		testutil.AddDependsOn(t, externalSecretID, testutil.ToIdentifier(t, resources["secret"])))
+	applyObjs := []*unstructured.Unstructured{deployment, secret, pod}
+
+	testCases := map[string]struct {
+		options               Options
+		expectedTasks         []string
+		expectedExternalIDs   object.ObjMetadataSet
+		expectedWaitTimeout   time.Duration
+		expectValidationError bool
+	}{
+		"external dependencies are invalid by default": {
+			expectedTasks: []string{
+				"inventory-add-0", "apply-0", "wait-0", "inventory-set-0",
+			},
+			expectValidationError: true,
+		},
+		"external dependencies are waited for once": {
+			options: Options{
+				AllowExternalDependencies: true,
+				ReconcileTimeout:          time.Minute,
+			},
+			expectedTasks: []string{
+				"inventory-add-0",
+				// deployment and secret, after the external secret
+				"wait-0", "apply-0", "wait-1",
+				// pod, after the secret
+				"apply-1", "wait-2",
+				"inventory-set-0",
+			},
+			expectedExternalIDs: object.ObjMetadataSet{externalSecretID},
+			expectedWaitTimeout: time.Minute,
+		},
+		"external dependency timeout": {
+			options: Options{
+				AllowExternalDependencies: true,
+				ReconcileTimeout:          time.Minute,
+				ExternalDependencyTimeout: time.Hour,
+			},
+			expectedTasks: []string{
+				"inventory-add-0", "wait-0", "apply-0", "wait-1", "apply-1", "wait-2", "inventory-set-0",
+			},
+			expectedExternalIDs: object.ObjMetadataSet{externalSecretID},
+			expectedWaitTimeout: time.Hour,
+		},
+		"dry-run skips external wait tasks": {
+			options: Options{
+				AllowExternalDependencies: true,
+				DryRunStrategy:            common.DryRunClient,
+			},
+			expectedTasks: []string{
+				"inventory-add-0", "apply-0", "apply-1", "inventory-set-0",
+			},
+			expectedExternalIDs: object.ObjMetadataSet{externalSecretID},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mapper := testutil.NewFakeRESTMapper()
+			inventoryObj := inventory.NewSingleObjectInventory(uObj)
+			fakeInvClient := inventory.NewFakeClient(object.UnstructuredSetToObjMetadataSet(applyObjs))
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    mapper,
+				Inventory: inventoryObj,
+				InvClient: fakeInvClient,
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+			tq := tqb.WithApplyObjects(applyObjs).Build(taskContext, tc.options)
+			if tc.expectValidationError {
+				require.Error(t, vCollector.ToError())
+			} else {
+				require.NoError(t, vCollector.ToError())
+			}
+
+			var names []string
+			for _, tsk := range tq.tasks {
+				names = append(names, tsk.Name())
+			}
+			testutil.AssertEqual(t, tc.expectedTasks, names)
+			testutil.AssertEqual(t, tc.expectedExternalIDs, taskContext.ExternalObjects())
+
+			if tc.expectedWaitTimeout == 0 {
+				return
+			}
+			externalWait, ok := tq.tasks[1].(*taskrunner.WaitTask)
+			require.True(t, ok)
+			assert.True(t, externalWait.External)
+			assert.Equal(t, taskrunner.AllCurrent, externalWait.Condition)
+			assert.Equal(t, object.ObjMetadataSet{externalSecretID}, externalWait.IDs)
+			assert.Equal(t, tc.expectedWaitTimeout, externalWait.Timeout)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_DependsOnConditions(t *testing.T) {
+	uObj := newInvObject("abc-123", "default", "test")
+
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+	podID := testutil.ToIdentifier(t, resources["pod"])
+	deployment := testutil.Unstructured(t, resources["deployment"],
+		testutil.AddDependsOn(t, secretID, podID, externalSecretID),
+		testutil.AddDependsOnConditions(t, map[object.ObjMetadata]dependson.Condition{
+			podID:            {Type: dependson.ConditionComplete},
+			externalSecretID: {Type: dependson.ConditionFieldNotEmpty, Value: "$.data.password"},
+		}))
+	secret := testutil.Unstructured(t, resources["secret"])
+	pod := testutil.Unstructured(t, resources["pod"])
+	applyObjs := []*unstructured.Unstructured{deployment, secret, pod}
+
+	mapper := testutil.NewFakeRESTMapper()
+	inventoryObj := inventory.NewSingleObjectInventory(uObj)
+	fakeInvClient := inventory.NewFakeClient(object.UnstructuredSetToObjMetadataSet(applyObjs))
+	vCollector := &validation.Collector{}
+	tqb := TaskQueueBuilder{
+		Pruner:    pruner,
+		Mapper:    mapper,
+		Inventory: inventoryObj,
+		InvClient: fakeInvClient,
+		Collector: vCollector,
+	}
+	taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+	tq := tqb.WithApplyObjects(applyObjs).Build(taskContext, Options{
+		AllowExternalDependencies: true,
+	})
+	require.NoError(t, vCollector.ToError())
+
+	var names []string
+	for _, tsk := range tq.tasks {
+		names = append(names, tsk.Name())
+	}
+	testutil.AssertEqual(t, []string{
+		"inventory-add-0",
+		// pod and secret
+		"apply-0", "wait-0",
+		// deployment, after the external secret
+		"wait-1", "apply-1", "wait-2",
+		"inventory-set-0",
+	}, names)
+
+	// The pod must also meet the condition required by the deployment
+	applyWait, ok := tq.tasks[2].(*taskrunner.WaitTask)
+	require.True(t, ok)
+	assert.Equal(t, taskrunner.AllCurrent, applyWait.Condition)
+	assert.Equal(t, map[object.ObjMetadata][]taskrunner.ObjectCondition{
+		podID: {{Kind: taskrunner.Completed}},
+	}, applyWait.Conditions)
+
+	// The external secret must also meet the condition required by the
+	// deployment
+	externalWait, ok := tq.tasks[3].(*taskrunner.WaitTask)
+	require.True(t, ok)
+	assert.True(t, externalWait.External)
+	assert.Equal(t, map[object.ObjMetadata][]taskrunner.ObjectCondition{
+		externalSecretID: {{Kind: taskrunner.FieldNotEmpty, JSONPath: "$.data.password"}},
+	}, externalWait.Conditions)
+
+	// The deployment has no dependents
+	deploymentWait, ok := tq.tasks[5].(*taskrunner.WaitTask)
+	require.True(t, ok)
+	assert.Nil(t, deploymentWait.Conditions)
+}
+
+func TestWaitCondition(t *testing.T) {
+	testCases := map[string]struct {
+		condition     dependson.Condition
+		expected      taskrunner.ObjectCondition
+		expectedError string
+	}{
+		"complete": {
+			condition: dependson.Condition{Type: dependson.ConditionComplete},
+			expected:  taskrunner.ObjectCondition{Kind: taskrunner.Completed},
+		},
+		"condition": {
+			condition: dependson.Condition{Type: dependson.ConditionTrue, Value: "Ready"},
+			expected:  taskrunner.ObjectCondition{Kind: taskrunner.ConditionTrue, Type: "Ready"},
+		},
+		"jsonpath": {
+			condition: dependson.Condition{Type: dependson.ConditionFieldNotEmpty, Value: "$.data.password"},
+			expected:  taskrunner.ObjectCondition{Kind: taskrunner.FieldNotEmpty, JSONPath: "$.data.password"},
+		},
+		"current": {
+			condition:     dependson.Condition{Type: dependson.ConditionCurrent},
+			expectedError: `unsupported dependency condition: "current"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			cond, err := waitCondition(tc.condition)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cond)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_CheckpointBuild(t *testing.T) {
+	// actionGroup is a subset of event.ActionGroup, to simplify comparison
+	type actionGroup struct {
+		Name      string
+		DependsOn []string
+	}
+
+	uObj := newInvObject("abc-123", "default", "test")
+
+	checkpointStatus := func(u *unstructured.Unstructured) actuation.ObjectStatus {
+		return actuation.ObjectStatus{
+			ObjectReference: inventory.ObjectReferenceFromObjMetadata(object.UnstructuredToObjMetadata(u)),
+			Strategy:        actuation.ActuationStrategyApply,
+			Actuation:       actuation.ActuationSucceeded,
+			Reconcile:       actuation.ReconcileSucceeded,
+			UID:             "unused-uid",
+			Generation:      1,
+			Hash:            "unused-hash",
+		}
+	}
+
+	testCases := map[string]struct {
+		applyObjs        []*unstructured.Unstructured
+		checkpointedObjs []*unstructured.Unstructured
+		options          Options
+		expectedGroups   []actionGroup
+	}{
+		"checkpoint after each apply phase": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			options: Options{Checkpoint: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0"},
+				{Name: "wait-0"},
+				{Name: "inventory-checkpoint-0"},
+				{Name: "apply-1"},
+				{Name: "wait-1"},
+				{Name: "inventory-checkpoint-1"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"dry-run skips checkpoint tasks": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			options: Options{
+				Checkpoint:     true,
+				DryRunStrategy: common.DryRunClient,
+			},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0"},
+				{Name: "apply-1"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"checkpointed phase is skipped": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			checkpointedObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			options: Options{Checkpoint: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0"},
+				{Name: "wait-0"},
+				{Name: "inventory-checkpoint-0"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"partially checkpointed phase is not skipped": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			checkpointedObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+			},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0"},
+				{Name: "wait-0"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"parallel checkpoints are sequential": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			checkpointedObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+			},
+			options: Options{
+				Checkpoint:     true,
+				ParallelPhases: true,
+			},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				// default-pod
+				{Name: "apply-0", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-0", DependsOn: []string{"apply-0"}},
+				// pod, in checkpointed namespace
+				{Name: "apply-1", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-1", DependsOn: []string{"apply-1"}},
+				{Name: "inventory-checkpoint-0", DependsOn: []string{"wait-0"}},
+				{Name: "inventory-checkpoint-1", DependsOn: []string{"wait-1", "inventory-checkpoint-0"}},
+				{Name: "inventory-set-0", DependsOn: []string{
+					"inventory-add-0", "apply-0", "wait-0", "apply-1", "wait-1",
+					"inventory-checkpoint-0", "inventory-checkpoint-1",
+				}},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mapper := testutil.NewFakeRESTMapper()
+			inventoryObj := inventory.NewSingleObjectInventory(uObj)
+			applyIDs := object.UnstructuredSetToObjMetadataSet(tc.applyObjs)
+			fakeInvClient := inventory.NewFakeClient(applyIDs)
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    mapper,
+				Inventory: inventoryObj,
+				InvClient: fakeInvClient,
+				Collector: vCollector,
+			}
+			var checkpoint object.ObjectStatusSet
+			for _, u := range tc.checkpointedObjs {
+				checkpoint = append(checkpoint, checkpointStatus(u))
+			}
+			taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+			tq := tqb.WithApplyObjects(tc.applyObjs).
+				WithCheckpointedObjects(checkpoint).
+				Build(taskContext, tc.options)
+			assert.NoError(t, vCollector.ToError())
+
+			var groups []actionGroup
+			for _, ag := range tq.ToActionGroups() {
+				groups = append(groups, actionGroup{
+					Name:      ag.Name,
+					DependsOn: ag.DependsOn,
+				})
+			}
+			testutil.AssertEqual(t, tc.expectedGroups, groups)
+
+			// Statuses of skipped objects are restored
+			for _, expected := range checkpoint {
+				id := inventory.ObjMetadataFromObjectReference(expected.ObjectReference)
+				actual, found := taskContext.InventoryManager().ObjectStatus(id)
+				require.True(t, found)
+				if taskContext.InventoryManager().IsPendingApply(id) {
+					// partially checkpointed phases are applied again
+					continue
+				}
+				assert.Equal(t, expected, *actual)
+			}
+		})
+	}
+}
+
+func TestTaskQueueBuilder_RollbackBuild(t *testing.T) {
+	// actionGroup is a subset of event.ActionGroup, to simplify comparison
+	type actionGroup struct {
+		Name      string
+		DependsOn []string
+	}
+
+	uObj := newInvObject("abc-123", "default", "test")
+	namespaceID := testutil.ToIdentifier(t, resources["namespace"])
+	podID := testutil.ToIdentifier(t, resources["pod"])
+
+	testCases := map[string]struct {
+		options                 Options
+		expectedGroups          []actionGroup
+		expectedRollbackObjects []object.ObjMetadataSet
+	}{
+		"phases are rolled back in reverse order before the inventory is set": {
+			options: Options{RollbackOnFailure: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0"},
+				{Name: "wait-0"},
+				{Name: "apply-1"},
+				{Name: "wait-1"},
+				{Name: "rollback-0"},
+				{Name: "rollback-1"},
+				{Name: "inventory-set-0"},
+			},
+			expectedRollbackObjects: []object.ObjMetadataSet{{podID}, {namespaceID}},
+		},
+		"dry-run skips rollback tasks": {
+			options: Options{
+				RollbackOnFailure: true,
+				DryRunStrategy:    common.DryRunClient,
+			},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0"},
+				{Name: "apply-1"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"parallel rollbacks are sequential": {
+			options: Options{
+				RollbackOnFailure: true,
+				ParallelPhases:    true,
+			},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-0", DependsOn: []string{"apply-0"}},
+				{Name: "apply-1", DependsOn: []string{"inventory-add-0", "wait-0"}},
+				{Name: "wait-1", DependsOn: []string{"apply-1"}},
+				{Name: "rollback-0", DependsOn: []string{
+					"inventory-add-0", "apply-0", "wait-0", "apply-1", "wait-1",
+				}},
+				{Name: "rollback-1", DependsOn: []string{"rollback-0"}},
+				{Name: "inventory-set-0", DependsOn: []string{
+					"inventory-add-0", "apply-0", "wait-0", "apply-1", "wait-1",
+					"rollback-0", "rollback-1",
+				}},
+			},
+			expectedRollbackObjects: []object.ObjMetadataSet{{podID}, {namespaceID}},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			applyObjs := []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["namespace"]),
+				testutil.Unstructured(t, resources["pod"]),
+			}
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    testutil.NewFakeRESTMapper(),
+				Inventory: inventory.NewSingleObjectInventory(uObj),
+				InvClient: inventory.NewFakeClient(object.UnstructuredSetToObjMetadataSet(applyObjs)),
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+			tq := tqb.WithApplyObjects(applyObjs).Build(taskContext, tc.options)
+			assert.NoError(t, vCollector.ToError())
+
+			var groups []actionGroup
+			for _, ag := range tq.ToActionGroups() {
+				groups = append(groups, actionGroup{
+					Name:      ag.Name,
+					DependsOn: ag.DependsOn,
+				})
+			}
+			testutil.AssertEqual(t, tc.expectedGroups, groups)
+
+			var rollbackObjects []object.ObjMetadataSet
+			for _, rollbackTask := range tq.RollbackTasks() {
+				rollbackObjects = append(rollbackObjects, rollbackTask.Objects)
+			}
+			testutil.AssertEqual(t, tc.expectedRollbackObjects, rollbackObjects)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_HookBuild(t *testing.T) {
+	// actionGroup is a subset of event.ActionGroup, to simplify comparison
+	type actionGroup struct {
+		Name      string
+		DependsOn []string
+	}
+
+	uObj := newInvObject("abc-123", "default", "test")
+
+	newJob := func(name string, mutators ...testutil.Mutator) *unstructured.Unstructured {
+		return testutil.Unstructured(t, fmt.Sprintf(`
+kind: Job
+apiVersion: batch/v1
+metadata:
+  name: %s
+  namespace: test-namespace
+`, name), mutators...)
+	}
+	hookObjs := []*unstructured.Unstructured{
+		newJob("pre-apply", testutil.AddHook(hook.PreApply, hook.DeleteSucceeded)),
+		newJob("post-apply", testutil.AddHook(hook.PostApply, "")),
+		newJob("pre-prune", testutil.AddHook(hook.PrePrune, hook.DeleteNever)),
+		newJob("post-destroy", testutil.AddHook(hook.PostDestroy, hook.DeleteAlways)),
+	}
+	// previousJob returns the previous instance of a hook in the cluster
+	previousJob := func(hookObj *unstructured.Unstructured) *unstructured.Unstructured {
+		u := hookObj.DeepCopy()
+		u.SetUID(types.UID(u.GetName() + "-uid"))
+		return u
+	}
+
+	testCases := map[string]struct {
+		applyObjs        []*unstructured.Unstructured
+		pruneObjs        []*unstructured.Unstructured
+		previousHookObjs []*unstructured.Unstructured
+		options          Options
+		expectedGroups   []actionGroup
+	}{
+		"apply hooks": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			pruneObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				// pre-apply
+				{Name: "apply-0"},
+				{Name: "wait-0"},
+				{Name: "hook-delete-0"},
+				// pod
+				{Name: "apply-1"},
+				{Name: "wait-1"},
+				// post-apply
+				{Name: "apply-2"},
+				{Name: "wait-2"},
+				// pre-prune
+				{Name: "apply-3"},
+				{Name: "wait-3"},
+				// secret
+				{Name: "prune-0"},
+				{Name: "wait-4"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"pre-prune hooks need prune objects": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			options: Options{Prune: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0"},
+				{Name: "wait-0"},
+				{Name: "hook-delete-0"},
+				{Name: "apply-1"},
+				{Name: "wait-1"},
+				{Name: "apply-2"},
+				{Name: "wait-2"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"destroy hooks": {
+			pruneObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			options: Options{Prune: true, Destroy: true},
+			expectedGroups: []actionGroup{
+				{Name: "prune-0"},
+				{Name: "wait-0"},
+				// post-destroy
+				{Name: "apply-0"},
+				{Name: "wait-1"},
+				{Name: "hook-delete-0"},
+				{Name: "inventory-delete-or-update-0"},
+			},
+		},
+		"replace previous hooks": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			pruneObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			previousHookObjs: []*unstructured.Unstructured{
+				previousJob(hookObjs[0]),
+				previousJob(hookObjs[1]),
+			},
+			options: Options{Prune: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				// pre-apply
+				{Name: "hook-delete-0"},
+				{Name: "wait-0"},
+				{Name: "apply-0"},
+				{Name: "wait-1"},
+				{Name: "hook-delete-1"},
+				// pod
+				{Name: "apply-1"},
+				{Name: "wait-2"},
+				// post-apply
+				{Name: "hook-delete-2"},
+				{Name: "wait-3"},
+				{Name: "apply-2"},
+				{Name: "wait-4"},
+				// pre-prune
+				{Name: "apply-3"},
+				{Name: "wait-5"},
+				// secret
+				{Name: "prune-0"},
+				{Name: "wait-6"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"replace previous destroy hooks": {
+			pruneObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			previousHookObjs: []*unstructured.Unstructured{
+				previousJob(hookObjs[3]),
+			},
+			options: Options{Prune: true, Destroy: true},
+			expectedGroups: []actionGroup{
+				{Name: "prune-0"},
+				{Name: "wait-0"},
+				// post-destroy
+				{Name: "hook-delete-0"},
+				{Name: "wait-1"},
+				{Name: "apply-0"},
+				{Name: "wait-2"},
+				{Name: "hook-delete-1"},
+				{Name: "inventory-delete-or-update-0"},
+			},
+		},
+		"parallel replace previous hooks": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			previousHookObjs: []*unstructured.Unstructured{
+				previousJob(hookObjs[0]),
+			},
+			options: Options{Prune: true, ParallelPhases: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				// pre-apply
+				{Name: "hook-delete-0", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-0", DependsOn: []string{"hook-delete-0"}},
+				{Name: "apply-0", DependsOn: []string{"wait-0"}},
+				{Name: "wait-1", DependsOn: []string{"apply-0"}},
+				{Name: "hook-delete-1", DependsOn: []string{"wait-1"}},
+				// pod
+				{Name: "apply-1", DependsOn: []string{"hook-delete-1"}},
+				{Name: "wait-2", DependsOn: []string{"apply-1"}},
+				// post-apply
+				{Name: "apply-2", DependsOn: []string{"wait-2"}},
+				{Name: "wait-3", DependsOn: []string{"apply-2"}},
+				{Name: "inventory-set-0", DependsOn: []string{
+					"inventory-add-0", "hook-delete-0", "wait-0", "apply-0", "wait-1",
+					"hook-delete-1", "apply-1", "wait-2", "apply-2", "wait-3",
+				}},
+			},
+		},
+		"dry-run skips hook replace, wait and delete tasks": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			previousHookObjs: []*unstructured.Unstructured{
+				previousJob(hookObjs[0]),
+			},
+			options: Options{DryRunStrategy: common.DryRunClient},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				{Name: "apply-0"},
+				{Name: "apply-1"},
+				{Name: "apply-2"},
+				{Name: "inventory-set-0"},
+			},
+		},
+		"parallel hooks": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["pod"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			pruneObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true, ParallelPhases: true},
+			expectedGroups: []actionGroup{
+				{Name: "inventory-add-0"},
+				// pre-apply
+				{Name: "apply-0", DependsOn: []string{"inventory-add-0"}},
+				{Name: "wait-0", DependsOn: []string{"apply-0"}},
+				{Name: "hook-delete-0", DependsOn: []string{"wait-0"}},
+				// pods
+				{Name: "apply-1", DependsOn: []string{"hook-delete-0"}},
+				{Name: "wait-1", DependsOn: []string{"apply-1"}},
+				{Name: "apply-2", DependsOn: []string{"hook-delete-0"}},
+				{Name: "wait-2", DependsOn: []string{"apply-2"}},
+				// post-apply
+				{Name: "apply-3", DependsOn: []string{"wait-1", "wait-2"}},
+				{Name: "wait-3", DependsOn: []string{"apply-3"}},
+				// pre-prune
+				{Name: "apply-4", DependsOn: []string{"wait-3"}},
+				{Name: "wait-4", DependsOn: []string{"apply-4"}},
+				// secret
+				{Name: "prune-0", DependsOn: []string{"wait-4"}},
+				{Name: "wait-5", DependsOn: []string{"prune-0"}},
+				{Name: "inventory-set-0", DependsOn: []string{
+					"inventory-add-0", "apply-0", "wait-0", "hook-delete-0",
+					"apply-1", "wait-1", "apply-2", "wait-2", "apply-3", "wait-3",
+					"apply-4", "wait-4", "prune-0", "wait-5",
+				}},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mapper := testutil.NewFakeRESTMapper()
+			inventoryObj := inventory.NewSingleObjectInventory(uObj)
+			fakeInvClient := inventory.NewFakeClient(object.UnstructuredSetToObjMetadataSet(tc.pruneObjs))
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    mapper,
+				Inventory: inventoryObj,
+				InvClient: fakeInvClient,
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+			tq := tqb.WithApplyObjects(tc.applyObjs).
+				WithPruneObjects(tc.pruneObjs).
+				WithHookObjects(hookObjs).
+				WithPreviousHookObjects(tc.previousHookObjs).
+				Build(taskContext, tc.options)
+			require.NoError(t, vCollector.ToError())
+
+			var groups []actionGroup
+			for _, ag := range tq.ToActionGroups() {
+				groups = append(groups, actionGroup{
+					Name:      ag.Name,
+					DependsOn: ag.DependsOn,
+				})
+			}
+			testutil.AssertEqual(t, tc.expectedGroups, groups)
+
+			// All hooks are excluded from the inventory, even if not run
+			for _, hookObj := range hookObjs {
+				assert.True(t, taskContext.IsHookObject(object.UnstructuredToObjMetadata(hookObj)))
+			}
+
+			// Hooks are waited on until they complete, and their previous
+			// instances until they are deleted
+			previousIDs := object.UnstructuredSetToObjMetadataSet(tc.previousHookObjs)
+			for _, tsk := range tq.tasks {
+				if deleteTask, ok := tsk.(*task.HookDeleteTask); ok && deleteTask.Replace {
+					assert.Subset(t, previousIDs, deleteTask.Identifiers())
+				}
+				waitTask, ok := tsk.(*taskrunner.WaitTask)
+				if !ok {
+					continue
+				}
+				for _, id := range waitTask.IDs {
+					if id.GroupKind.Kind != "Job" {
+						continue
+					}
+					if waitTask.Condition == taskrunner.AllNotFound {
+						assert.Contains(t, previousIDs, id)
+						continue
+					}
+					assert.Equal(t, taskrunner.AllCurrent, waitTask.Condition)
+					assert.Equal(t, []taskrunner.ObjectCondition{{Kind: taskrunner.Completed}}, waitTask.Conditions[id])
+				}
+			}
+		})
+	}
+}
+
+func TestTaskQueueBuilder_InvalidHook(t *testing.T) {
+	uObj := newInvObject("abc-123", "default", "test")
+	invalidHook := testutil.Unstructured(t, resources["deployment"],
+		testutil.AddHook(hook.PreApply, ""))
+	pod := testutil.Unstructured(t, resources["pod"])
+
+	vCollector := &validation.Collector{}
+	tqb := TaskQueueBuilder{
+		Pruner:    pruner,
+		Mapper:    testutil.NewFakeRESTMapper(),
+		Inventory: inventory.NewSingleObjectInventory(uObj),
+		InvClient: inventory.NewFakeClient(object.ObjMetadataSet{}),
+		Collector: vCollector,
+	}
+	taskContext := taskrunner.NewTaskContext(t.Context(), nil, nil)
+	tq := tqb.WithApplyObjects([]*unstructured.Unstructured{pod}).
+		WithHookObjects([]*unstructured.Unstructured{invalidHook}).
+		Build(taskContext, Options{})
+
+	invalidID := object.UnstructuredToObjMetadata(invalidHook)
+	require.Error(t, vCollector.ToError())
+	assert.Contains(t, vCollector.InvalidIDs, invalidID)
+	assert.False(t, taskContext.IsHookObject(invalidID))
+
+	var names []string
+	for _, tsk := range tq.tasks {
+		names = append(names, tsk.Name())
+	}
+	testutil.AssertEqual(t, []string{
+		"inventory-add-0", "apply-0", "wait-0", "inventory-set-0",
+	}, names)
+}
+
+func TestPhaseGroups_MissingFromGraph(t *testing.T) {
+	pod := testutil.Unstructured(t, resources["pod"])
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	secret := testutil.Unstructured(t, resources["secret"])
+	podID := object.UnstructuredToObjMetadata(pod)
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	secretID := object.UnstructuredToObjMetadata(secret)
+
+	// The secret is not in the graph, so it must not be grouped with the
+	// objects of the first component.
+	g := graph.New()
+	g.AddVertex(podID)
+	g.AddVertex(deploymentID)
+	idSetList := []object.ObjMetadataSet{{podID, deploymentID, secretID}}
+
+	groups := phaseGroups(g, idSetList, object.UnstructuredSet{pod, deployment, secret})
+	assert.ElementsMatch(t, []object.UnstructuredSet{{pod}, {deployment}, {secret}}, groups)
+}
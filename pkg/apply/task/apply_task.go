@@ -9,8 +9,11 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -22,11 +25,13 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/cmd/apply"
 	cmddelete "k8s.io/kubectl/pkg/cmd/delete"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
 	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/object"
@@ -59,6 +64,19 @@ type ApplyTask struct {
 	Mutators          []mutator.Interface
 	DryRunStrategy    common.DryRunStrategy
 	ServerSideOptions common.ServerSideOptions
+	// RecordPreviousState enables storing the live state of each object in
+	// the TaskContext before it is applied, so it can be restored later.
+	RecordPreviousState bool
+	// Unchanged maps the objects that have not changed since they were last
+	// applied, locally or in the cluster, to their previous status. These
+	// objects are not sent to the API server, unless their hash differs.
+	Unchanged map[object.ObjMetadata]actuation.ObjectStatus
+	// Concurrency is the maximum number of objects to apply concurrently.
+	// If less than two, the objects are applied one at a time.
+	Concurrency int
+	// RetryPolicy defines how applying an object is retried when the server
+	// returns a transient error. The zero value does not retry.
+	RetryPolicy retry.Policy
 }
 
 // applyOptionsFactoryFunc is a factory function for creating a new
@@ -87,99 +105,168 @@ func (a *ApplyTask) Identifiers() object.ObjMetadataSet {
 // the desired state of a resource is changed.
 func (a *ApplyTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		ctx := taskContext.Context()
 		objects := a.Objects
 		klog.V(2).Infof("apply task starting (name: %q, objects: %d)",
 			a.Name(), len(objects))
-		for _, obj := range objects {
-			// Set the client and mapping fields on the provided
-			// info so they can be applied to the cluster.
-			info, err := a.InfoHelper.BuildInfo(obj)
-			// BuildInfo strips path annotations.
-			// Use modified object for filters, mutations, and events.
-			obj = info.Object.(*unstructured.Unstructured)
-			id := object.UnstructuredToObjMetadata(obj)
-			if err != nil {
-				err = applyerror.NewUnknownTypeError(err)
-				if klog.V(4).Enabled() {
-					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply task errored (object: %s): unable to convert obj to info: %v", id, err)
-				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
-				taskContext.InventoryManager().AddFailedApply(id)
-				continue
+		if a.Concurrency > 1 {
+			taskContext.RunConcurrently(a.Concurrency, objects,
+				func(obj *unstructured.Unstructured, eventChannel chan<- event.Event) {
+					a.applyObject(taskContext, obj, eventChannel)
+				})
+		} else {
+			for _, obj := range objects {
+				a.applyObject(taskContext, obj, taskContext.EventChannel())
 			}
+		}
+		a.sendTaskResult(taskContext)
+	}()
+}
 
-			// Check filters to see if we're prevented from applying.
-			var filterErr error
-			for _, applyFilter := range a.Filters {
-				klog.V(6).Infof("apply filter evaluating (filter: %s, object: %s)", applyFilter.Name(), id)
-				filterErr = applyFilter.Filter(taskContext.Context(), obj)
-				if filterErr != nil {
-					var fatalErr *filter.FatalError
-					if errors.As(filterErr, &fatalErr) {
-						if klog.V(4).Enabled() {
-							// only log event emitted errors if the verbosity > 4
-							klog.Errorf("apply filter errored (filter: %s, object: %s): %v", applyFilter.Name(), id, fatalErr.Err)
-						}
-						taskContext.SendEvent(a.createApplyFailedEvent(id, fatalErr))
-						taskContext.InventoryManager().AddFailedApply(id)
-						break
-					}
-					klog.V(4).Infof("apply filtered (filter: %s, object: %s): %v", applyFilter.Name(), id, filterErr)
-					taskContext.SendEvent(a.createApplySkippedEvent(id, obj, filterErr))
-					taskContext.InventoryManager().AddSkippedApply(id)
-					break
-				}
-			}
-			if filterErr != nil {
-				continue
-			}
+// applyObject applies one object, sending its events to the event channel,
+// and records the result in the inventory manager.
+func (a *ApplyTask) applyObject(taskContext *taskrunner.TaskContext, obj *unstructured.Unstructured,
+	eventChannel chan<- event.Event) {
+	ctx := taskContext.Context()
+	// Set the client and mapping fields on the provided
+	// info so they can be applied to the cluster.
+	info, err := a.InfoHelper.BuildInfo(obj)
+	// BuildInfo strips path annotations.
+	// Use modified object for filters, mutations, and events.
+	obj = info.Object.(*unstructured.Unstructured)
+	id := object.UnstructuredToObjMetadata(obj)
+	if err != nil {
+		err = applyerror.NewUnknownTypeError(err)
+		if klog.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			klog.Errorf("apply task errored (object: %s): unable to convert obj to info: %v", id, err)
+		}
+		eventChannel <- a.createApplyFailedEvent(id, err)
+		taskContext.InventoryManager().AddFailedApply(id)
+		return
+	}
 
-			// Execute mutators, if any apply
-			err = a.mutate(ctx, obj)
-			if err != nil {
+	// Check filters to see if we're prevented from applying.
+	var filterErr error
+	for _, applyFilter := range a.Filters {
+		klog.V(6).Infof("apply filter evaluating (filter: %s, object: %s)", applyFilter.Name(), id)
+		filterErr = applyFilter.Filter(taskContext.Context(), obj)
+		if filterErr != nil {
+			var fatalErr *filter.FatalError
+			if errors.As(filterErr, &fatalErr) {
 				if klog.V(4).Enabled() {
 					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply mutation errored (object: %s): %v", id, err)
+					klog.Errorf("apply filter errored (filter: %s, object: %s): %v", applyFilter.Name(), id, fatalErr.Err)
 				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
+				eventChannel <- a.createApplyFailedEvent(id, fatalErr)
 				taskContext.InventoryManager().AddFailedApply(id)
-				continue
+				break
 			}
+			klog.V(4).Infof("apply filtered (filter: %s, object: %s): %v", applyFilter.Name(), id, filterErr)
+			eventChannel <- a.createApplySkippedEvent(id, obj, filterErr)
+			taskContext.InventoryManager().AddSkippedApply(id)
+			break
+		}
+	}
+	if filterErr != nil {
+		return
+	}
+
+	// Hash the object before mutation, so the hash only changes when
+	// the local object changes.
+	hash, err := object.Hash(obj)
+	if err != nil {
+		if klog.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			klog.Errorf("apply hash errored (object: %s): %v", id, err)
+		}
+		eventChannel <- a.createApplyFailedEvent(id, err)
+		taskContext.InventoryManager().AddFailedApply(id)
+		return
+	}
+
+	// Skip objects that have not changed since they were last applied.
+	if prevStatus, found := a.Unchanged[id]; found && prevStatus.Hash == hash {
+		klog.V(4).Infof("apply skipped (object unchanged): %s", id)
+		eventChannel <- a.createApplyUnchangedEvent(id, obj)
+		taskContext.InventoryManager().AddSuccessfulApply(id, prevStatus.UID, prevStatus.Generation)
+		if err := taskContext.InventoryManager().SetAppliedHash(id, hash); err != nil {
+			klog.Errorf("Failed to record applied hash: %v", err)
+		}
+		return
+	}
+
+	// Execute mutators, if any apply
+	err = a.mutate(ctx, obj)
+	if err != nil {
+		if klog.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			klog.Errorf("apply mutation errored (object: %s): %v", id, err)
+		}
+		eventChannel <- a.createApplyFailedEvent(id, err)
+		taskContext.InventoryManager().AddFailedApply(id)
+		return
+	}
 
-			// Create a new instance of the applyOptions interface and use it
-			// to apply the objects.
-			ao := applyOptionsFactoryFunc(a.Name(), taskContext.EventChannel(),
-				a.ServerSideOptions, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
-			ao.SetObjects([]*resource.Info{info})
-			klog.V(5).Infof("applying object: %v", id)
-			err = ao.Run()
-			if err != nil && a.ServerSideOptions.ServerSideApply && isAPIService(obj) && isStreamError(err) {
-				// Server-side Apply doesn't work with APIService before k8s 1.21
-				// https://github.com/kubernetes/kubernetes/issues/89264
-				// Thus APIService is handled specially using client-side apply.
-				err = a.clientSideApply(info, taskContext.EventChannel())
+	// Record the live state of the object, so it can be restored if
+	// the object fails to reconcile.
+	if a.RecordPreviousState && !a.DryRunStrategy.ClientOrServerDryRun() {
+		if err := a.recordPreviousState(ctx, taskContext, id, info); err != nil {
+			if klog.V(4).Enabled() {
+				// only log event emitted errors if the verbosity > 4
+				klog.Errorf("apply errored (object: %s): unable to get previous state: %v", id, err)
 			}
-			if err != nil {
-				err = applyerror.NewApplyRunError(err)
-				if klog.V(4).Enabled() {
-					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply errored (object: %s): %v", id, err)
-				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
-				taskContext.InventoryManager().AddFailedApply(id)
-			} else if info.Object != nil {
-				acc, err := meta.Accessor(info.Object)
-				if err == nil {
-					uid := acc.GetUID()
-					gen := acc.GetGeneration()
-					taskContext.InventoryManager().AddSuccessfulApply(id, uid, gen)
-				}
+			eventChannel <- a.createApplyFailedEvent(id, err)
+			taskContext.InventoryManager().AddFailedApply(id)
+			return
+		}
+	}
+
+	// Apply the object, retrying transient errors according to the
+	// retry policy.
+	err = a.RetryPolicy.Do(ctx, func() error {
+		return a.apply(id, obj, info, eventChannel)
+	}, func(attempt int, delay time.Duration, err error) {
+		klog.V(4).Infof("apply failed, retrying in %s (object: %s, attempt: %d): %v", delay, id, attempt, err)
+		eventChannel <- a.createRetryEvent(id, attempt, delay, err)
+	})
+	if err != nil {
+		err = applyerror.NewApplyRunError(err)
+		if klog.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			klog.Errorf("apply errored (object: %s): %v", id, err)
+		}
+		eventChannel <- a.createApplyFailedEvent(id, err)
+		taskContext.InventoryManager().AddFailedApply(id)
+	} else if info.Object != nil {
+		acc, err := meta.Accessor(info.Object)
+		if err == nil {
+			uid := acc.GetUID()
+			gen := acc.GetGeneration()
+			taskContext.InventoryManager().AddSuccessfulApply(id, uid, gen)
+			if err := taskContext.InventoryManager().SetAppliedHash(id, hash); err != nil {
+				klog.Errorf("Failed to record applied hash: %v", err)
 			}
 		}
-		a.sendTaskResult(taskContext)
-	}()
+	}
+}
+
+// apply applies the object once.
+func (a *ApplyTask) apply(id object.ObjMetadata, obj *unstructured.Unstructured, info *resource.Info,
+	eventChannel chan<- event.Event) error {
+	// Create a new instance of the applyOptions interface and use it
+	// to apply the objects.
+	ao := applyOptionsFactoryFunc(a.Name(), eventChannel,
+		a.ServerSideOptions, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
+	ao.SetObjects([]*resource.Info{info})
+	klog.V(5).Infof("applying object: %v", id)
+	err := ao.Run()
+	if err != nil && a.ServerSideOptions.ServerSideApply && isAPIService(obj) && isStreamError(err) {
+		// Server-side Apply doesn't work with APIService before k8s 1.21
+		// https://github.com/kubernetes/kubernetes/issues/89264
+		// Thus APIService is handled specially using client-side apply.
+		err = a.clientSideApply(info, eventChannel)
+	}
+	return err
 }
 
 func newApplyOptions(taskName string, eventChannel chan<- event.Event, serverSideOptions common.ServerSideOptions,
@@ -228,6 +315,26 @@ func (a *ApplyTask) Cancel(_ *taskrunner.TaskContext) {}
 // StatusUpdate is not supported by the ApplyTask.
 func (a *ApplyTask) StatusUpdate(_ *taskrunner.TaskContext, _ object.ObjMetadata) {}
 
+// recordPreviousState fetches the live state of the object and stores it in
+// the TaskContext, unless a previous state was already stored.
+func (a *ApplyTask) recordPreviousState(ctx context.Context, taskContext *taskrunner.TaskContext,
+	id object.ObjMetadata, info *resource.Info) error {
+	if _, found := taskContext.PreviousObject(id); found {
+		return nil
+	}
+	liveObj, err := a.DynamicClient.Resource(info.Mapping.Resource).
+		Namespace(info.Namespace).
+		Get(ctx, info.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get previous state of %q: %w", id, err)
+		}
+		liveObj = nil
+	}
+	taskContext.AddPreviousObject(id, liveObj)
+	return nil
+}
+
 // mutate loops through the mutator list and executes them on the object.
 func (a *ApplyTask) mutate(ctx context.Context, obj *unstructured.Unstructured) error {
 	id := object.UnstructuredToObjMetadata(obj)
@@ -269,6 +376,33 @@ func (a *ApplyTask) createApplySkippedEvent(id object.ObjMetadata, resource *uns
 	}
 }
 
+func (a *ApplyTask) createApplyUnchangedEvent(id object.ObjMetadata, resource *unstructured.Unstructured) event.Event {
+	return event.Event{
+		Type: event.ApplyType,
+		ApplyEvent: event.ApplyEvent{
+			GroupName:  a.Name(),
+			Identifier: id,
+			Status:     event.ApplyUnchanged,
+			Resource:   resource,
+		},
+	}
+}
+
+func (a *ApplyTask) createRetryEvent(id object.ObjMetadata, attempt int, delay time.Duration, err error) event.Event {
+	return event.Event{
+		Type: event.RetryType,
+		RetryEvent: event.RetryEvent{
+			GroupName:   a.Name(),
+			Identifier:  id,
+			Action:      event.ApplyAction,
+			Attempt:     attempt,
+			MaxAttempts: a.RetryPolicy.MaxAttempts,
+			Delay:       delay,
+			Error:       err,
+		},
+	}
+}
+
 func isAPIService(obj *unstructured.Unstructured) bool {
 	gk := obj.GroupVersionKind().GroupKind()
 	return gk.Group == "apiregistration.k8s.io" && gk.Kind == "APIService"
@@ -4,22 +4,29 @@
 package task
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
@@ -346,6 +353,342 @@ func TestApplyTask_DryRun(t *testing.T) {
 	}
 }
 
+func TestApplyTask_Unchanged(t *testing.T) {
+	objs := toUnstructureds([]resourceInfo{
+		{
+			group:      "apps",
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       "unchanged",
+			namespace:  "default",
+			uid:        types.UID("uid-1"),
+			generation: int64(1),
+		},
+		{
+			group:      "apps",
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       "changed",
+			namespace:  "default",
+			uid:        types.UID("uid-2"),
+			generation: int64(2),
+		},
+	})
+	unchangedID := object.UnstructuredToObjMetadata(objs[0])
+	changedID := object.UnstructuredToObjMetadata(objs[1])
+	hash, err := object.Hash(objs[0])
+	assert.NoError(t, err)
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
+
+	ao := &fakeApplyOptions{}
+	oldAO := applyOptionsFactoryFunc
+	applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+		dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+		return ao
+	}
+	defer func() { applyOptionsFactoryFunc = oldAO }()
+
+	applyTask := &ApplyTask{
+		TaskName:   "apply-0",
+		Objects:    objs,
+		InfoHelper: &fakeInfoHelper{},
+		Unchanged: map[object.ObjMetadata]actuation.ObjectStatus{
+			unchangedID: {
+				ObjectReference: inventory.ObjectReferenceFromObjMetadata(unchangedID),
+				Strategy:        actuation.ActuationStrategyApply,
+				Actuation:       actuation.ActuationSucceeded,
+				UID:             "previous-uid",
+				Generation:      7,
+				Hash:            hash,
+			},
+			// The local object changed since the previous apply.
+			changedID: {
+				ObjectReference: inventory.ObjectReferenceFromObjMetadata(changedID),
+				Strategy:        actuation.ActuationStrategyApply,
+				Actuation:       actuation.ActuationSucceeded,
+				UID:             "uid-2",
+				Generation:      2,
+				Hash:            "previous-hash",
+			},
+		},
+	}
+
+	var events []event.Event
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for msg := range eventChannel {
+			events = append(events, msg)
+		}
+	}()
+
+	applyTask.Start(taskContext)
+	<-taskContext.TaskChannel()
+	close(eventChannel)
+	wg.Wait()
+
+	// Only the changed object is sent to the server.
+	if assert.Len(t, ao.passedObjects, 1) {
+		assert.Equal(t, "changed", ao.passedObjects[0].Name)
+	}
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, event.ApplyType, events[0].Type)
+		assert.Equal(t, event.ApplyUnchanged, events[0].ApplyEvent.Status)
+		assert.Equal(t, unchangedID, events[0].ApplyEvent.Identifier)
+	}
+
+	// The unchanged object keeps its previous status.
+	im := taskContext.InventoryManager()
+	assert.True(t, im.IsSuccessfulApply(unchangedID))
+	uid, _ := im.AppliedResourceUID(unchangedID)
+	assert.Equal(t, types.UID("previous-uid"), uid)
+	gen, _ := im.AppliedGeneration(unchangedID)
+	assert.Equal(t, int64(7), gen)
+	appliedHash, _ := im.AppliedHash(unchangedID)
+	assert.Equal(t, hash, appliedHash)
+	assert.True(t, im.IsSuccessfulApply(changedID))
+}
+
+func TestApplyTask_Concurrency(t *testing.T) {
+	var rss []resourceInfo
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("success-%d", i)
+		if i%3 == 0 {
+			name = fmt.Sprintf("failure-%d", i)
+		}
+		rss = append(rss, resourceInfo{
+			group:      "apps",
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       name,
+			namespace:  "default",
+			uid:        types.UID(name),
+			generation: int64(1),
+		})
+	}
+	objs := toUnstructureds(rss)
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
+
+	var mu sync.Mutex
+	var applied []string
+	oldAO := applyOptionsFactoryFunc
+	applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+		dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+		return &fakeApplyOptions{
+			onRun: func(info *resource.Info) {
+				mu.Lock()
+				defer mu.Unlock()
+				applied = append(applied, info.Name)
+			},
+		}
+	}
+	defer func() { applyOptionsFactoryFunc = oldAO }()
+
+	applyTask := &ApplyTask{
+		TaskName:    "apply-0",
+		Objects:     objs,
+		InfoHelper:  &fakeInfoHelper{},
+		Concurrency: 4,
+	}
+
+	var events []event.Event
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for msg := range eventChannel {
+			events = append(events, msg)
+		}
+	}()
+
+	applyTask.Start(taskContext)
+	<-taskContext.TaskChannel()
+	close(eventChannel)
+	wg.Wait()
+
+	assert.Len(t, applied, len(objs))
+
+	// The events are sent in the order of the objects.
+	var expectedFailed []object.ObjMetadata
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		if strings.HasPrefix(id.Name, "failure") {
+			expectedFailed = append(expectedFailed, id)
+			assert.True(t, taskContext.InventoryManager().IsFailedApply(id))
+		} else {
+			assert.True(t, taskContext.InventoryManager().IsSuccessfulApply(id))
+		}
+	}
+	var actualFailed []object.ObjMetadata
+	for _, e := range events {
+		assert.Equal(t, event.ApplyFailed, e.ApplyEvent.Status)
+		actualFailed = append(actualFailed, e.ApplyEvent.Identifier)
+	}
+	assert.Equal(t, expectedFailed, actualFailed)
+}
+
+func TestApplyTask_Retry(t *testing.T) {
+	objs := toUnstructureds([]resourceInfo{
+		{
+			group:      "apps",
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       "deploy",
+			namespace:  "default",
+			uid:        types.UID("deploy"),
+			generation: int64(1),
+		},
+	})
+	id := object.UnstructuredToObjMetadata(objs[0])
+	webhookErr := apierrors.NewInternalError(errors.New(`failed calling webhook "validate.example.com": ` +
+		`context deadline exceeded`))
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
+
+	// The first attempt fails with a webhook timeout.
+	attempts := 0
+	oldAO := applyOptionsFactoryFunc
+	applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+		dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+		attempts++
+		if attempts == 1 {
+			return &flakyApplyOptions{err: webhookErr}
+		}
+		return &fakeApplyOptions{}
+	}
+	defer func() { applyOptionsFactoryFunc = oldAO }()
+
+	applyTask := &ApplyTask{
+		TaskName:   "apply-0",
+		Objects:    objs,
+		InfoHelper: &fakeInfoHelper{},
+		RetryPolicy: retry.Policy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+		},
+	}
+
+	var events []event.Event
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for msg := range eventChannel {
+			events = append(events, msg)
+		}
+	}()
+
+	applyTask.Start(taskContext)
+	<-taskContext.TaskChannel()
+	close(eventChannel)
+	wg.Wait()
+
+	assert.Equal(t, 2, attempts)
+	assert.True(t, taskContext.InventoryManager().IsSuccessfulApply(id))
+	require.Len(t, events, 1)
+	assert.Equal(t, event.RetryType, events[0].Type)
+	assert.Equal(t, event.RetryEvent{
+		GroupName:   "apply-0",
+		Identifier:  id,
+		Action:      event.ApplyAction,
+		Attempt:     1,
+		MaxAttempts: 3,
+		Delay:       time.Millisecond,
+		Error:       webhookErr,
+	}, events[0].RetryEvent)
+}
+
+func TestApplyTask_ConcurrentRetry(t *testing.T) {
+	objs := toUnstructureds([]resourceInfo{
+		{
+			group:      "apps",
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       "blocked",
+			namespace:  "default",
+			uid:        types.UID("blocked"),
+			generation: int64(1),
+		},
+		{
+			group:      "apps",
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       "deploy",
+			namespace:  "default",
+			uid:        types.UID("deploy"),
+			generation: int64(1),
+		},
+	})
+	id := object.UnstructuredToObjMetadata(objs[1])
+	webhookErr := apierrors.NewInternalError(errors.New(`failed calling webhook "validate.example.com": ` +
+		`context deadline exceeded`))
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
+
+	// The first object blocks until released, and the first attempt to apply
+	// the second object fails with a webhook timeout.
+	release := make(chan struct{})
+	var mu sync.Mutex
+	attempts := 0
+	oldAO := applyOptionsFactoryFunc
+	applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+		dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+		return &blockingApplyOptions{
+			run: func(info *resource.Info) error {
+				if info.Name == "blocked" {
+					<-release
+					return nil
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				attempts++
+				if attempts == 1 {
+					return webhookErr
+				}
+				return nil
+			},
+		}
+	}
+	defer func() { applyOptionsFactoryFunc = oldAO }()
+
+	applyTask := &ApplyTask{
+		TaskName:    "apply-0",
+		Objects:     objs,
+		InfoHelper:  &fakeInfoHelper{},
+		Concurrency: 2,
+		RetryPolicy: retry.Policy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+		},
+	}
+	applyTask.Start(taskContext)
+
+	// The retry event is sent while the first object is still being applied.
+	select {
+	case e := <-eventChannel:
+		assert.Equal(t, event.RetryType, e.Type)
+		assert.Equal(t, id, e.RetryEvent.Identifier)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the retry event")
+	}
+	close(release)
+	<-taskContext.TaskChannel()
+
+	assert.Equal(t, 2, attempts)
+	assert.True(t, taskContext.InventoryManager().IsSuccessfulApply(id))
+}
+
 func TestApplyTaskWithError(t *testing.T) {
 	testCases := map[string]struct {
 		objs            []*unstructured.Unstructured
@@ -546,11 +889,16 @@ func toUnstructureds(rss []resourceInfo) []*unstructured.Unstructured {
 type fakeApplyOptions struct {
 	objects       []*resource.Info
 	passedObjects []*resource.Info
+	// onRun, if set, is called for each object when Run is called.
+	onRun func(*resource.Info)
 }
 
 func (f *fakeApplyOptions) Run() error {
 	var err error
 	for _, obj := range f.objects {
+		if f.onRun != nil {
+			f.onRun(obj)
+		}
 		if strings.Contains(obj.Name, "failure") {
 			err = fmt.Errorf("expected apply error")
 		} else {
@@ -564,6 +912,37 @@ func (f *fakeApplyOptions) SetObjects(objects []*resource.Info) {
 	f.objects = objects
 }
 
+// flakyApplyOptions fails to apply with the given error.
+type flakyApplyOptions struct {
+	err error
+}
+
+func (f *flakyApplyOptions) Run() error {
+	return f.err
+}
+
+func (f *flakyApplyOptions) SetObjects([]*resource.Info) {}
+
+// blockingApplyOptions calls run for each object when Run is called. Unlike
+// fakeApplyOptions, run may block.
+type blockingApplyOptions struct {
+	objects []*resource.Info
+	run     func(*resource.Info) error
+}
+
+func (b *blockingApplyOptions) Run() error {
+	for _, obj := range b.objects {
+		if err := b.run(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *blockingApplyOptions) SetObjects(objects []*resource.Info) {
+	b.objects = objects
+}
+
 type fakeInfoHelper struct{}
 
 func (f *fakeInfoHelper) UpdateInfo(*resource.Info) error {
@@ -0,0 +1,192 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/retry"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
+)
+
+// HookDeleteTask deletes the lifecycle hooks that have completed, according
+// to their delete policy. Unlike the PruneTask, the deleted hooks are not
+// recorded in the InventoryManager, because hooks are not inventory members,
+// and their apply and reconcile statuses are still used to skip the
+// actuation of the objects that follow them.
+//
+// If Replace is true, the Objects are instead the previous instances of the
+// hooks that are still in the cluster, which are deleted regardless of their
+// delete policy before the hooks are applied again. Their deletions are
+// recorded in the InventoryManager, so that they can be waited on.
+type HookDeleteTask struct {
+	TaskName string
+
+	DynamicClient dynamic.Interface
+	Mapper        meta.RESTMapper
+	Objects       object.UnstructuredSet
+	// Replace is true if the Objects are previous instances of hooks that
+	// are about to be applied again.
+	Replace bool
+	// Filters skip the deletion of previous instances of hooks that will
+	// not be applied again. Only used if Replace is true.
+	Filters []filter.ValidationFilter
+	// RetryPolicy defines how deleting a hook is retried when the server
+	// returns a transient error.
+	RetryPolicy retry.Policy
+}
+
+func (h *HookDeleteTask) Name() string {
+	return h.TaskName
+}
+
+func (h *HookDeleteTask) Action() event.ResourceAction {
+	return event.HookDeleteAction
+}
+
+func (h *HookDeleteTask) Identifiers() object.ObjMetadataSet {
+	return object.UnstructuredSetToObjMetadataSet(h.Objects)
+}
+
+// Start deletes the hooks in a new goroutine, and pushes a TaskResult on the
+// taskChannel when done. Hooks that fail to be deleted are reported with
+// failed events, but do not fail the task.
+func (h *HookDeleteTask) Start(taskContext *taskrunner.TaskContext) {
+	go func() {
+		klog.V(2).Infof("hook delete task starting (name: %q, objects: %d)",
+			h.Name(), len(h.Objects))
+		for _, obj := range h.Objects {
+			h.deleteHook(taskContext, obj)
+		}
+		klog.V(2).Infof("hook delete task completing (name: %q)", h.Name())
+		taskContext.TaskChannel() <- taskrunner.TaskResult{}
+	}()
+}
+
+// deleteHook deletes the hook if its delete policy allows it, or if it is a
+// previous instance to replace, and sends the resulting event.
+func (h *HookDeleteTask) deleteHook(taskContext *taskrunner.TaskContext, obj *unstructured.Unstructured) {
+	id := object.UnstructuredToObjMetadata(obj)
+	im := taskContext.InventoryManager()
+
+	var uid types.UID
+	if h.Replace {
+		if err := h.filter(taskContext, obj); err != nil {
+			im.AddSkippedDelete(id)
+			taskContext.SendEvent(h.createEvent(id, obj, event.HookDeleteSkipped, err))
+			return
+		}
+		// Only delete the previous instance that was found in the cluster.
+		uid = obj.GetUID()
+	} else {
+		// Invalid annotations are rejected by validation
+		policy, _ := hook.ReadDeletePolicy(obj)
+		appliedUID, applied := im.AppliedResourceUID(id)
+		switch {
+		case policy == hook.DeleteNever:
+			return
+		case !applied || appliedUID == "":
+			taskContext.SendEvent(h.createEvent(id, obj, event.HookDeleteSkipped,
+				errors.New("hook was not applied")))
+			return
+		case policy == hook.DeleteSucceeded && !im.IsSuccessfulReconcile(id):
+			taskContext.SendEvent(h.createEvent(id, obj, event.HookDeleteSkipped,
+				errors.New("hook did not complete successfully")))
+			return
+		}
+		uid = appliedUID
+	}
+
+	klog.V(4).Infof("deleting hook (object: %q)", id)
+	propagationPolicy := metav1.DeletePropagationBackground
+	err := h.RetryPolicy.Do(taskContext.Context(), func() error {
+		mapping, err := h.Mapper.RESTMapping(id.GroupKind)
+		if err != nil {
+			return err
+		}
+		return h.DynamicClient.Resource(mapping.Resource).Namespace(id.Namespace).
+			Delete(taskContext.Context(), id.Name, metav1.DeleteOptions{
+				// Only delete the hook that was applied or found,
+				// not a replacement created by another client.
+				Preconditions: &metav1.Preconditions{
+					UID: &uid,
+				},
+				PropagationPolicy: &propagationPolicy,
+			})
+	}, func(attempt int, delay time.Duration, err error) {
+		klog.V(4).Infof("hook delete failed, retrying in %s (object: %q, attempt: %d): %v", delay, id, attempt, err)
+		taskContext.SendEvent(event.Event{
+			Type: event.RetryType,
+			RetryEvent: event.RetryEvent{
+				GroupName:   h.Name(),
+				Identifier:  id,
+				Action:      event.HookDeleteAction,
+				Attempt:     attempt,
+				MaxAttempts: h.RetryPolicy.MaxAttempts,
+				Delay:       delay,
+				Error:       err,
+			},
+		})
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		if klog.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			klog.Errorf("error deleting hook (object: %q): %v", id, err)
+		}
+		if h.Replace {
+			im.AddFailedDelete(id)
+		}
+		taskContext.SendEvent(h.createEvent(id, nil, event.HookDeleteFailed, err))
+		return
+	}
+	if h.Replace {
+		im.AddSuccessfulDelete(id, uid)
+	}
+	taskContext.SendEvent(h.createEvent(id, obj, event.HookDeleteSuccessful, nil))
+}
+
+// filter returns the error of the first filter that skips the deletion of
+// the object, or nil if none do.
+func (h *HookDeleteTask) filter(taskContext *taskrunner.TaskContext, obj *unstructured.Unstructured) error {
+	for _, f := range h.Filters {
+		if err := f.Filter(taskContext.Context(), obj); err != nil {
+			klog.V(4).Infof("hook delete filtered (filter: %s, object: %s): %v",
+				f.Name(), object.UnstructuredToObjMetadata(obj), err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *HookDeleteTask) createEvent(id object.ObjMetadata, obj *unstructured.Unstructured,
+	status event.HookDeleteEventStatus, err error) event.Event {
+	return event.Event{
+		Type: event.HookDeleteType,
+		HookDeleteEvent: event.HookDeleteEvent{
+			GroupName:  h.Name(),
+			Identifier: id,
+			Status:     status,
+			Object:     obj,
+			Error:      err,
+		},
+	}
+}
+
+// Cancel is not supported by the HookDeleteTask.
+func (h *HookDeleteTask) Cancel(_ *taskrunner.TaskContext) {}
+
+// StatusUpdate is not supported by the HookDeleteTask.
+func (h *HookDeleteTask) StatusUpdate(_ *taskrunner.TaskContext, _ object.ObjMetadata) {}
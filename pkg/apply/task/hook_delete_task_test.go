@@ -0,0 +1,196 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func newHook(name string, policy hook.DeletePolicy) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+	u.SetName(name)
+	u.SetNamespace("test-namespace")
+	u.SetUID(types.UID(name + "-uid"))
+	u.SetAnnotations(map[string]string{
+		hook.Annotation:             string(hook.PostApply),
+		hook.DeletePolicyAnnotation: string(policy),
+	})
+	return u
+}
+
+func TestHookDeleteTask(t *testing.T) {
+	succeeded := newHook("succeeded", hook.DeleteSucceeded)
+	failed := newHook("failed", hook.DeleteSucceeded)
+	failedAlways := newHook("failed-always", hook.DeleteAlways)
+	notApplied := newHook("not-applied", hook.DeleteAlways)
+	never := newHook("never", hook.DeleteNever)
+	hooks := object.UnstructuredSet{succeeded, failed, failedAlways, notApplied, never}
+
+	clusterObjs := make([]runtime.Object, 0, len(hooks))
+	for _, obj := range hooks {
+		clusterObjs = append(clusterObjs, obj)
+	}
+	dynamicClient := fake.NewSimpleDynamicClient(scheme.Scheme, clusterObjs...)
+	eventChannel := make(chan event.Event, len(hooks))
+	taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, cache.NewResourceCacheMap())
+	im := taskContext.InventoryManager()
+	im.AddPendingApply(object.UnstructuredToObjMetadata(notApplied))
+	for _, obj := range []*unstructured.Unstructured{succeeded, failed, failedAlways, never} {
+		id := object.UnstructuredToObjMetadata(obj)
+		im.AddSuccessfulApply(id, obj.GetUID(), 0)
+		if obj == succeeded || obj == never {
+			require.NoError(t, im.SetSuccessfulReconcile(id))
+		} else {
+			require.NoError(t, im.SetFailedReconcile(id))
+		}
+	}
+
+	task := &HookDeleteTask{
+		TaskName:      "hook-delete-0",
+		DynamicClient: dynamicClient,
+		Mapper:        testutil.NewFakeRESTMapper(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}),
+		Objects:       hooks,
+	}
+	assert.Equal(t, event.HookDeleteAction, task.Action())
+	assert.Equal(t, object.UnstructuredSetToObjMetadataSet(hooks), task.Identifiers())
+
+	task.Start(taskContext)
+	result := <-taskContext.TaskChannel()
+	require.NoError(t, result.Err)
+	close(eventChannel)
+
+	statuses := make(map[string]event.HookDeleteEventStatus)
+	for e := range eventChannel {
+		require.Equal(t, event.HookDeleteType, e.Type)
+		assert.Equal(t, "hook-delete-0", e.HookDeleteEvent.GroupName)
+		statuses[e.HookDeleteEvent.Identifier.Name] = e.HookDeleteEvent.Status
+	}
+	assert.Equal(t, map[string]event.HookDeleteEventStatus{
+		"succeeded":     event.HookDeleteSuccessful,
+		"failed":        event.HookDeleteSkipped,
+		"failed-always": event.HookDeleteSuccessful,
+		"not-applied":   event.HookDeleteSkipped,
+	}, statuses)
+
+	podsClient := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "pods"}).
+		Namespace("test-namespace")
+	for _, obj := range hooks {
+		_, err := podsClient.Get(t.Context(), obj.GetName(), metav1.GetOptions{})
+		deleted := obj == succeeded || obj == failedAlways
+		assert.Equal(t, deleted, apierrors.IsNotFound(err), "hook %s deleted", obj.GetName())
+		if !deleted {
+			assert.NoError(t, err)
+		}
+	}
+
+	// Deleted hooks are not recorded in the inventory
+	assert.Empty(t, im.SuccessfulDeletes())
+}
+
+func TestHookDeleteTask_Replace(t *testing.T) {
+	failedHookID := object.UnstructuredToObjMetadata(newHook("failed", hook.DeleteNever))
+
+	testCases := map[string]struct {
+		deleteErr      error
+		failedHook     bool
+		expectedStatus event.HookDeleteEventStatus
+		expectDeleted  bool
+	}{
+		"previous hook deleted, regardless of delete policy": {
+			expectedStatus: event.HookDeleteSuccessful,
+			expectDeleted:  true,
+		},
+		"previous hook failed to be deleted": {
+			deleteErr:      apierrors.NewInternalError(errors.New("test")),
+			expectedStatus: event.HookDeleteFailed,
+		},
+		"previous hook skipped after a hook failed": {
+			failedHook:     true,
+			expectedStatus: event.HookDeleteSkipped,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			prevHook := newHook("previous", hook.DeleteNever)
+			id := object.UnstructuredToObjMetadata(prevHook)
+			dynamicClient := fake.NewSimpleDynamicClient(scheme.Scheme, prevHook)
+			if tc.deleteErr != nil {
+				dynamicClient.PrependReactor("delete", "pods", func(clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tc.deleteErr
+				})
+			}
+			eventChannel := make(chan event.Event, 1)
+			taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, cache.NewResourceCacheMap())
+			im := taskContext.InventoryManager()
+			taskContext.AddHookObject(id)
+			im.AddPendingApply(id)
+			if tc.failedHook {
+				taskContext.AddHookObject(failedHookID)
+				im.AddFailedApply(failedHookID)
+			}
+
+			task := &HookDeleteTask{
+				TaskName:      "hook-delete-0",
+				DynamicClient: dynamicClient,
+				Mapper:        testutil.NewFakeRESTMapper(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}),
+				Objects:       object.UnstructuredSet{prevHook},
+				Replace:       true,
+				Filters: []filter.ValidationFilter{
+					filter.HookFilter{TaskContext: taskContext},
+				},
+			}
+			task.Start(taskContext)
+			result := <-taskContext.TaskChannel()
+			require.NoError(t, result.Err)
+			close(eventChannel)
+
+			var events []event.Event
+			for e := range eventChannel {
+				events = append(events, e)
+			}
+			require.Len(t, events, 1)
+			require.Equal(t, event.HookDeleteType, events[0].Type)
+			assert.Equal(t, tc.expectedStatus, events[0].HookDeleteEvent.Status)
+
+			// Replaced hooks are recorded in the inventory, to wait for them
+			switch tc.expectedStatus {
+			case event.HookDeleteSuccessful:
+				assert.True(t, im.IsSuccessfulDelete(id))
+				objStatus, found := im.ObjectStatus(id)
+				require.True(t, found)
+				assert.Equal(t, prevHook.GetUID(), objStatus.UID)
+			case event.HookDeleteFailed:
+				assert.True(t, im.IsFailedDelete(id))
+			case event.HookDeleteSkipped:
+				assert.True(t, im.IsSkippedDelete(id))
+			}
+
+			_, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "pods"}).
+				Namespace("test-namespace").Get(t.Context(), prevHook.GetName(), metav1.GetOptions{})
+			assert.Equal(t, tc.expectDeleted, apierrors.IsNotFound(err))
+		})
+	}
+}
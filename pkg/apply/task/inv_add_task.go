@@ -78,7 +78,7 @@ func (i *InvAddTask) Start(taskContext *taskrunner.TaskContext) {
 		pruneObjs := inventoryObjs.Diff(currentObjs)
 
 		i.Inventory.SetObjectRefs(unionObjs)
-		i.Inventory.SetObjectStatuses(i.getObjStatus(pruneObjs, unionObjs))
+		i.Inventory.SetObjectStatuses(i.getObjStatus(taskContext.InventoryManager(), pruneObjs, unionObjs))
 
 		var err error
 		if !i.DryRun.ClientOrServerDryRun() {
@@ -152,13 +152,19 @@ func (i *InvAddTask) sendTaskResult(taskContext *taskrunner.TaskContext, err err
 
 // getObjStatus returns the list of object status
 // at the beginning of an apply process.
-func (i *InvAddTask) getObjStatus(pruneIDs, unionIDs []object.ObjMetadata) object.ObjectStatusSet {
+// Objects that were already actuated, like objects restored from a
+// checkpoint, keep their current status.
+func (i *InvAddTask) getObjStatus(im *inventory.Manager, pruneIDs, unionIDs []object.ObjMetadata) object.ObjectStatusSet {
 	var status object.ObjectStatusSet
 	pruneMap := make(map[object.ObjMetadata]bool)
 	for _, obj := range pruneIDs {
 		pruneMap[obj] = true
 	}
 	for _, obj := range unionIDs {
+		if objStatus, found := im.ObjectStatus(obj); found && objStatus.Actuation != actuation.ActuationPending {
+			status = append(status, *objStatus)
+			continue
+		}
 		strategy := actuation.ActuationStrategyApply
 		if isPruneObj, ok := pruneMap[obj]; ok && isPruneObj {
 			strategy = actuation.ActuationStrategyDelete
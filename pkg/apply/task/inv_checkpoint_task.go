@@ -0,0 +1,62 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// InvCheckpointTask persists the current object statuses to the inventory,
+// so that the progress of the apply is not lost if it is interrupted.
+// A subsequent apply can then resume from the checkpoint, by skipping the
+// objects that have already been applied and reconciled.
+//
+// The object references are not modified, because the InvAddTask has already
+// added all the objects being applied, and the DeleteOrUpdateInvTask will
+// remove the pruned objects at the end of the apply.
+type InvCheckpointTask struct {
+	TaskName  string
+	Inventory inventory.Inventory
+	InvClient inventory.WriteClient
+	DryRun    common.DryRunStrategy
+}
+
+func (i *InvCheckpointTask) Name() string {
+	return i.TaskName
+}
+
+func (i *InvCheckpointTask) Action() event.ResourceAction {
+	return event.InventoryAction
+}
+
+func (i *InvCheckpointTask) Identifiers() object.ObjMetadataSet {
+	return object.ObjMetadataSet{}
+}
+
+// Start updates the object statuses in the inventory.
+func (i *InvCheckpointTask) Start(taskContext *taskrunner.TaskContext) {
+	go func() {
+		klog.V(2).Infof("inventory checkpoint task starting (name: %q)", i.Name())
+		var err error
+		if i.DryRun.ClientOrServerDryRun() {
+			klog.V(4).Infoln("dry-run checkpoint inventory object: not applied")
+		} else {
+			i.Inventory.SetObjectStatuses(inventoryObjectStatuses(taskContext, true))
+			err = i.InvClient.CreateOrUpdate(taskContext.Context(), i.Inventory, inventory.UpdateOptions{})
+		}
+		klog.V(2).Infof("inventory checkpoint task completing (name: %q)", i.Name())
+		taskContext.TaskChannel() <- taskrunner.TaskResult{Err: err}
+	}()
+}
+
+// Cancel is not supported by the InvCheckpointTask.
+func (i *InvCheckpointTask) Cancel(_ *taskrunner.TaskContext) {}
+
+// StatusUpdate is not supported by the InvCheckpointTask.
+func (i *InvCheckpointTask) StatusUpdate(_ *taskrunner.TaskContext, _ object.ObjMetadata) {}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestInvCheckpointTask(t *testing.T) {
+	id1 := object.UnstructuredToObjMetadata(obj1)
+	id2 := object.UnstructuredToObjMetadata(obj2)
+
+	tests := map[string]struct {
+		dryRun           common.DryRunStrategy
+		expectedStatuses object.ObjectStatusSet
+	}{
+		"statuses are persisted": {
+			expectedStatuses: object.ObjectStatusSet{
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(id1),
+					Strategy:        actuation.ActuationStrategyApply,
+					Actuation:       actuation.ActuationSucceeded,
+					Reconcile:       actuation.ReconcileSucceeded,
+					UID:             "uid1",
+					Generation:      1,
+					Hash:            "hash1",
+				},
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(id2),
+					Strategy:        actuation.ActuationStrategyApply,
+					Actuation:       actuation.ActuationPending,
+					Reconcile:       actuation.ReconcilePending,
+				},
+			},
+		},
+		"dry-run does not persist statuses": {
+			dryRun: common.DryRunClient,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := inventory.NewFakeClient(object.ObjMetadataSet{id1, id2})
+			eventChannel := make(chan event.Event)
+			resourceCache := cache.NewResourceCacheMap()
+			taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
+
+			im := taskContext.InventoryManager()
+			im.AddPendingApply(id1)
+			im.AddPendingApply(id2)
+			im.AddSuccessfulApply(id1, "uid1", 1)
+			require.NoError(t, im.SetAppliedHash(id1, "hash1"))
+			require.NoError(t, im.SetSuccessfulReconcile(id1))
+
+			task := InvCheckpointTask{
+				TaskName:  taskName,
+				InvClient: client,
+				Inventory: client.Inv,
+				DryRun:    tc.dryRun,
+			}
+			if taskName != task.Name() {
+				t.Errorf("expected task name (%s), got (%s)", taskName, task.Name())
+			}
+			task.Start(taskContext)
+			result := <-taskContext.TaskChannel()
+			require.NoError(t, result.Err)
+
+			actual, _ := client.Get(t.Context(), nil, inventory.GetOptions{})
+			testutil.AssertEqual(t, object.ObjMetadataSet{id1, id2}, actual.GetObjectRefs())
+			testutil.AssertEqual(t, tc.expectedStatuses, actual.GetObjectStatuses())
+		})
+	}
+}
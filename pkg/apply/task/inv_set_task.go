@@ -24,6 +24,12 @@ type DeleteOrUpdateInvTask struct {
 	DryRun    common.DryRunStrategy
 	// if Destroy is set, the inventory will be deleted if all objects were successfully pruned
 	Destroy bool
+	// Objects are the objects being applied. If the inventory stores
+	// revisions, the objects are added to the inventory as a new revision.
+	Objects object.UnstructuredSet
+	// If StoreAppliedState is set, the UID, generation and hash of each
+	// applied object are stored in the inventory with its status.
+	StoreAppliedState bool
 }
 
 func (i *DeleteOrUpdateInvTask) Name() string {
@@ -156,8 +162,14 @@ func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskCont
 	klog.V(4).Infof("keep in inventory %d invalid objects", len(invalidObjects))
 	invObjs = invObjs.Union(invalidObjects)
 
+	// Lifecycle hooks are never stored in the inventory, even if they were
+	// previously inventory members.
+	hookObjects := taskContext.HookObjects()
+	klog.V(4).Infof("remove from inventory %d hooks", len(hookObjects))
+	invObjs = invObjs.Diff(hookObjects)
+
 	klog.V(4).Infof("get the apply status for %d objects", len(invObjs))
-	objStatus := taskContext.InventoryManager().Inventory().ObjectStatuses
+	objStatus := inventoryObjectStatuses(taskContext, i.StoreAppliedState)
 
 	klog.V(4).Infof("set inventory %d total objects", len(invObjs))
 	// Exit before updating the inventory, but after logging the above changes
@@ -168,6 +180,12 @@ func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskCont
 
 	i.Inventory.SetObjectRefs(invObjs)
 	i.Inventory.SetObjectStatuses(objStatus)
+	if revInv, ok := i.Inventory.(inventory.RevisionInventory); ok && !i.Destroy {
+		revisions := revInv.GetRevisions()
+		revision := inventory.NewRevision(revisions, i.Objects, i.applySuccessful(taskContext))
+		klog.V(4).Infof("add inventory revision %d", revision.Number)
+		revInv.SetRevisions(append(revisions, revision))
+	}
 	if err := i.InvClient.CreateOrUpdate(taskContext.Context(), i.Inventory, inventory.UpdateOptions{}); err != nil {
 		return err
 	}
@@ -176,6 +194,30 @@ func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskCont
 	return nil
 }
 
+// inventoryObjectStatuses returns the object statuses to store in the
+// inventory, which exclude the lifecycle hooks. Unless appliedState is true,
+// the UID, generation and hash are cleared, so they are not stored.
+func inventoryObjectStatuses(taskContext *taskrunner.TaskContext, appliedState bool) object.ObjectStatusSet {
+	objStatuses := taskContext.InventoryManager().Inventory().ObjectStatuses
+	hookObjects := taskContext.HookObjects()
+	if len(hookObjects) == 0 && appliedState {
+		return objStatuses
+	}
+	filtered := make(object.ObjectStatusSet, 0, len(objStatuses))
+	for _, objStatus := range objStatuses {
+		if hookObjects.Contains(inventory.ObjMetadataFromObjectReference(objStatus.ObjectReference)) {
+			continue
+		}
+		if !appliedState {
+			objStatus.UID = ""
+			objStatus.Generation = 0
+			objStatus.Hash = ""
+		}
+		filtered = append(filtered, objStatus)
+	}
+	return filtered
+}
+
 // deleteInventory deletes the inventory object from the cluster.
 func (i *DeleteOrUpdateInvTask) deleteInventory(ctx context.Context) error {
 	klog.V(2).Infof("delete inventory task starting (name: %q)", i.Name())
@@ -192,19 +234,31 @@ func (i *DeleteOrUpdateInvTask) deleteInventory(ctx context.Context) error {
 	return err
 }
 
+// applySuccessful returns true when apply actuation and reconciliation was
+// fully successful. When true, the applied objects can be rolled back to.
+func (i *DeleteOrUpdateInvTask) applySuccessful(taskContext *taskrunner.TaskContext) bool {
+	im := taskContext.InventoryManager()
+	return len(im.FailedApplies()) == 0 &&
+		len(im.FailedReconciles()) == 0 &&
+		len(im.TimeoutReconciles()) == 0
+}
+
 // destroySuccessful returns true when destroy actuation and reconciliation was
 // fully successful. When true, it's safe to delete the inventory.
+// Hooks that failed do not prevent the inventory from being deleted, because
+// they are not inventory members.
 func (i *DeleteOrUpdateInvTask) destroySuccessful(taskContext *taskrunner.TaskContext) bool {
+	hookObjects := taskContext.HookObjects()
 	// if any deletes failed, the Destroy is considered failed
 	if len(taskContext.InventoryManager().FailedDeletes()) > 0 {
 		return false
 	}
 	// if any reconciles failed, the Destroy is considered failed
-	if len(taskContext.InventoryManager().FailedReconciles()) > 0 {
+	if len(taskContext.InventoryManager().FailedReconciles().Diff(hookObjects)) > 0 {
 		return false
 	}
 	// if any reconciles timed out, the Destroy is considered failed
-	if len(taskContext.InventoryManager().TimeoutReconciles()) > 0 {
+	if len(taskContext.InventoryManager().TimeoutReconciles().Diff(hookObjects)) > 0 {
 		return false
 	}
 	return true
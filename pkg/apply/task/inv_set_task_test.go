@@ -6,7 +6,11 @@ package task
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
@@ -40,6 +44,7 @@ func TestInvSetTask(t *testing.T) {
 		timeoutReconciles object.ObjMetadataSet
 		abandonedObjs     object.ObjMetadataSet
 		invalidObjs       object.ObjMetadataSet
+		hookObjs          object.ObjMetadataSet
 		expectedObjs      object.ObjMetadataSet
 	}{
 		"no apply objs, no prune failures; no inventory": {
@@ -187,6 +192,19 @@ func TestInvSetTask(t *testing.T) {
 			timeoutReconciles: object.ObjMetadataSet{id3},
 			expectedObjs:      object.ObjMetadataSet{id3},
 		},
+		"applied hook not in the inventory": {
+			prevInventory: object.ObjMetadataSet{},
+			appliedObjs:   object.ObjMetadataSet{id1, id2},
+			hookObjs:      object.ObjMetadataSet{id2},
+			expectedObjs:  object.ObjMetadataSet{id1},
+		},
+		"failed hook removed from prev inventory": {
+			prevInventory: object.ObjMetadataSet{id1, id2},
+			appliedObjs:   object.ObjMetadataSet{id1},
+			failedApplies: object.ObjMetadataSet{id2},
+			hookObjs:      object.ObjMetadataSet{id2},
+			expectedObjs:  object.ObjMetadataSet{id1},
+		},
 	}
 
 	for name, tc := range tests {
@@ -227,6 +245,9 @@ func TestInvSetTask(t *testing.T) {
 			for _, invalidObj := range tc.invalidObjs {
 				taskContext.AddInvalidObject(invalidObj)
 			}
+			for _, hookObj := range tc.hookObjs {
+				taskContext.AddHookObject(hookObj)
+			}
 			for _, failedReconcile := range tc.failedReconciles {
 				if err := im.SetFailedReconcile(failedReconcile); err != nil {
 					t.Fatal(err)
@@ -249,6 +270,68 @@ func TestInvSetTask(t *testing.T) {
 			testutil.AssertEqual(t, tc.expectedObjs, actual.GetObjectRefs(),
 				"Actual cluster objects (%d) do not match expected cluster objects (%d)",
 				len(actual.GetObjectRefs()), len(tc.expectedObjs))
+			for _, objStatus := range actual.GetObjectStatuses() {
+				id := inventory.ObjMetadataFromObjectReference(objStatus.ObjectReference)
+				if tc.hookObjs.Contains(id) {
+					t.Errorf("unexpected hook status in inventory: %s", id)
+				}
+			}
+		})
+	}
+}
+
+func TestInvSetTask_StoreAppliedState(t *testing.T) {
+	id1 := object.UnstructuredToObjMetadata(obj1)
+
+	tests := map[string]struct {
+		storeAppliedState bool
+		expectedUID       types.UID
+		expectedGen       int64
+		expectedHash      string
+	}{
+		"applied state not stored": {
+			storeAppliedState: false,
+		},
+		"applied state stored": {
+			storeAppliedState: true,
+			expectedUID:       "uid-1",
+			expectedGen:       2,
+			expectedHash:      "hash-1",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := inventory.NewFakeClient(object.ObjMetadataSet{})
+			eventChannel := make(chan event.Event)
+			resourceCache := cache.NewResourceCacheMap()
+			taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, resourceCache)
+
+			task := DeleteOrUpdateInvTask{
+				TaskName:          taskName,
+				InvClient:         client,
+				Inventory:         client.Inv,
+				StoreAppliedState: tc.storeAppliedState,
+			}
+			im := taskContext.InventoryManager()
+			im.AddSuccessfulApply(id1, "uid-1", 2)
+			require.NoError(t, im.SetAppliedHash(id1, "hash-1"))
+
+			task.Start(taskContext)
+			result := <-taskContext.TaskChannel()
+			require.NoError(t, result.Err)
+
+			actual, _ := client.Get(t.Context(), nil, inventory.GetOptions{})
+			require.Len(t, actual.GetObjectStatuses(), 1)
+			objStatus := actual.GetObjectStatuses()[0]
+			assert.Equal(t, actuation.ActuationSucceeded, objStatus.Actuation)
+			assert.Equal(t, tc.expectedUID, objStatus.UID)
+			assert.Equal(t, tc.expectedGen, objStatus.Generation)
+			assert.Equal(t, tc.expectedHash, objStatus.Hash)
+
+			// The in-memory statuses are not modified.
+			uid, _ := im.AppliedResourceUID(id1)
+			assert.Equal(t, types.UID("uid-1"), uid)
 		})
 	}
 }
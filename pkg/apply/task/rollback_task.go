@@ -0,0 +1,166 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// RollbackTask restores the objects applied by one apply phase to the state
+// they had before they were applied, if any applied object failed to
+// reconcile or timed out. Objects that did not exist before are deleted.
+// The rollback tasks of an apply run in reverse phase order, before the
+// inventory is updated, and the result of each restore is reported with a
+// RollbackEvent.
+type RollbackTask struct {
+	TaskName string
+
+	DynamicClient dynamic.Interface
+	Mapper        meta.RESTMapper
+	// Objects are the objects of the apply phase. Only the objects that
+	// were successfully applied are restored.
+	Objects object.ObjMetadataSet
+	// PropagationPolicy is used to delete the objects that did not exist
+	// before they were applied.
+	PropagationPolicy metav1.DeletionPropagation
+
+	once sync.Once
+}
+
+func (r *RollbackTask) Name() string {
+	return r.TaskName
+}
+
+func (r *RollbackTask) Action() event.ResourceAction {
+	return event.RollbackAction
+}
+
+func (r *RollbackTask) Identifiers() object.ObjMetadataSet {
+	return r.Objects
+}
+
+// Start restores the objects in a new goroutine, if any applied object
+// failed to reconcile or timed out, and pushes a TaskResult on the
+// taskChannel when done. Objects that fail to be restored are reported with
+// failed events, but do not fail the task.
+func (r *RollbackTask) Start(taskContext *taskrunner.TaskContext) {
+	go func() {
+		klog.V(2).Infof("rollback task starting (name: %q, objects: %d)",
+			r.Name(), len(r.Objects))
+		im := taskContext.InventoryManager()
+		if failed := len(im.FailedReconciles()) + len(im.TimeoutReconciles()); failed > 0 {
+			klog.V(4).Infof("rolling back: %d objects failed to reconcile", failed)
+			r.Restore(taskContext)
+		}
+		klog.V(2).Infof("rollback task completing (name: %q)", r.Name())
+		taskContext.TaskChannel() <- taskrunner.TaskResult{}
+	}()
+}
+
+// Restore restores the successfully applied objects, in reverse order,
+// regardless of their reconcile status. It is used directly when the apply
+// fails before the rollback tasks are run. The objects are only restored
+// once, even if Restore is called again.
+func (r *RollbackTask) Restore(taskContext *taskrunner.TaskContext) {
+	r.once.Do(func() {
+		applied := taskContext.InventoryManager().SuccessfulApplies()
+		for i := len(r.Objects) - 1; i >= 0; i-- {
+			id := r.Objects[i]
+			if !applied.Contains(id) {
+				continue
+			}
+			prevObj, found := taskContext.PreviousObject(id)
+			if !found {
+				// Not applied by this run, e.g. restored from a checkpoint.
+				taskContext.SendEvent(rollbackEvent(id, event.RollbackSkipped, nil,
+					fmt.Errorf("previous state unknown")))
+				continue
+			}
+			restored, err := r.restoreObject(taskContext.Context(), id, prevObj)
+			if err != nil {
+				if klog.V(4).Enabled() {
+					// only log event emitted errors if the verbosity > 4
+					klog.Errorf("rollback errored (object: %s): %v", id, err)
+				}
+				taskContext.SendEvent(rollbackEvent(id, event.RollbackFailed, nil, err))
+				continue
+			}
+			taskContext.SendEvent(rollbackEvent(id, event.RollbackSuccessful, restored, nil))
+		}
+	})
+}
+
+// restoreObject restores the live object to the specified previous state. If
+// the previous state is nil, the object is deleted. Returns the restored
+// object, or nil if the object was deleted.
+func (r *RollbackTask) restoreObject(ctx context.Context, id object.ObjMetadata,
+	prevObj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	mapping, err := r.Mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		return nil, err
+	}
+	client := r.DynamicClient.Resource(mapping.Resource).Namespace(id.Namespace)
+	if prevObj == nil {
+		err = client.Delete(ctx, id.Name, metav1.DeleteOptions{PropagationPolicy: &r.PropagationPolicy})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete: %w", err)
+		}
+		return nil, nil
+	}
+	obj := prevObj.DeepCopy()
+	// The managed fields and status are owned by the server and the
+	// controllers, not restored.
+	obj.SetManagedFields(nil)
+	unstructured.RemoveNestedField(obj.Object, "status")
+	liveObj, err := client.Get(ctx, id.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get: %w", err)
+		}
+		// Deleted since it was applied. Re-create it.
+		obj.SetResourceVersion("")
+		obj.SetUID("")
+		restored, err := client.Create(ctx, obj, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create: %w", err)
+		}
+		return restored, nil
+	}
+	obj.SetResourceVersion(liveObj.GetResourceVersion())
+	restored, err := client.Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update: %w", err)
+	}
+	return restored, nil
+}
+
+// Cancel is not supported by the RollbackTask.
+func (r *RollbackTask) Cancel(_ *taskrunner.TaskContext) {}
+
+// StatusUpdate is not supported by the RollbackTask.
+func (r *RollbackTask) StatusUpdate(_ *taskrunner.TaskContext, _ object.ObjMetadata) {}
+
+func rollbackEvent(id object.ObjMetadata, status event.RollbackEventStatus, obj *unstructured.Unstructured, err error) event.Event {
+	return event.Event{
+		Type: event.RollbackType,
+		RollbackEvent: event.RollbackEvent{
+			Identifier: id,
+			Status:     status,
+			Object:     obj,
+			Error:      err,
+		},
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var rollbackDeploymentManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deployment
+  namespace: test-namespace
+  uid: deployment-uid
+  generation: 1
+  managedFields:
+  - manager: kubectl
+    operation: Apply
+spec:
+  replicas: 1
+status:
+  replicas: 1
+`
+
+var rollbackSecretManifest = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: test-namespace
+  uid: secret-uid
+  generation: 1
+type: Opaque
+`
+
+func TestRollbackTask(t *testing.T) {
+	deployment := testutil.Unstructured(t, rollbackDeploymentManifest)
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	secret := testutil.Unstructured(t, rollbackSecretManifest)
+	secretID := object.UnstructuredToObjMetadata(secret)
+
+	// The deployment was scaled up by the apply.
+	appliedDeployment := deployment.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(appliedDeployment.Object, int64(3), "spec", "replicas"))
+
+	tests := map[string]struct {
+		reconcile actuation.ReconcileStatus
+		// restore is true if Restore is called directly, like after the
+		// apply fails with an error.
+		restore        bool
+		expectedEvents []testutil.ExpEvent
+		expectRestored bool
+	}{
+		"reconcile succeeded": {
+			reconcile: actuation.ReconcileSucceeded,
+		},
+		"reconcile failed": {
+			reconcile: actuation.ReconcileFailed,
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.RollbackType,
+					RollbackEvent: &testutil.ExpRollbackEvent{
+						Identifier: secretID,
+						Status:     event.RollbackSuccessful,
+					},
+				},
+				{
+					EventType: event.RollbackType,
+					RollbackEvent: &testutil.ExpRollbackEvent{
+						Identifier: deploymentID,
+						Status:     event.RollbackSuccessful,
+					},
+				},
+			},
+			expectRestored: true,
+		},
+		"reconcile timeout": {
+			reconcile: actuation.ReconcileTimeout,
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.RollbackType,
+					RollbackEvent: &testutil.ExpRollbackEvent{
+						Identifier: secretID,
+						Status:     event.RollbackSuccessful,
+					},
+				},
+				{
+					EventType: event.RollbackType,
+					RollbackEvent: &testutil.ExpRollbackEvent{
+						Identifier: deploymentID,
+						Status:     event.RollbackSuccessful,
+					},
+				},
+			},
+			expectRestored: true,
+		},
+		"restored after an error": {
+			reconcile: actuation.ReconcileSucceeded,
+			restore:   true,
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.RollbackType,
+					RollbackEvent: &testutil.ExpRollbackEvent{
+						Identifier: secretID,
+						Status:     event.RollbackSuccessful,
+					},
+				},
+				{
+					EventType: event.RollbackType,
+					RollbackEvent: &testutil.ExpRollbackEvent{
+						Identifier: deploymentID,
+						Status:     event.RollbackSuccessful,
+					},
+				},
+			},
+			expectRestored: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dynamicClient := fake.NewSimpleDynamicClient(scheme.Scheme,
+				[]runtime.Object{appliedDeployment.DeepCopy(), secret.DeepCopy()}...)
+			task := &RollbackTask{
+				TaskName:      "rollback-0",
+				DynamicClient: dynamicClient,
+				Mapper: testutil.NewFakeRESTMapper(
+					appsv1.SchemeGroupVersion.WithKind("Deployment"),
+					v1.SchemeGroupVersion.WithKind("Secret"),
+				),
+				Objects:           object.ObjMetadataSet{deploymentID, secretID},
+				PropagationPolicy: metav1.DeletePropagationBackground,
+			}
+
+			eventChannel := make(chan event.Event, 10)
+			taskContext := taskrunner.NewTaskContext(t.Context(), eventChannel, cache.NewResourceCacheMap())
+			im := taskContext.InventoryManager()
+			im.AddSuccessfulApply(deploymentID, deployment.GetUID(), deployment.GetGeneration())
+			im.AddSuccessfulApply(secretID, secret.GetUID(), secret.GetGeneration())
+			require.NoError(t, im.SetSuccessfulReconcile(deploymentID))
+			im.SetObjectStatus(actuation.ObjectStatus{
+				ObjectReference: inventory.ObjectReferenceFromObjMetadata(secretID),
+				Strategy:        actuation.ActuationStrategyApply,
+				Actuation:       actuation.ActuationSucceeded,
+				Reconcile:       tc.reconcile,
+				UID:             secret.GetUID(),
+				Generation:      secret.GetGeneration(),
+			})
+			// The deployment existed before the apply, the secret did not.
+			taskContext.AddPreviousObject(deploymentID, deployment.DeepCopy())
+			taskContext.AddPreviousObject(secretID, nil)
+
+			if tc.restore {
+				task.Restore(taskContext)
+			} else {
+				task.Start(taskContext)
+				result := <-taskContext.TaskChannel()
+				require.NoError(t, result.Err)
+			}
+			close(eventChannel)
+
+			var received []testutil.ExpEvent
+			for e := range eventChannel {
+				received = append(received, testutil.EventToExpEvent(e))
+			}
+			testutil.AssertEqual(t, tc.expectedEvents, received)
+
+			depClient := dynamicClient.Resource(schema.GroupVersionResource{
+				Group: "apps", Version: "v1", Resource: "deployments"}).Namespace(deploymentID.Namespace)
+			liveDeployment, err := depClient.Get(t.Context(), deploymentID.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			replicas, _, err := unstructured.NestedInt64(liveDeployment.Object, "spec", "replicas")
+			require.NoError(t, err)
+
+			secretClient := dynamicClient.Resource(schema.GroupVersionResource{
+				Version: "v1", Resource: "secrets"}).Namespace(secretID.Namespace)
+			_, err = secretClient.Get(t.Context(), secretID.Name, metav1.GetOptions{})
+			if tc.expectRestored {
+				require.Equal(t, int64(1), replicas)
+				require.True(t, apierrors.IsNotFound(err))
+				// The managed fields and status are not restored.
+				assert.Empty(t, liveDeployment.GetManagedFields())
+				assert.NotContains(t, liveDeployment.Object, "status")
+			} else {
+				require.Equal(t, int64(3), replicas)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package taskrunner
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// RunConcurrently calls fn for each object with at most concurrency
+// workers, and waits for all of them to finish. The events of each object
+// are buffered and sent in the order of the objects, so the events are in
+// the same order as when calling fn sequentially. Retry events are sent
+// immediately instead, so that retries are reported while they happen.
+func (tc *TaskContext) RunConcurrently(
+	concurrency int,
+	objs object.UnstructuredSet,
+	fn func(obj *unstructured.Unstructured, eventChannel chan<- event.Event),
+) {
+	results := make([]chan []event.Event, len(objs))
+	for i := range results {
+		results[i] = make(chan []event.Event, 1)
+	}
+	go func() {
+		workers := make(chan struct{}, concurrency)
+		for i, obj := range objs {
+			workers <- struct{}{}
+			go func() {
+				defer func() { <-workers }()
+				results[i] <- collectEvents(tc.SendEvent, func(eventChannel chan<- event.Event) {
+					fn(obj, eventChannel)
+				})
+			}()
+		}
+	}()
+	for _, result := range results {
+		for _, e := range <-result {
+			tc.SendEvent(e)
+		}
+	}
+}
+
+// collectEvents calls fn with a new event channel and returns the events
+// sent to it, except retry events, which are passed to send as soon as they
+// are received.
+func collectEvents(send func(event.Event), fn func(chan<- event.Event)) []event.Event {
+	eventChannel := make(chan event.Event)
+	done := make(chan struct{})
+	var events []event.Event
+	go func() {
+		defer close(done)
+		for e := range eventChannel {
+			if e.Type == event.RetryType {
+				send(e)
+				continue
+			}
+			events = append(events, e)
+		}
+	}()
+	fn(eventChannel)
+	close(eventChannel)
+	<-done
+	return events
+}
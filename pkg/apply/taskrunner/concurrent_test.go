@@ -0,0 +1,61 @@
+// Copyright 2025 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package taskrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestRunConcurrently(t *testing.T) {
+	var objs object.UnstructuredSet
+	for _, name := range []string{"a", "b", "c"} {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("default")
+		u.SetName(name)
+		objs = append(objs, u)
+	}
+
+	eventChannel := make(chan event.Event)
+	taskContext := NewTaskContext(t.Context(), eventChannel, cache.NewResourceCacheMap())
+	release := make(chan struct{})
+	go func() {
+		defer close(eventChannel)
+		taskContext.RunConcurrently(3, objs, func(obj *unstructured.Unstructured, eventChannel chan<- event.Event) {
+			id := object.UnstructuredToObjMetadata(obj)
+			if obj.GetName() == "a" {
+				// The retry event must be sent while the first object is
+				// still running, and before the events of the others.
+				eventChannel <- event.Event{
+					Type:       event.RetryType,
+					RetryEvent: event.RetryEvent{Identifier: id},
+				}
+				<-release
+			}
+			eventChannel <- event.Event{
+				Type:       event.ApplyType,
+				ApplyEvent: event.ApplyEvent{Identifier: id},
+			}
+		})
+	}()
+
+	first := <-eventChannel
+	assert.Equal(t, event.RetryType, first.Type)
+	close(release)
+
+	var names []string
+	for e := range eventChannel {
+		assert.Equal(t, event.ApplyType, e.Type)
+		names = append(names, e.ApplyEvent.Identifier.Name)
+	}
+	testutil.AssertEqual(t, []string{"a", "b", "c"}, names)
+}
@@ -4,6 +4,10 @@
 package taskrunner
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/jsonpath"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -36,6 +40,61 @@ func (c Condition) Meets(s status.Status) bool {
 	}
 }
 
+// ObjectConditionKind is the kind of an ObjectCondition.
+type ObjectConditionKind string
+
+const (
+	// Completed ObjectCondition means the resource has run to completion:
+	// Jobs have the Complete condition and Pods have the Succeeded phase.
+	// Jobs and Pods that failed fail the condition.
+	Completed ObjectConditionKind = "Completed"
+
+	// ConditionTrue ObjectCondition means the resource has a status
+	// condition of the ObjectCondition Type, with status True.
+	ConditionTrue ObjectConditionKind = "ConditionTrue"
+
+	// FieldNotEmpty ObjectCondition means the ObjectCondition JSONPath
+	// expression matches a field of the resource that is not empty.
+	FieldNotEmpty ObjectConditionKind = "FieldNotEmpty"
+)
+
+// ObjectCondition is a condition that a specific resource must meet, in
+// addition to the Condition of the WaitTask. Unlike a Condition, it depends
+// on the resource itself, not just its status.
+type ObjectCondition struct {
+	Kind ObjectConditionKind
+	// Type is the status condition type, for ConditionTrue.
+	Type string
+	// JSONPath is the JSONPath expression, for FieldNotEmpty.
+	JSONPath string
+}
+
+// Meets returns true if the provided resource meets the condition and
+// false if it does not.
+func (c ObjectCondition) Meets(obj *unstructured.Unstructured) bool {
+	switch c.Kind {
+	case Completed:
+		return isCompleted(obj)
+	case ConditionTrue:
+		return hasConditionTrue(obj, c.Type)
+	case FieldNotEmpty:
+		return hasFieldNotEmpty(obj, c.JSONPath)
+	default:
+		return false
+	}
+}
+
+// Failed returns true if the provided resource can no longer meet the
+// condition. Only Pods can fail the Completed condition this way, because
+// failed Jobs have the Failed status.
+func (c ObjectCondition) Failed(obj *unstructured.Unstructured) bool {
+	if c.Kind != Completed || obj.GroupVersionKind().GroupKind() != podGK {
+		return false
+	}
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	return phase == string(corev1.PodFailed)
+}
+
 // conditionMet tests whether the provided Condition holds true for
 // all resources in the list, according to the ResourceCache.
 // Resources in the cache older that the applied generation are non-matches.
@@ -50,6 +109,23 @@ func conditionMet(taskContext *TaskContext, ids object.ObjMetadataSet, c Conditi
 	}
 }
 
+// objectConditionMet tests whether the provided ObjectCondition holds true
+// for all resources in the list, according to the ResourceCache.
+// Resources in the cache older that the applied generation are non-matches.
+func objectConditionMet(taskContext *TaskContext, ids object.ObjMetadataSet, c ObjectCondition) bool {
+	return allMatchResource(taskContext, ids, c.Meets)
+}
+
+// objectConditionFailed tests whether the provided ObjectCondition can no
+// longer be met by the resource, according to the ResourceCache.
+func objectConditionFailed(taskContext *TaskContext, id object.ObjMetadata, c ObjectCondition) bool {
+	cached := taskContext.ResourceCache().Get(id)
+	if cached.Resource == nil {
+		return false
+	}
+	return c.Failed(cached.Resource)
+}
+
 // allMatchStatus checks whether all of the resources provided have the provided status.
 // Resources with older generations are considered non-matching.
 func allMatchStatus(taskContext *TaskContext, ids object.ObjMetadataSet, s status.Status) bool {
@@ -93,3 +169,84 @@ func noneMatchStatus(taskContext *TaskContext, ids object.ObjMetadataSet, s stat
 	}
 	return true
 }
+
+// allMatchResource checks whether all of the resources provided exist and
+// match the provided function.
+// Resources with older generations are considered non-matching.
+func allMatchResource(taskContext *TaskContext, ids object.ObjMetadataSet, matches func(*unstructured.Unstructured) bool) bool {
+	for _, id := range ids {
+		cached := taskContext.ResourceCache().Get(id)
+		if cached.Resource == nil || cached.Status == status.NotFoundStatus {
+			return false
+		}
+
+		applyGen, _ := taskContext.InventoryManager().AppliedGeneration(id) // generation at apply time
+		if cached.Resource.GetGeneration() < applyGen {
+			// cache too old
+			return false
+		}
+
+		if !matches(cached.Resource) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasConditionTrue checks whether the resource has a status condition of the
+// provided type, with status True.
+func hasConditionTrue(obj *unstructured.Unstructured, conditionType string) bool {
+	objc, err := status.GetObjectWithConditions(obj.Object)
+	if err != nil {
+		klog.V(3).Infof("failed to read conditions of %s: %v", object.UnstructuredToObjMetadata(obj), err)
+		return false
+	}
+	for _, c := range objc.Status.Conditions {
+		if c.Type == conditionType {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isCompleted checks whether the Pod has the Succeeded phase, or whether any
+// other resource has the Complete condition.
+func isCompleted(obj *unstructured.Unstructured) bool {
+	if obj.GroupVersionKind().GroupKind() == podGK {
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		return phase == string(corev1.PodSucceeded)
+	}
+	return hasConditionTrue(obj, "Complete")
+}
+
+// hasFieldNotEmpty checks whether the JSONPath expression matches a field of
+// the resource that is not null, an empty string, an empty list or an empty
+// map.
+func hasFieldNotEmpty(obj *unstructured.Unstructured, expression string) bool {
+	values, err := jsonpath.Get(obj.Object, expression)
+	if err != nil {
+		klog.V(3).Infof("failed to read field of %s: %v", object.UnstructuredToObjMetadata(obj), err)
+		return false
+	}
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			if v != "" {
+				return true
+			}
+		case []any:
+			if len(v) > 0 {
+				return true
+			}
+		case map[string]any:
+			if len(v) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
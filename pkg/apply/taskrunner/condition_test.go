@@ -48,6 +48,45 @@ conditions:
   type: Ready
 `
 
+var job1y = `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: Foo
+  namespace: default
+spec: {}
+status:
+  startTime: "2026-01-01T00:00:00Z"
+  conditions:
+  - status: "True"
+    type: Complete
+`
+
+var service1y = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: Foo
+  namespace: default
+spec:
+  type: LoadBalancer
+status:
+  loadBalancer:
+    ingress:
+    - ip: 10.0.0.1
+`
+
+var pod1y = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: Foo
+  namespace: default
+spec: {}
+status:
+  phase: Succeeded
+`
+
 // withGeneration returns a DeepCopy with .metadata.generation set.
 func withGeneration(obj *unstructured.Unstructured, gen int64) *unstructured.Unstructured {
 	obj = obj.DeepCopy()
@@ -167,3 +206,232 @@ func TestCollector_ConditionMet(t *testing.T) {
 		})
 	}
 }
+
+func TestCollector_ObjectConditionMet(t *testing.T) {
+	deployment1 := ktestutil.YamlToUnstructured(t, deployment1y)
+	deployment1Meta := object.UnstructuredToObjMetadata(deployment1)
+	custom1 := ktestutil.YamlToUnstructured(t, custom1y)
+	custom1Meta := object.UnstructuredToObjMetadata(custom1)
+	job1 := ktestutil.YamlToUnstructured(t, job1y)
+	job1Meta := object.UnstructuredToObjMetadata(job1)
+	service1 := ktestutil.YamlToUnstructured(t, service1y)
+	service1Meta := object.UnstructuredToObjMetadata(service1)
+	pod1 := ktestutil.YamlToUnstructured(t, pod1y)
+	pod1Meta := object.UnstructuredToObjMetadata(pod1)
+
+	testCases := map[string]struct {
+		cacheContents  []cache.ResourceStatus
+		appliedGen     map[object.ObjMetadata]int64
+		ids            object.ObjMetadataSet
+		condition      ObjectCondition
+		expectedResult bool
+	}{
+		"single resource with condition true": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: withGeneration(job1, 1),
+					Status:   status.CurrentStatus,
+				},
+			},
+			appliedGen: map[object.ObjMetadata]int64{
+				job1Meta: 1,
+			},
+			ids: object.ObjMetadataSet{
+				job1Meta,
+			},
+			condition:      ObjectCondition{Kind: ConditionTrue, Type: "Complete"},
+			expectedResult: true,
+		},
+		"single resource with condition true and old generation": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: withGeneration(job1, 1),
+					Status:   status.CurrentStatus,
+				},
+			},
+			appliedGen: map[object.ObjMetadata]int64{
+				job1Meta: 2,
+			},
+			ids: object.ObjMetadataSet{
+				job1Meta,
+			},
+			condition:      ObjectCondition{Kind: ConditionTrue, Type: "Complete"},
+			expectedResult: false,
+		},
+		"single resource with condition false": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: withGeneration(custom1, 0),
+					Status:   status.CurrentStatus,
+				},
+			},
+			ids: object.ObjMetadataSet{
+				custom1Meta,
+			},
+			condition:      ObjectCondition{Kind: ConditionTrue, Type: "Ready"},
+			expectedResult: false,
+		},
+		"single resource without condition": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: withGeneration(job1, 1),
+					Status:   status.CurrentStatus,
+				},
+			},
+			ids: object.ObjMetadataSet{
+				job1Meta,
+			},
+			condition:      ObjectCondition{Kind: ConditionTrue, Type: "Failed"},
+			expectedResult: false,
+		},
+		"single resource not found with condition": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: withGeneration(job1, 1),
+					Status:   status.NotFoundStatus,
+				},
+			},
+			ids: object.ObjMetadataSet{
+				job1Meta,
+			},
+			condition:      ObjectCondition{Kind: ConditionTrue, Type: "Complete"},
+			expectedResult: false,
+		},
+		"single resource with field not empty": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: service1,
+					Status:   status.CurrentStatus,
+				},
+			},
+			ids: object.ObjMetadataSet{
+				service1Meta,
+			},
+			condition:      ObjectCondition{Kind: FieldNotEmpty, JSONPath: "$.status.loadBalancer.ingress[0].ip"},
+			expectedResult: true,
+		},
+		"single resource with field empty": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: service1,
+					Status:   status.CurrentStatus,
+				},
+			},
+			ids: object.ObjMetadataSet{
+				service1Meta,
+			},
+			condition:      ObjectCondition{Kind: FieldNotEmpty, JSONPath: "$.status.loadBalancer.ingress[0].hostname"},
+			expectedResult: false,
+		},
+		"multiple resources with field not empty": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: service1,
+					Status:   status.CurrentStatus,
+				},
+				{
+					Resource: withGeneration(job1, 1),
+					Status:   status.CurrentStatus,
+				},
+			},
+			ids: object.ObjMetadataSet{
+				service1Meta,
+				job1Meta,
+			},
+			condition:      ObjectCondition{Kind: FieldNotEmpty, JSONPath: "$.status.conditions"},
+			expectedResult: false,
+		},
+		"multiple resources completed": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: withGeneration(job1, 1),
+					Status:   status.CurrentStatus,
+				},
+				{
+					Resource: pod1,
+					Status:   status.CurrentStatus,
+				},
+			},
+			ids: object.ObjMetadataSet{
+				job1Meta,
+				pod1Meta,
+			},
+			condition:      ObjectCondition{Kind: Completed},
+			expectedResult: true,
+		},
+		"single resource not completed": {
+			cacheContents: []cache.ResourceStatus{
+				{
+					Resource: withGeneration(deployment1, 1),
+					Status:   status.CurrentStatus,
+				},
+			},
+			ids: object.ObjMetadataSet{
+				deployment1Meta,
+			},
+			condition:      ObjectCondition{Kind: Completed},
+			expectedResult: false,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			resourceCache := cache.NewResourceCacheMap()
+			resourceCache.Load(tc.cacheContents...)
+
+			taskContext := NewTaskContext(t.Context(), nil, resourceCache)
+
+			for id, gen := range tc.appliedGen {
+				taskContext.InventoryManager().AddSuccessfulApply(id, types.UID("unused"), gen)
+			}
+
+			res := objectConditionMet(taskContext, tc.ids, tc.condition)
+
+			assert.Equal(t, tc.expectedResult, res)
+		})
+	}
+}
+
+func TestCollector_ObjectConditionFailed(t *testing.T) {
+	pod1 := ktestutil.YamlToUnstructured(t, pod1y)
+	pod1Meta := object.UnstructuredToObjMetadata(pod1)
+	pod1Failed := pod1.DeepCopy()
+	pod1Failed.Object["status"] = map[string]any{"phase": "Failed"}
+
+	testCases := map[string]struct {
+		resource       *unstructured.Unstructured
+		condition      ObjectCondition
+		expectedResult bool
+	}{
+		"succeeded pod": {
+			resource:       pod1,
+			condition:      ObjectCondition{Kind: Completed},
+			expectedResult: false,
+		},
+		"failed pod": {
+			resource:       pod1Failed,
+			condition:      ObjectCondition{Kind: Completed},
+			expectedResult: true,
+		},
+		"failed pod with other condition": {
+			resource:       pod1Failed,
+			condition:      ObjectCondition{Kind: ConditionTrue, Type: "Ready"},
+			expectedResult: false,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			resourceCache := cache.NewResourceCacheMap()
+			resourceCache.Load(cache.ResourceStatus{
+				Resource: tc.resource,
+				Status:   status.CurrentStatus,
+			})
+			taskContext := NewTaskContext(t.Context(), nil, resourceCache)
+
+			res := objectConditionFailed(taskContext, pod1Meta, tc.condition)
+
+			assert.Equal(t, tc.expectedResult, res)
+		})
+	}
+}
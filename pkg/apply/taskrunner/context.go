@@ -5,8 +5,12 @@ package taskrunner
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
@@ -22,8 +26,12 @@ func NewTaskContext(ctx context.Context, eventChannel chan event.Event, resource
 		eventChannel:     eventChannel,
 		resourceCache:    resourceCache,
 		inventoryManager: inventory.NewManager(),
+		objectsMu:        &sync.RWMutex{},
 		abandonedObjects: make(map[object.ObjMetadata]struct{}),
 		invalidObjects:   make(map[object.ObjMetadata]struct{}),
+		hookObjects:      make(map[object.ObjMetadata]struct{}),
+		previousObjects:  make(map[object.ObjMetadata]*unstructured.Unstructured),
+		externalObjects:  make(map[object.ObjMetadata]actuation.ReconcileStatus),
 		graph:            graph.New(),
 	}
 }
@@ -36,9 +44,22 @@ type TaskContext struct {
 	eventChannel     chan event.Event
 	resourceCache    cache.ResourceCache
 	inventoryManager *inventory.Manager
+	// objectsMu protects abandonedObjects, invalidObjects, hookObjects,
+	// previousObjects and externalObjects, which are shared with the copies
+	// of the TaskContext given to running tasks.
+	objectsMu        *sync.RWMutex
 	abandonedObjects map[object.ObjMetadata]struct{}
 	invalidObjects   map[object.ObjMetadata]struct{}
-	graph            *graph.Graph
+	// hookObjects are the lifecycle hooks, which are applied and deleted but
+	// not stored in the inventory.
+	hookObjects map[object.ObjMetadata]struct{}
+	// previousObjects stores the live state of objects before they were
+	// applied. A nil value means the object did not exist.
+	previousObjects map[object.ObjMetadata]*unstructured.Unstructured
+	// externalObjects maps the external dependencies, which are waited on
+	// but not applied or deleted, to their reconcile status.
+	externalObjects map[object.ObjMetadata]actuation.ReconcileStatus
+	graph           *graph.Graph
 }
 
 func (tc *TaskContext) Context() context.Context {
@@ -49,6 +70,15 @@ func (tc *TaskContext) TaskChannel() chan TaskResult {
 	return tc.taskChannel
 }
 
+// withTaskChannel returns a shallow copy of the TaskContext that reports
+// task results on the specified channel. All other state is shared.
+// This allows the runner to tell apart the results of concurrent tasks.
+func (tc *TaskContext) withTaskChannel(taskChannel chan TaskResult) *TaskContext {
+	c := *tc
+	c.taskChannel = taskChannel
+	return &c
+}
+
 func (tc *TaskContext) EventChannel() chan event.Event {
 	return tc.eventChannel
 }
@@ -77,32 +107,131 @@ func (tc *TaskContext) SendEvent(e event.Event) {
 
 // IsAbandonedObject returns true if the object is abandoned
 func (tc *TaskContext) IsAbandonedObject(id object.ObjMetadata) bool {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
 	_, found := tc.abandonedObjects[id]
 	return found
 }
 
 // AddAbandonedObject registers that the object is abandoned
 func (tc *TaskContext) AddAbandonedObject(id object.ObjMetadata) {
+	tc.objectsMu.Lock()
+	defer tc.objectsMu.Unlock()
 	tc.abandonedObjects[id] = struct{}{}
 }
 
 // AbandonedObjects returns all the abandoned objects
 func (tc *TaskContext) AbandonedObjects() object.ObjMetadataSet {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
 	return object.ObjMetadataSetFromMap(tc.abandonedObjects)
 }
 
 // IsInvalidObject returns true if the object is abandoned
 func (tc *TaskContext) IsInvalidObject(id object.ObjMetadata) bool {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
 	_, found := tc.invalidObjects[id]
 	return found
 }
 
 // AddInvalidObject registers that the object is abandoned
 func (tc *TaskContext) AddInvalidObject(id object.ObjMetadata) {
+	tc.objectsMu.Lock()
+	defer tc.objectsMu.Unlock()
 	tc.invalidObjects[id] = struct{}{}
 }
 
 // InvalidObjects returns all the abandoned objects
 func (tc *TaskContext) InvalidObjects() object.ObjMetadataSet {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
 	return object.ObjMetadataSetFromMap(tc.invalidObjects)
 }
+
+// IsHookObject returns true if the object is a lifecycle hook
+func (tc *TaskContext) IsHookObject(id object.ObjMetadata) bool {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
+	_, found := tc.hookObjects[id]
+	return found
+}
+
+// AddHookObject registers that the object is a lifecycle hook
+func (tc *TaskContext) AddHookObject(id object.ObjMetadata) {
+	tc.objectsMu.Lock()
+	defer tc.objectsMu.Unlock()
+	tc.hookObjects[id] = struct{}{}
+}
+
+// HookObjects returns all the lifecycle hooks
+func (tc *TaskContext) HookObjects() object.ObjMetadataSet {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
+	return object.ObjMetadataSetFromMap(tc.hookObjects)
+}
+
+// AddPreviousObject registers the live state of an object before it was
+// applied, or nil if the object did not exist. Only the first state
+// registered for an object is kept.
+func (tc *TaskContext) AddPreviousObject(id object.ObjMetadata, obj *unstructured.Unstructured) {
+	tc.objectsMu.Lock()
+	defer tc.objectsMu.Unlock()
+	if _, found := tc.previousObjects[id]; found {
+		return
+	}
+	tc.previousObjects[id] = obj
+}
+
+// PreviousObject returns the live state of an object before it was applied,
+// and whether the state was registered. The returned object is nil if the
+// object did not exist.
+func (tc *TaskContext) PreviousObject(id object.ObjMetadata) (*unstructured.Unstructured, bool) {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
+	obj, found := tc.previousObjects[id]
+	return obj, found
+}
+
+// AddExternalObject registers an external dependency, which is waited on but
+// not applied or deleted. Its reconcile status is pending until set.
+func (tc *TaskContext) AddExternalObject(id object.ObjMetadata) {
+	tc.objectsMu.Lock()
+	defer tc.objectsMu.Unlock()
+	if _, found := tc.externalObjects[id]; found {
+		return
+	}
+	tc.externalObjects[id] = actuation.ReconcilePending
+}
+
+// ExternalObjects returns all the external dependencies
+func (tc *TaskContext) ExternalObjects() object.ObjMetadataSet {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
+	ids := make(object.ObjMetadataSet, 0, len(tc.externalObjects))
+	for id := range tc.externalObjects {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ExternalReconcileStatus returns the reconcile status of an external
+// dependency, and whether the object is an external dependency.
+func (tc *TaskContext) ExternalReconcileStatus(id object.ObjMetadata) (actuation.ReconcileStatus, bool) {
+	tc.objectsMu.RLock()
+	defer tc.objectsMu.RUnlock()
+	status, found := tc.externalObjects[id]
+	return status, found
+}
+
+// SetExternalReconcileStatus updates the reconcile status of an external
+// dependency. Returns an error if the object is not an external dependency.
+func (tc *TaskContext) SetExternalReconcileStatus(id object.ObjMetadata, status actuation.ReconcileStatus) error {
+	tc.objectsMu.Lock()
+	defer tc.objectsMu.Unlock()
+	if _, found := tc.externalObjects[id]; !found {
+		return fmt.Errorf("object not an external dependency: %q", id)
+	}
+	tc.externalObjects[id] = status
+	return nil
+}
@@ -41,7 +41,8 @@ type Options struct {
 }
 
 // Run executes the tasks in the taskqueue, with the statusPoller running in the
-// background.
+// background. Tasks are executed sequentially, in the order they are read from
+// the taskqueue.
 //
 // The tasks run in a loop where a single goroutine will process events from
 // three different channels.
@@ -55,6 +56,40 @@ func (tsr *TaskStatusRunner) Run(
 	taskContext *TaskContext,
 	taskQueue chan Task,
 	opts Options,
+) error {
+	return tsr.run(ctx, taskContext, &queueScheduler{taskQueue: taskQueue}, opts)
+}
+
+// RunGraph executes the tasks in the TaskGraph, with the statusPoller running
+// in the background. Each task is started as soon as all of its dependencies
+// have completed, so tasks that do not depend on each other run concurrently.
+func (tsr *TaskStatusRunner) RunGraph(
+	ctx context.Context,
+	taskContext *TaskContext,
+	taskGraph *TaskGraph,
+	opts Options,
+) error {
+	return tsr.run(ctx, taskContext, newGraphScheduler(taskGraph), opts)
+}
+
+// runningTask is a task that has been started but has not yet completed,
+// along with the TaskContext it was started with.
+type runningTask struct {
+	task        Task
+	taskContext *TaskContext
+}
+
+// completedTask is the result of a task, along with the task itself.
+type completedTask struct {
+	task   Task
+	result TaskResult
+}
+
+func (tsr *TaskStatusRunner) run(
+	ctx context.Context,
+	taskContext *TaskContext,
+	sched scheduler,
+	opts Options,
 ) error {
 	// Give the poller its own context and run it in the background.
 	// If taskStatusRunner.Run is cancelled, baseRunner.run will exit early,
@@ -78,18 +113,37 @@ func (tsr *TaskStatusRunner) Run(
 		return err
 	}
 
-	// Wait until the StatusWatcher is sychronized to start the first task.
-	var currentTask Task
-	done := false
+	// running is the list of started tasks that have not yet completed,
+	// in the order they were started.
+	var running []runningTask
+
+	// completedChannel receives the results of all running tasks.
+	completedChannel := make(chan completedTask)
 
 	// abort is used to signal that something has failed, and
 	// the task processing should end as soon as is possible. Only
 	// wait tasks can be interrupted, so for all other tasks we need
-	// to wait for the currently running one to finish before we can
+	// to wait for the currently running ones to finish before we can
 	// exit.
 	abort := false
 	var abortReason error
 
+	// cancelRunning interrupts all the running tasks.
+	cancelRunning := func() {
+		for _, rt := range running {
+			rt.task.Cancel(rt.taskContext)
+		}
+	}
+
+	// startTasks starts all the tasks that are ready to be started.
+	// Returns true if there are no more tasks to run.
+	startTasks := func() bool {
+		for _, tsk := range sched.next(len(running)) {
+			running = append(running, startTask(tsk, taskContext, completedChannel))
+		}
+		return len(running) == 0
+	}
+
 	// We do this so we can set the doneCh to a nil channel after
 	// it has been closed. This is needed to avoid a busy loop.
 	doneCh := ctx.Done()
@@ -124,21 +178,19 @@ func (tsr *TaskStatusRunner) Run(
 				abort = true
 				abortReason = fmt.Errorf("polling for status failed: %v",
 					statusEvent.Error)
-				if currentTask != nil {
-					currentTask.Cancel(taskContext)
-				} else {
+				if len(running) == 0 {
 					// tasks not started yet - abort now
 					return complete(abortReason)
 				}
+				cancelRunning()
 				continue
 			}
 
 			// The StatusWatcher is synchronized.
 			// Tasks may commence!
 			if statusEvent.Type == pollevent.SyncEvent {
-				// Find and start the first task in the queue.
-				currentTask, done = nextTask(taskQueue, taskContext)
-				if done {
+				// Find and start the first task(s) in the queue.
+				if done := startTasks(); done {
 					return complete(nil)
 				}
 				continue
@@ -168,75 +220,82 @@ func (tsr *TaskStatusRunner) Run(
 				StatusMessage: statusEvent.Resource.Message,
 			})
 
-			// send a status update to the running task, but only if the status
+			// send a status update to the running tasks, but only if the status
 			// has changed and the task is tracking the object.
-			if currentTask != nil {
-				if currentTask.Identifiers().Contains(id) {
-					currentTask.StatusUpdate(taskContext, id)
+			for _, rt := range running {
+				if rt.task.Identifiers().Contains(id) {
+					rt.task.StatusUpdate(rt.taskContext, id)
 				}
 			}
-		// A message on the taskChannel means that the current task
+		// A message on the completedChannel means that a running task
 		// has either completed or failed.
-		// If it has failed, we return the error.
+		// If it has failed, we abort and return the error once all the
+		// other running tasks have finished.
 		// If the abort flag is true, which means something
-		// else has gone wrong and we are waiting for the current task to
-		// finish, we exit.
-		// If everything is ok, we fetch and start the next task.
-		case msg := <-taskContext.TaskChannel():
+		// else has gone wrong and we are waiting for the running
+		// tasks to finish, we exit once they have.
+		// If everything is ok, we fetch and start the next task(s).
+		case msg := <-completedChannel:
+			running = removeRunningTask(running, msg.task)
+			sched.complete(msg.task)
 			taskContext.SendEvent(event.Event{
 				Type: event.ActionGroupType,
 				ActionGroupEvent: event.ActionGroupEvent{
-					GroupName: currentTask.Name(),
-					Action:    currentTask.Action(),
+					GroupName: msg.task.Name(),
+					Action:    msg.task.Action(),
 					Status:    event.Finished,
 				},
 			})
-			if msg.Err != nil {
-				return complete(
-					fmt.Errorf("task failed (action: %q, name: %q): %w",
-						currentTask.Action(), currentTask.Name(), msg.Err))
+			if msg.result.Err != nil {
+				abort = true
+				abortReason = fmt.Errorf("task failed (action: %q, name: %q): %w",
+					msg.task.Action(), msg.task.Name(), msg.result.Err)
+				cancelRunning()
 			}
 			if abort {
-				return complete(abortReason)
+				if len(running) == 0 {
+					return complete(abortReason)
+				}
+				continue
 			}
-			currentTask, done = nextTask(taskQueue, taskContext)
 			// If there are no more tasks, we are done. So just
 			// return.
-			if done {
+			if done := startTasks(); done {
 				return complete(nil)
 			}
 		// The doneCh will be closed if the passed in context is cancelled.
 		// If so, we just set the abort flag and wait for the currently running
-		// task to complete before we exit.
+		// tasks to complete before we exit.
 		case <-doneCh:
 			doneCh = nil // Set doneCh to nil so we don't enter a busy loop.
 			abort = true
 			abortReason = ctx.Err() // always non-nil when doneCh is closed
 			klog.V(7).Infof("Runner aborting: %v", abortReason)
-			if currentTask != nil {
-				currentTask.Cancel(taskContext)
-			} else {
+			if len(running) == 0 {
 				// tasks not started yet - abort now
 				return complete(abortReason)
 			}
+			cancelRunning()
 		}
 	}
 }
 
-// nextTask fetches the latest task from the taskQueue and
-// starts it. If the taskQueue is empty, it the second
-// return value will be true.
-func nextTask(taskQueue chan Task, taskContext *TaskContext) (Task, bool) {
-	var tsk Task
-	select {
-	// If there is any tasks left in the queue, this
-	// case statement will be executed.
-	case t := <-taskQueue:
-		tsk = t
-	default:
-		// Only happens when the channel is empty.
-		return nil, true
+// startTask sends the event for the start of the task and starts it.
+// The task is given its own TaskContext, with a task channel whose
+// result is forwarded to the completedChannel, so that the results of
+// concurrently running tasks can be told apart.
+func startTask(tsk Task, taskContext *TaskContext, completedChannel chan<- completedTask) runningTask {
+	taskChannel := make(chan TaskResult)
+	rt := runningTask{
+		task:        tsk,
+		taskContext: taskContext.withTaskChannel(taskChannel),
 	}
+	go func() {
+		completedChannel <- completedTask{
+			task:   tsk,
+			result: <-taskChannel,
+		}
+	}()
 
 	taskContext.SendEvent(event.Event{
 		Type: event.ActionGroupType,
@@ -247,9 +306,20 @@ func nextTask(taskQueue chan Task, taskContext *TaskContext) (Task, bool) {
 		},
 	})
 
-	tsk.Start(taskContext)
+	tsk.Start(rt.taskContext)
 
-	return tsk, false
+	return rt
+}
+
+// removeRunningTask returns the list of running tasks without the specified
+// task, preserving the order of the remaining tasks.
+func removeRunningTask(running []runningTask, tsk Task) []runningTask {
+	for i, rt := range running {
+		if rt.task == tsk {
+			return append(running[:i], running[i+1:]...)
+		}
+	}
+	return running
 }
 
 // TaskResult is the type returned from tasks once they have completed
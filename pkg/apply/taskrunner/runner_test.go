@@ -507,6 +507,114 @@ func TestBaseRunnerCancellation(t *testing.T) {
 	}
 }
 
+func TestBaseRunnerGraph(t *testing.T) {
+	slow := newBlockingTask("slow")
+	fast := newBlockingTask("fast")
+	last := newBlockingTask("last")
+	taskGraph := NewTaskGraph()
+	taskGraph.AddTask(slow)
+	taskGraph.AddTask(fast)
+	taskGraph.AddTask(last, slow, fast)
+
+	ids := object.ObjMetadataSet{} // unused by fake statusWatcher
+	statusWatcher := newFakeWatcher(nil)
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(t.Context(), eventChannel, resourceCache)
+	runner := NewTaskStatusRunner(ids, statusWatcher)
+	statusWatcher.Start()
+
+	groupEvents := make(chan event.ActionGroupEvent, 10)
+	go func() {
+		defer close(groupEvents)
+		for msg := range eventChannel {
+			if msg.Type == event.ActionGroupType {
+				groupEvents <- msg.ActionGroupEvent
+			}
+		}
+	}()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runner.RunGraph(context.Background(), taskContext, taskGraph, Options{})
+		close(eventChannel)
+	}()
+
+	nextGroupEvent := func() event.ActionGroupEvent {
+		select {
+		case e := <-groupEvents:
+			return e
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for an action group event")
+			return event.ActionGroupEvent{}
+		}
+	}
+
+	// Independent tasks start together, before either has finished.
+	assert.Equal(t, event.ActionGroupEvent{GroupName: "slow", Action: event.ApplyAction, Status: event.Started}, nextGroupEvent())
+	assert.Equal(t, event.ActionGroupEvent{GroupName: "fast", Action: event.ApplyAction, Status: event.Started}, nextGroupEvent())
+	<-slow.started
+	<-fast.started
+
+	// The dependent task does not start until all its dependencies have
+	// finished.
+	close(fast.release)
+	assert.Equal(t, event.ActionGroupEvent{GroupName: "fast", Action: event.ApplyAction, Status: event.Finished}, nextGroupEvent())
+	select {
+	case <-last.started:
+		t.Fatal("dependent task started before all its dependencies finished")
+	default:
+	}
+
+	close(slow.release)
+	assert.Equal(t, event.ActionGroupEvent{GroupName: "slow", Action: event.ApplyAction, Status: event.Finished}, nextGroupEvent())
+	assert.Equal(t, event.ActionGroupEvent{GroupName: "last", Action: event.ApplyAction, Status: event.Started}, nextGroupEvent())
+	close(last.release)
+	assert.Equal(t, event.ActionGroupEvent{GroupName: "last", Action: event.ApplyAction, Status: event.Finished}, nextGroupEvent())
+
+	assert.NoError(t, <-errCh)
+}
+
+// blockingTask is a fake task that signals when it has started, and does not
+// complete until it is released.
+type blockingTask struct {
+	name    string
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingTask(name string) *blockingTask {
+	return &blockingTask{
+		name:    name,
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (b *blockingTask) Name() string {
+	return b.name
+}
+
+func (b *blockingTask) Action() event.ResourceAction {
+	return event.ApplyAction
+}
+
+func (b *blockingTask) Identifiers() object.ObjMetadataSet {
+	return object.ObjMetadataSet{}
+}
+
+func (b *blockingTask) Start(taskContext *TaskContext) {
+	close(b.started)
+	go func() {
+		<-b.release
+		taskContext.TaskChannel() <- TaskResult{}
+	}()
+}
+
+func (b *blockingTask) Cancel(_ *TaskContext) {}
+
+func (b *blockingTask) StatusUpdate(_ *TaskContext, _ object.ObjMetadata) {}
+
 type fakeApplyTask struct {
 	name        string
 	resultEvent event.Event
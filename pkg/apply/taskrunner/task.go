@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
@@ -20,6 +21,11 @@ import (
 
 var (
 	crdGK = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+	podGK = schema.GroupKind{Kind: "Pod"}
+
+	// stabilityInterval is the maximum interval between the events that
+	// report the time remaining in the stability window of an object.
+	stabilityInterval = 5 * time.Second
 )
 
 // Task is the interface that must be implemented by
@@ -59,11 +65,27 @@ type WaitTask struct {
 	IDs object.ObjMetadataSet
 	// Condition defines the status we want all resources to reach
 	Condition Condition
+	// Conditions are additional conditions that specific resources must
+	// meet, as well as the Condition.
+	Conditions map[object.ObjMetadata][]ObjectCondition
 	// Timeout defines how long we are willing to wait for the condition
 	// to be met.
 	Timeout time.Duration
+	// Timeouts overrides the Timeout for specific objects. Objects that
+	// time out before the others are reported as soon as they time out.
+	Timeouts map[object.ObjMetadata]time.Duration
 	// Mapper is the RESTMapper to update after CRDs have been reconciled
 	Mapper meta.RESTMapper
+	// StabilityWindow defines how long objects must remain reconciled
+	// before the condition is considered met. Only used with the
+	// AllCurrent condition.
+	StabilityWindow time.Duration
+	// StabilityWindows overrides the StabilityWindow for specific objects.
+	StabilityWindows map[object.ObjMetadata]time.Duration
+	// External is true if the resources are external dependencies, which
+	// are not applied or deleted by the task queue. Their reconcile status
+	// is recorded in the TaskContext, instead of the inventory.
+	External bool
 	// cancelFunc is a function that will cancel the timeout timer
 	// on the task.
 	cancelFunc context.CancelFunc
@@ -72,6 +94,21 @@ type WaitTask struct {
 	// failed is the set of resources that we are waiting for, but is considered
 	// failed, i.e. unlikely to successfully reconcile.
 	failed object.ObjMetadataSet
+	// stabilizing maps the pending resources that are reconciled, but have
+	// not yet been reconciled for their whole stability window, to the time
+	// they became reconciled.
+	stabilizing map[object.ObjMetadata]time.Time
+	// timers maps the stabilizing resources to the timer that next checks
+	// their stability window.
+	timers map[object.ObjMetadata]*time.Timer
+	// timedOut is the set of resources that timed out before the task
+	// completed, because of a shorter timeout.
+	timedOut object.ObjMetadataSet
+	// timeoutTimers are the timers of the resources with a shorter timeout.
+	timeoutTimers []*time.Timer
+	// done is true once the task is completing, after which stabilizing
+	// resources are no longer checked.
+	done bool
 	// mu protects the pending ObjMetadataSet
 	mu sync.RWMutex
 }
@@ -98,13 +135,15 @@ func (w *WaitTask) Start(taskContext *TaskContext) {
 	ctx := context.Background()
 
 	// use a context wrapper to handle complete/cancel/timeout
-	if w.Timeout > 0 {
-		ctx, w.cancelFunc = context.WithTimeout(ctx, w.Timeout)
+	timeout := w.taskTimeout()
+	if timeout > 0 {
+		ctx, w.cancelFunc = context.WithTimeout(ctx, timeout)
 	} else {
 		ctx, w.cancelFunc = context.WithCancel(ctx)
 	}
 
 	w.startInner(taskContext)
+	w.startTimeoutTimers(taskContext, timeout)
 
 	// A goroutine to handle ending the WaitTask.
 	go func() {
@@ -115,6 +154,8 @@ func (w *WaitTask) Start(taskContext *TaskContext) {
 
 		klog.V(2).Infof("wait task completing (name: %q,): %v", w.TaskName, err)
 
+		w.stopTimers()
+
 		switch err {
 		case context.Canceled:
 			// happy path - cancelled or completed (not considered an error)
@@ -155,7 +196,7 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 	for _, id := range w.IDs {
 		switch {
 		case w.skipped(taskContext, id):
-			err := taskContext.InventoryManager().SetSkippedReconcile(id)
+			err := w.reconcileStatuses(taskContext).SetSkippedReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
 				klog.Errorf("Failed to mark object as skipped reconcile: %v", err)
@@ -164,15 +205,24 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 		case w.changedUID(taskContext, id):
 			// replaced
 			w.handleChangedUID(taskContext, id)
+		case w.reconciledByID(taskContext, id) && w.stabilityWindow(id) > 0:
+			// reconciled, but must remain reconciled for a while
+			err := w.reconcileStatuses(taskContext).SetPendingReconcile(id)
+			if err != nil {
+				// Object never applied or deleted!
+				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+			}
+			pending = append(pending, id)
+			w.startStabilizing(taskContext, id)
 		case w.reconciledByID(taskContext, id):
-			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
+			err := w.reconcileStatuses(taskContext).SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
 				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
 			}
 			w.sendEvent(taskContext, id, event.ReconcileSuccessful)
 		default:
-			err := taskContext.InventoryManager().SetPendingReconcile(id)
+			err := w.reconcileStatuses(taskContext).SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
 				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
@@ -199,7 +249,7 @@ func (w *WaitTask) sendTimeoutEvents(taskContext *TaskContext) {
 	defer w.mu.RUnlock()
 
 	for _, id := range w.pending {
-		err := taskContext.InventoryManager().SetTimeoutReconcile(id)
+		err := w.reconcileStatuses(taskContext).SetTimeoutReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
 			klog.Errorf("Failed to mark object as pending reconcile: %v", err)
@@ -208,10 +258,19 @@ func (w *WaitTask) sendTimeoutEvents(taskContext *TaskContext) {
 	}
 }
 
-// reconciledByID checks whether the condition set in the task is currently met
+// reconciledByID checks whether the conditions set in the task are currently met
 // for the specified object given the status of resource in the cache.
 func (w *WaitTask) reconciledByID(taskContext *TaskContext, id object.ObjMetadata) bool {
-	return conditionMet(taskContext, object.ObjMetadataSet{id}, w.Condition)
+	ids := object.ObjMetadataSet{id}
+	if !conditionMet(taskContext, ids, w.Condition) {
+		return false
+	}
+	for _, c := range w.Conditions[id] {
+		if !objectConditionMet(taskContext, ids, c) {
+			return false
+		}
+	}
+	return true
 }
 
 // skipped returns true if the object failed or was skipped by a preceding
@@ -229,15 +288,29 @@ func (w *WaitTask) skipped(taskContext *TaskContext, id object.ObjMetadata) bool
 	return false
 }
 
-// failedByID returns true if the resource is failed.
+// failedByID returns true if the resource is failed, or if it failed one of
+// its additional conditions.
 func (w *WaitTask) failedByID(taskContext *TaskContext, id object.ObjMetadata) bool {
 	cached := taskContext.ResourceCache().Get(id)
-	return cached.Status == status.FailedStatus
+	if cached.Status == status.FailedStatus {
+		return true
+	}
+	for _, c := range w.Conditions[id] {
+		if objectConditionFailed(taskContext, id, c) {
+			return true
+		}
+	}
+	return false
 }
 
 // changedUID returns true if the UID of the object has changed since it was
 // applied or deleted. This indicates that the object was deleted and recreated.
 func (w *WaitTask) changedUID(taskContext *TaskContext, id object.ObjMetadata) bool {
+	if w.External {
+		// not applied or deleted
+		return false
+	}
+
 	var oldUID, newUID types.UID
 
 	// Get the uid from the ApplyTask/PruneTask
@@ -285,7 +358,7 @@ func (w *WaitTask) handleChangedUID(taskContext *TaskContext, id object.ObjMetad
 		// Object recreated by another actor after deletion.
 		// Treat as success.
 		klog.Infof("UID change detected: deleted object have been recreated: marking reconcile successful: %v", id)
-		err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
+		err := w.reconcileStatuses(taskContext).SetSuccessfulReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
 			klog.Errorf("Failed to mark object as successful reconcile: %v", err)
@@ -295,7 +368,7 @@ func (w *WaitTask) handleChangedUID(taskContext *TaskContext, id object.ObjMetad
 		// Object deleted and recreated by another actor after apply.
 		// Treat as failure (unverifiable).
 		klog.Infof("UID change detected: applied object has been deleted and recreated: marking reconcile failed: %v", id)
-		err := taskContext.InventoryManager().SetFailedReconcile(id)
+		err := w.reconcileStatuses(taskContext).SetFailedReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
 			klog.Errorf("Failed to mark object as failed reconcile: %v", err)
@@ -306,6 +379,51 @@ func (w *WaitTask) handleChangedUID(taskContext *TaskContext, id object.ObjMetad
 	}
 }
 
+// reconcileStatuses returns where the reconcile status of the resources is
+// recorded.
+func (w *WaitTask) reconcileStatuses(taskContext *TaskContext) reconcileStatusRecorder {
+	if w.External {
+		return externalReconcileStatuses{taskContext: taskContext}
+	}
+	return taskContext.InventoryManager()
+}
+
+// reconcileStatusRecorder records the reconcile status of the resources that
+// a WaitTask is waiting for.
+type reconcileStatusRecorder interface {
+	SetPendingReconcile(object.ObjMetadata) error
+	SetSuccessfulReconcile(object.ObjMetadata) error
+	SetFailedReconcile(object.ObjMetadata) error
+	SetSkippedReconcile(object.ObjMetadata) error
+	SetTimeoutReconcile(object.ObjMetadata) error
+}
+
+// externalReconcileStatuses records the reconcile status of external
+// dependencies in the TaskContext.
+type externalReconcileStatuses struct {
+	taskContext *TaskContext
+}
+
+func (e externalReconcileStatuses) SetPendingReconcile(id object.ObjMetadata) error {
+	return e.taskContext.SetExternalReconcileStatus(id, actuation.ReconcilePending)
+}
+
+func (e externalReconcileStatuses) SetSuccessfulReconcile(id object.ObjMetadata) error {
+	return e.taskContext.SetExternalReconcileStatus(id, actuation.ReconcileSucceeded)
+}
+
+func (e externalReconcileStatuses) SetFailedReconcile(id object.ObjMetadata) error {
+	return e.taskContext.SetExternalReconcileStatus(id, actuation.ReconcileFailed)
+}
+
+func (e externalReconcileStatuses) SetSkippedReconcile(id object.ObjMetadata) error {
+	return e.taskContext.SetExternalReconcileStatus(id, actuation.ReconcileSkipped)
+}
+
+func (e externalReconcileStatuses) SetTimeoutReconcile(id object.ObjMetadata) error {
+	return e.taskContext.SetExternalReconcileStatus(id, actuation.ReconcileTimeout)
+}
+
 // Cancel exits early with a timeout error
 func (w *WaitTask) Cancel(_ *TaskContext) {
 	w.cancelFunc()
@@ -328,11 +446,17 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 		switch {
 		case w.changedUID(taskContext, id):
 			// replaced
+			w.cancelStabilizing(id)
 			w.handleChangedUID(taskContext, id)
 			w.pending = w.pending.Remove(id)
+		case w.reconciledByID(taskContext, id) && w.stabilityWindow(id) > 0:
+			// reconciled - wait for the stability window to pass
+			if _, found := w.stabilizing[id]; !found {
+				w.startStabilizing(taskContext, id)
+			}
 		case w.reconciledByID(taskContext, id):
 			// reconciled - remove from pending & send event
-			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
+			err := w.reconcileStatuses(taskContext).SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
 				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
@@ -341,7 +465,8 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			w.sendEvent(taskContext, id, event.ReconcileSuccessful)
 		case w.failedByID(taskContext, id):
 			// failed - remove from pending & send event
-			err := taskContext.InventoryManager().SetFailedReconcile(id)
+			w.cancelStabilizing(id)
+			err := w.reconcileStatuses(taskContext).SetFailedReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
 				klog.Errorf("Failed to mark object as failed reconcile: %v", err)
@@ -349,7 +474,11 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			w.pending = w.pending.Remove(id)
 			w.failed = append(w.failed, id)
 			w.sendEvent(taskContext, id, event.ReconcileFailed)
-			// default - still pending
+		default:
+			// still pending - restart the stability window, if started
+			if w.cancelStabilizing(id) {
+				w.sendEvent(taskContext, id, event.ReconcilePending)
+			}
 		}
 	case !w.IDs.Contains(id):
 		// not in wait group - ignore
@@ -357,13 +486,26 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 	case w.skipped(taskContext, id):
 		// skipped - ignore
 		return
+	case w.timedOut.Contains(id):
+		// timed out - ignore
+		return
 	case w.failed.Contains(id):
 		// If a failed resource becomes current before other
 		// resources have completed/timed out, we consider it
 		// current.
-		if w.reconciledByID(taskContext, id) {
+		if w.reconciledByID(taskContext, id) && w.stabilityWindow(id) > 0 {
+			// reconciled - add to pending until the stability window passes
+			err := w.reconcileStatuses(taskContext).SetPendingReconcile(id)
+			if err != nil {
+				// Object never applied or deleted!
+				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+			}
+			w.failed = w.failed.Remove(id)
+			w.pending = append(w.pending, id)
+			w.startStabilizing(taskContext, id)
+		} else if w.reconciledByID(taskContext, id) {
 			// reconciled - remove from pending & send event
-			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
+			err := w.reconcileStatuses(taskContext).SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
 				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
@@ -373,7 +515,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 		} else if !w.failedByID(taskContext, id) {
 			// If a resource is no longer reported as Failed and is not Reconciled,
 			// they should just go back to InProgress.
-			err := taskContext.InventoryManager().SetPendingReconcile(id)
+			err := w.reconcileStatuses(taskContext).SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
 				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
@@ -387,7 +529,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 		// reconciled - check if unreconciled
 		if !w.reconciledByID(taskContext, id) {
 			// unreconciled - add to pending & send event
-			err := taskContext.InventoryManager().SetPendingReconcile(id)
+			err := w.reconcileStatuses(taskContext).SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
 				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
@@ -409,6 +551,218 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 	}
 }
 
+// stabilityWindow returns how long the object must remain reconciled before
+// the condition is considered met.
+func (w *WaitTask) stabilityWindow(id object.ObjMetadata) time.Duration {
+	if w.Condition != AllCurrent {
+		return 0
+	}
+	if window, found := w.StabilityWindows[id]; found {
+		return window
+	}
+	return w.StabilityWindow
+}
+
+// startStabilizing starts the stability window of a reconciled object and
+// sends an event with the time remaining.
+// The pending set must be write locked by the caller.
+func (w *WaitTask) startStabilizing(taskContext *TaskContext, id object.ObjMetadata) {
+	if w.done {
+		// task completing - stay pending
+		return
+	}
+	if w.stabilizing == nil {
+		w.stabilizing = make(map[object.ObjMetadata]time.Time)
+		w.timers = make(map[object.ObjMetadata]*time.Timer)
+	}
+	w.stabilizing[id] = time.Now()
+	w.scheduleStabilityCheck(taskContext, id, w.stabilityWindow(id))
+}
+
+// scheduleStabilityCheck sends an event with the time remaining in the
+// stability window of an object, and schedules the next check.
+// The pending set must be write locked by the caller.
+func (w *WaitTask) scheduleStabilityCheck(taskContext *TaskContext, id object.ObjMetadata, remaining time.Duration) {
+	if remaining > time.Second {
+		remaining = remaining.Round(time.Second)
+	}
+	klog.V(3).Infof("object reconciled, waiting for stability window (object: %q, remaining: %s)", id, remaining)
+	taskContext.SendEvent(event.Event{
+		Type: event.WaitType,
+		WaitEvent: event.WaitEvent{
+			GroupName:  w.Name(),
+			Identifier: id,
+			Status:     event.ReconcileStabilizing,
+			Remaining:  remaining,
+		},
+	})
+	w.timers[id] = time.AfterFunc(min(remaining, stabilityInterval), func() {
+		w.checkStability(taskContext, id)
+	})
+}
+
+// checkStability marks the object as reconciled if it has remained reconciled
+// for its whole stability window. Otherwise, it schedules the next check.
+// If all objects are reconciled or skipped, cancelFunc is called.
+// The pending set is write locked during execution of checkStability.
+func (w *WaitTask) checkStability(taskContext *TaskContext, id object.ObjMetadata) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	since, found := w.stabilizing[id]
+	if w.done || !found {
+		// task completed, or object no longer reconciled
+		return
+	}
+	if !w.reconciledByID(taskContext, id) {
+		// unreconciled - restart the stability window on the next update
+		w.cancelStabilizing(id)
+		w.sendEvent(taskContext, id, event.ReconcilePending)
+		return
+	}
+	remaining := w.stabilityWindow(id) - time.Since(since)
+	if remaining > 0 {
+		w.scheduleStabilityCheck(taskContext, id, remaining)
+		return
+	}
+
+	// stable - remove from pending & send event
+	w.cancelStabilizing(id)
+	err := w.reconcileStatuses(taskContext).SetSuccessfulReconcile(id)
+	if err != nil {
+		// Object never applied or deleted!
+		klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+	}
+	w.pending = w.pending.Remove(id)
+	w.sendEvent(taskContext, id, event.ReconcileSuccessful)
+
+	klog.V(3).Infof("wait task progress: %d/%d", len(w.IDs)-len(w.pending), len(w.IDs))
+
+	if len(w.pending) == 0 {
+		// all reconciled, so exit
+		klog.V(3).Infof("all objects reconciled or skipped (name: %q)", w.TaskName)
+		w.cancelFunc()
+	}
+}
+
+// cancelStabilizing stops the stability window of an object.
+// Returns true if the object was stabilizing.
+// The pending set must be write locked by the caller.
+func (w *WaitTask) cancelStabilizing(id object.ObjMetadata) bool {
+	if _, found := w.stabilizing[id]; !found {
+		return false
+	}
+	w.timers[id].Stop()
+	delete(w.timers, id)
+	delete(w.stabilizing, id)
+	return true
+}
+
+// stopTimers stops the stability windows and timeouts of all objects, once
+// the task is completing. Objects that are still stabilizing remain pending.
+// The pending set is write locked during execution of stopTimers.
+func (w *WaitTask) stopTimers() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.done = true
+	for _, timer := range w.timers {
+		timer.Stop()
+	}
+	w.timers = nil
+	w.stabilizing = nil
+	for _, timer := range w.timeoutTimers {
+		timer.Stop()
+	}
+	w.timeoutTimers = nil
+}
+
+// timeout returns how long to wait for the object to meet the condition.
+// Zero waits indefinitely.
+func (w *WaitTask) timeout(id object.ObjMetadata) time.Duration {
+	if timeout, found := w.Timeouts[id]; found {
+		return timeout
+	}
+	return w.Timeout
+}
+
+// taskTimeout returns the longest timeout of all the objects. Zero waits
+// indefinitely.
+func (w *WaitTask) taskTimeout() time.Duration {
+	if len(w.Timeouts) == 0 {
+		return w.Timeout
+	}
+	var taskTimeout time.Duration
+	for _, id := range w.IDs {
+		timeout := w.timeout(id)
+		if timeout <= 0 {
+			return 0
+		}
+		taskTimeout = max(taskTimeout, timeout)
+	}
+	return taskTimeout
+}
+
+// startTimeoutTimers starts a timer for each pending object that has a
+// shorter timeout than the task.
+// The pending set is write locked during execution of startTimeoutTimers.
+func (w *WaitTask) startTimeoutTimers(taskContext *TaskContext, taskTimeout time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, id := range w.IDs {
+		timeout := w.timeout(id)
+		if timeout <= 0 || (taskTimeout > 0 && timeout >= taskTimeout) {
+			continue
+		}
+		w.timeoutTimers = append(w.timeoutTimers, time.AfterFunc(timeout, func() {
+			w.handleObjectTimeout(taskContext, id)
+		}))
+	}
+}
+
+// handleObjectTimeout sends a timeout event if the object is still pending
+// when its timeout expires.
+// If all other objects are reconciled or skipped, cancelFunc is called.
+// The pending set is write locked during execution of handleObjectTimeout.
+func (w *WaitTask) handleObjectTimeout(taskContext *TaskContext, id object.ObjMetadata) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.done {
+		// task completed
+		return
+	}
+	if w.failed.Contains(id) {
+		// failed - keep the failure, but stop waiting for recovery
+		w.failed = w.failed.Remove(id)
+		w.timedOut = append(w.timedOut, id)
+		return
+	}
+	if !w.pending.Contains(id) {
+		// reconciled or skipped
+		return
+	}
+	klog.V(3).Infof("object timed out (object: %q, timeout: %s)", id, w.timeout(id))
+	w.cancelStabilizing(id)
+	err := w.reconcileStatuses(taskContext).SetTimeoutReconcile(id)
+	if err != nil {
+		// Object never applied or deleted!
+		klog.Errorf("Failed to mark object as timeout reconcile: %v", err)
+	}
+	w.pending = w.pending.Remove(id)
+	w.timedOut = append(w.timedOut, id)
+	w.sendEvent(taskContext, id, event.ReconcileTimeout)
+
+	klog.V(3).Infof("wait task progress: %d/%d", len(w.IDs)-len(w.pending), len(w.IDs))
+
+	if len(w.pending) == 0 {
+		// all reconciled or timed out, so exit
+		klog.V(3).Infof("all objects reconciled, skipped, or timed out (name: %q)", w.TaskName)
+		w.cancelFunc()
+	}
+}
+
 // updateRESTMapper resets the RESTMapper if CRDs were applied, so that new
 // resource types can be applied by subsequent tasks.
 // TODO: find a way to add/remove mappers without resetting the entire mapper
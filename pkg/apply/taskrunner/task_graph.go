@@ -0,0 +1,129 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package taskrunner
+
+import (
+	"fmt"
+)
+
+// TaskGraph is a directed acyclic graph of tasks. A task may only be started
+// after all of its dependencies have completed, which allows tasks that do
+// not depend on each other to be executed concurrently.
+type TaskGraph struct {
+	// tasks is the list of tasks in the order they were added, which is
+	// always a topological ordering of the graph.
+	tasks []Task
+	// dependencies maps each task to the tasks that must complete first.
+	dependencies map[Task][]Task
+}
+
+// NewTaskGraph returns an empty TaskGraph.
+func NewTaskGraph() *TaskGraph {
+	return &TaskGraph{
+		dependencies: make(map[Task][]Task),
+	}
+}
+
+// AddTask adds a task to the graph, which will only be started after all the
+// specified dependencies have completed. Dependencies must have been added to
+// the graph before the tasks that depend on them, which guarantees that the
+// graph is acyclic.
+func (g *TaskGraph) AddTask(t Task, dependencies ...Task) {
+	if _, found := g.dependencies[t]; found {
+		panic(fmt.Sprintf("task already in graph: %q", t.Name()))
+	}
+	for _, dep := range dependencies {
+		if _, found := g.dependencies[dep]; !found {
+			panic(fmt.Sprintf("task %q depends on unknown task: %q", t.Name(), dep.Name()))
+		}
+	}
+	g.tasks = append(g.tasks, t)
+	g.dependencies[t] = dependencies
+}
+
+// Tasks returns all the tasks in the graph, in the order they were added.
+func (g *TaskGraph) Tasks() []Task {
+	return g.tasks
+}
+
+// Dependencies returns the tasks that must complete before the specified
+// task can be started.
+func (g *TaskGraph) Dependencies(t Task) []Task {
+	return g.dependencies[t]
+}
+
+// scheduler decides which tasks the runner should start next.
+type scheduler interface {
+	// next returns the tasks that are ready to be started, given the number
+	// of tasks that are currently running. Returned tasks are considered
+	// started.
+	next(running int) []Task
+	// complete records that the task has completed.
+	complete(Task)
+}
+
+// queueScheduler schedules tasks from a channel, one at a time.
+type queueScheduler struct {
+	taskQueue chan Task
+}
+
+func (s *queueScheduler) next(running int) []Task {
+	if running > 0 {
+		return nil
+	}
+	select {
+	// If there is any tasks left in the queue, this
+	// case statement will be executed.
+	case t := <-s.taskQueue:
+		return []Task{t}
+	default:
+		// Only happens when the channel is empty.
+		return nil
+	}
+}
+
+func (s *queueScheduler) complete(Task) {}
+
+// graphScheduler schedules tasks from a TaskGraph, starting every task whose
+// dependencies have all completed.
+type graphScheduler struct {
+	graph     *TaskGraph
+	started   map[Task]struct{}
+	completed map[Task]struct{}
+}
+
+func newGraphScheduler(g *TaskGraph) *graphScheduler {
+	return &graphScheduler{
+		graph:     g,
+		started:   make(map[Task]struct{}),
+		completed: make(map[Task]struct{}),
+	}
+}
+
+func (s *graphScheduler) next(_ int) []Task {
+	var ready []Task
+	for _, t := range s.graph.Tasks() {
+		if _, found := s.started[t]; found {
+			continue
+		}
+		if s.dependenciesCompleted(t) {
+			s.started[t] = struct{}{}
+			ready = append(ready, t)
+		}
+	}
+	return ready
+}
+
+func (s *graphScheduler) dependenciesCompleted(t Task) bool {
+	for _, dep := range s.graph.Dependencies(t) {
+		if _, found := s.completed[dep]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *graphScheduler) complete(t Task) {
+	s.completed[t] = struct{}{}
+}
@@ -421,6 +421,104 @@ loop:
 	testutil.AssertEqual(t, expectedInventory, taskContext.InventoryManager().Inventory())
 }
 
+func TestWaitTask_External(t *testing.T) {
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment1 := testutil.Unstructured(t, testDeployment1YAML)
+	testDeployment2ID := testutil.ToIdentifier(t, testDeployment2YAML)
+	ids := object.ObjMetadataSet{
+		testDeployment1ID,
+		testDeployment2ID,
+	}
+	waitTimeout := 2 * time.Second
+	taskName := "wait-0"
+	task := NewWaitTask(taskName, ids, AllCurrent,
+		waitTimeout, testutil.NewFakeRESTMapper())
+	task.External = true
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(t.Context(), eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	// external dependencies are registered, but not applied
+	taskContext.AddExternalObject(testDeployment1ID)
+	taskContext.AddExternalObject(testDeployment2ID)
+
+	// deployment1 already exists and is Current
+	resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+		Resource: testDeployment1,
+		Status:   status.CurrentStatus,
+	})
+	// deployment2 does not exist
+	resourceCache.Put(testDeployment2ID, cache.ResourceStatus{
+		Status: status.NotFoundStatus,
+	})
+
+	// run task async, to let the test collect events
+	go func() {
+		task.Start(taskContext)
+	}()
+
+	// wait for task result
+	timer := time.NewTimer(5 * time.Second)
+	receivedEvents := []event.Event{}
+loop:
+	for {
+		select {
+		case e := <-taskContext.EventChannel():
+			receivedEvents = append(receivedEvents, e)
+		case res := <-taskContext.TaskChannel():
+			timer.Stop()
+			assert.NoError(t, res.Err)
+			break loop
+		case <-timer.C:
+			t.Fatalf("timed out waiting for TaskResult")
+		}
+	}
+
+	expectedEvents := []event.Event{
+		// deployment1 current
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcileSuccessful,
+			},
+		},
+		// deployment2 pending
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		// deployment2 timeout
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcileTimeout,
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedEvents, receivedEvents,
+		"Actual events (%d) do not match expected events (%d)",
+		len(receivedEvents), len(expectedEvents))
+
+	// The reconcile status is recorded in the TaskContext, not the inventory
+	reconcileStatus, found := taskContext.ExternalReconcileStatus(testDeployment1ID)
+	assert.True(t, found)
+	assert.Equal(t, actuation.ReconcileSucceeded, reconcileStatus)
+	reconcileStatus, found = taskContext.ExternalReconcileStatus(testDeployment2ID)
+	assert.True(t, found)
+	assert.Equal(t, actuation.ReconcileTimeout, reconcileStatus)
+	testutil.AssertEqual(t, inventory.InventoryContents{}, taskContext.InventoryManager().Inventory())
+}
+
 func TestWaitTask_StartAndComplete(t *testing.T) {
 	testDeploymentID := testutil.ToIdentifier(t, testDeployment1YAML)
 	testDeployment := testutil.Unstructured(t, testDeployment1YAML)
@@ -504,6 +602,429 @@ loop:
 	testutil.AssertEqual(t, expectedInventory, taskContext.InventoryManager().Inventory())
 }
 
+func TestWaitTask_StabilityWindow(t *testing.T) {
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment1 := testutil.Unstructured(t, testDeployment1YAML)
+	testDeployment2ID := testutil.ToIdentifier(t, testDeployment2YAML)
+	testDeployment2 := testutil.Unstructured(t, testDeployment2YAML)
+	ids := object.ObjMetadataSet{
+		testDeployment1ID,
+		testDeployment2ID,
+	}
+	waitTimeout := 2 * time.Second
+	stabilityWindow := 200 * time.Millisecond
+	taskName := "wait-stability"
+	task := NewWaitTask(taskName, ids, AllCurrent,
+		waitTimeout, testutil.NewFakeRESTMapper())
+	task.StabilityWindow = stabilityWindow
+	// deployment2 overrides the stability window
+	task.StabilityWindows = map[object.ObjMetadata]time.Duration{
+		testDeployment2ID: 0,
+	}
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(t.Context(), eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	// Update metadata on successfully applied objects
+	testDeployment1.SetUID("a")
+	testDeployment1.SetGeneration(1)
+	testDeployment2.SetUID("b")
+	testDeployment2.SetGeneration(1)
+
+	// mark deployment 1 & 2 as apply succeeded
+	taskContext.InventoryManager().AddSuccessfulApply(testDeployment1ID,
+		testDeployment1.GetUID(), testDeployment1.GetGeneration())
+	taskContext.InventoryManager().AddSuccessfulApply(testDeployment2ID,
+		testDeployment2.GetUID(), testDeployment2.GetGeneration())
+
+	// run task async, to let the test collect events
+	go func() {
+		// start the task
+		task.Start(taskContext)
+
+		// mark deployment2 as Current
+		resourceCache.Put(testDeployment2ID, cache.ResourceStatus{
+			Resource: testDeployment2,
+			Status:   status.CurrentStatus,
+		})
+		// tell the WaitTask deployment2 has new status
+		task.StatusUpdate(taskContext, testDeployment2ID)
+
+		// mark deployment1 as Current
+		resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+			Resource: testDeployment1,
+			Status:   status.CurrentStatus,
+		})
+		// tell the WaitTask deployment1 has new status
+		task.StatusUpdate(taskContext, testDeployment1ID)
+
+		// mark deployment1 as InProgress, before the stability window passes
+		resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+			Resource: testDeployment1,
+			Status:   status.InProgressStatus,
+		})
+		// tell the WaitTask deployment1 has new status
+		task.StatusUpdate(taskContext, testDeployment1ID)
+
+		// mark deployment1 as Current again
+		resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+			Resource: testDeployment1,
+			Status:   status.CurrentStatus,
+		})
+		// tell the WaitTask deployment1 has new status
+		task.StatusUpdate(taskContext, testDeployment1ID)
+	}()
+
+	// wait for task result
+	timer := time.NewTimer(5 * time.Second)
+	receivedEvents := []event.Event{}
+loop:
+	for {
+		select {
+		case e := <-taskContext.EventChannel():
+			receivedEvents = append(receivedEvents, e)
+		case res := <-taskContext.TaskChannel():
+			timer.Stop()
+			assert.NoError(t, res.Err)
+			break loop
+		case <-timer.C:
+			t.Fatalf("timed out waiting for TaskResult")
+		}
+	}
+
+	expectedEvents := []event.Event{
+		// deployment1 pending
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		// deployment2 pending
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		// deployment2 current (no stability window)
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcileSuccessful,
+			},
+		},
+		// deployment1 current, stability window started
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcileStabilizing,
+				Remaining:  stabilityWindow,
+			},
+		},
+		// deployment1 in progress, stability window cancelled
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		// deployment1 current, stability window restarted
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcileStabilizing,
+				Remaining:  stabilityWindow,
+			},
+		},
+		// deployment1 stable
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcileSuccessful,
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedEvents, receivedEvents,
+		"Actual events (%d) do not match expected events (%d)",
+		len(receivedEvents), len(expectedEvents))
+
+	expectedInventory := inventory.InventoryContents{
+		ObjectStatuses: object.ObjectStatusSet{
+			{
+				ObjectReference: inventory.ObjectReferenceFromObjMetadata(testDeployment1ID),
+				Strategy:        actuation.ActuationStrategyApply,
+				Actuation:       actuation.ActuationSucceeded,
+				Reconcile:       actuation.ReconcileSucceeded,
+				UID:             testDeployment1.GetUID(),
+				Generation:      testDeployment1.GetGeneration(),
+			},
+			{
+				ObjectReference: inventory.ObjectReferenceFromObjMetadata(testDeployment2ID),
+				Strategy:        actuation.ActuationStrategyApply,
+				Actuation:       actuation.ActuationSucceeded,
+				Reconcile:       actuation.ReconcileSucceeded,
+				UID:             testDeployment2.GetUID(),
+				Generation:      testDeployment2.GetGeneration(),
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedInventory, taskContext.InventoryManager().Inventory())
+}
+
+func TestWaitTask_StabilityWindowTimeout(t *testing.T) {
+	testDeploymentID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment := testutil.Unstructured(t, testDeployment1YAML)
+	ids := object.ObjMetadataSet{
+		testDeploymentID,
+	}
+	waitTimeout := 200 * time.Millisecond
+	taskName := "wait-stability-timeout"
+	task := NewWaitTask(taskName, ids, AllCurrent,
+		waitTimeout, testutil.NewFakeRESTMapper())
+	// the stability window is longer than the timeout
+	task.StabilityWindow = time.Minute
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(t.Context(), eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	// Update metadata on successfully applied objects
+	testDeployment.SetUID("a")
+	testDeployment.SetGeneration(1)
+
+	// mark deployment as apply succeeded
+	taskContext.InventoryManager().AddSuccessfulApply(testDeploymentID,
+		testDeployment.GetUID(), testDeployment.GetGeneration())
+
+	// mark the deployment as Current before starting
+	resourceCache.Put(testDeploymentID, cache.ResourceStatus{
+		Resource: testDeployment,
+		Status:   status.CurrentStatus,
+	})
+
+	// run task async, to let the test collect events
+	go func() {
+		// start the task
+		task.Start(taskContext)
+	}()
+
+	// wait for task result
+	timer := time.NewTimer(5 * time.Second)
+	receivedEvents := []event.Event{}
+loop:
+	for {
+		select {
+		case e := <-taskContext.EventChannel():
+			receivedEvents = append(receivedEvents, e)
+		case res := <-taskContext.TaskChannel():
+			timer.Stop()
+			assert.NoError(t, res.Err)
+			break loop
+		case <-timer.C:
+			t.Fatalf("timed out waiting for TaskResult")
+		}
+	}
+
+	expectedEvents := []event.Event{
+		// deployment current before start, stability window started
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeploymentID,
+				Status:     event.ReconcileStabilizing,
+				Remaining:  time.Minute,
+			},
+		},
+		// deployment timeout
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeploymentID,
+				Status:     event.ReconcileTimeout,
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedEvents, receivedEvents,
+		"Actual events (%d) do not match expected events (%d)",
+		len(receivedEvents), len(expectedEvents))
+
+	expectedInventory := inventory.InventoryContents{
+		ObjectStatuses: object.ObjectStatusSet{
+			{
+				ObjectReference: inventory.ObjectReferenceFromObjMetadata(testDeploymentID),
+				Strategy:        actuation.ActuationStrategyApply,
+				Actuation:       actuation.ActuationSucceeded,
+				Reconcile:       actuation.ReconcileTimeout,
+				UID:             testDeployment.GetUID(),
+				Generation:      testDeployment.GetGeneration(),
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedInventory, taskContext.InventoryManager().Inventory())
+}
+
+func TestWaitTask_ObjectTimeout(t *testing.T) {
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment1 := testutil.Unstructured(t, testDeployment1YAML)
+	testDeployment2ID := testutil.ToIdentifier(t, testDeployment2YAML)
+	testDeployment2 := testutil.Unstructured(t, testDeployment2YAML)
+	ids := object.ObjMetadataSet{
+		testDeployment1ID,
+		testDeployment2ID,
+	}
+	waitTimeout := 2 * time.Second
+	taskName := "wait-object-timeout"
+	task := NewWaitTask(taskName, ids, AllCurrent,
+		waitTimeout, testutil.NewFakeRESTMapper())
+	// deployment1 times out before the task
+	task.Timeouts = map[object.ObjMetadata]time.Duration{
+		testDeployment1ID: 100 * time.Millisecond,
+	}
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(t.Context(), eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	// Update metadata on successfully applied objects
+	testDeployment1.SetUID("a")
+	testDeployment1.SetGeneration(1)
+	testDeployment2.SetUID("b")
+	testDeployment2.SetGeneration(1)
+
+	// mark deployment 1 & 2 as apply succeeded
+	taskContext.InventoryManager().AddSuccessfulApply(testDeployment1ID,
+		testDeployment1.GetUID(), testDeployment1.GetGeneration())
+	taskContext.InventoryManager().AddSuccessfulApply(testDeployment2ID,
+		testDeployment2.GetUID(), testDeployment2.GetGeneration())
+
+	// run task async, to let the test collect events
+	go func() {
+		// start the task
+		task.Start(taskContext)
+
+		// wait for deployment1 to time out
+		time.Sleep(300 * time.Millisecond)
+
+		// mark deployment1 as Current, after it timed out
+		resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+			Resource: testDeployment1,
+			Status:   status.CurrentStatus,
+		})
+		// tell the WaitTask deployment1 has new status
+		task.StatusUpdate(taskContext, testDeployment1ID)
+
+		// mark deployment2 as Current
+		resourceCache.Put(testDeployment2ID, cache.ResourceStatus{
+			Resource: testDeployment2,
+			Status:   status.CurrentStatus,
+		})
+		// tell the WaitTask deployment2 has new status
+		task.StatusUpdate(taskContext, testDeployment2ID)
+	}()
+
+	// wait for task result
+	timer := time.NewTimer(5 * time.Second)
+	receivedEvents := []event.Event{}
+loop:
+	for {
+		select {
+		case e := <-taskContext.EventChannel():
+			receivedEvents = append(receivedEvents, e)
+		case res := <-taskContext.TaskChannel():
+			timer.Stop()
+			assert.NoError(t, res.Err)
+			break loop
+		case <-timer.C:
+			t.Fatalf("timed out waiting for TaskResult")
+		}
+	}
+
+	expectedEvents := []event.Event{
+		// deployment1 pending
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		// deployment2 pending
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		// deployment1 timeout (later status updates are ignored)
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcileTimeout,
+			},
+		},
+		// deployment2 current
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcileSuccessful,
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedEvents, receivedEvents,
+		"Actual events (%d) do not match expected events (%d)",
+		len(receivedEvents), len(expectedEvents))
+
+	expectedInventory := inventory.InventoryContents{
+		ObjectStatuses: object.ObjectStatusSet{
+			{
+				ObjectReference: inventory.ObjectReferenceFromObjMetadata(testDeployment1ID),
+				Strategy:        actuation.ActuationStrategyApply,
+				Actuation:       actuation.ActuationSucceeded,
+				Reconcile:       actuation.ReconcileTimeout,
+				UID:             testDeployment1.GetUID(),
+				Generation:      testDeployment1.GetGeneration(),
+			},
+			{
+				ObjectReference: inventory.ObjectReferenceFromObjMetadata(testDeployment2ID),
+				Strategy:        actuation.ActuationStrategyApply,
+				Actuation:       actuation.ActuationSucceeded,
+				Reconcile:       actuation.ReconcileSucceeded,
+				UID:             testDeployment2.GetUID(),
+				Generation:      testDeployment2.GetGeneration(),
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedInventory, taskContext.InventoryManager().Inventory())
+}
+
 func TestWaitTask_Cancel(t *testing.T) {
 	testDeploymentID := testutil.ToIdentifier(t, testDeployment1YAML)
 	testDeployment := testutil.Unstructured(t, testDeployment1YAML)
@@ -16,7 +16,10 @@ import (
 	"k8s.io/klog/v2"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/inventory/configmap"
+	"sigs.k8s.io/cli-utils/pkg/inventory/resourcegroup"
+	"sigs.k8s.io/cli-utils/pkg/inventory/secret"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/filters"
 	"sigs.k8s.io/kustomize/kyaml/openapi"
@@ -40,6 +43,11 @@ type InitOptions struct {
 	Namespace string
 	// Inventory object label value; must be a valid k8s label value.
 	InventoryID string
+	// Inventory object storage type; selects the Template if set.
+	InventoryType inventory.StorageType
+	// PrintCRD prints the ResourceGroup CRD manifest instead of creating
+	// the inventory object template.
+	PrintCRD bool
 }
 
 func NewInitOptions(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *InitOptions {
@@ -54,6 +62,12 @@ func NewInitOptions(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *In
 // TODO(seans3): Look into changing this kubectl-inspired way of organizing
 // the InitOptions (e.g. Complete and Run methods).
 func (i *InitOptions) Complete(args []string) error {
+	if i.PrintCRD {
+		if len(args) != 0 {
+			return fmt.Errorf("no 'directory' arg allowed with --print-crd; have %d", len(args))
+		}
+		return nil
+	}
 	if len(args) != 1 {
 		return fmt.Errorf("need one 'directory' arg; have %d", len(args))
 	}
@@ -64,6 +78,14 @@ func (i *InitOptions) Complete(args []string) error {
 	i.Dir = dir
 	klog.V(4).Infof("init directory: %s", i.Dir)
 
+	if len(i.InventoryType) > 0 {
+		template, err := templateForType(i.InventoryType)
+		if err != nil {
+			return err
+		}
+		i.Template = template
+	}
+
 	ns, err := FindNamespace(i.factory.ToRawKubeConfigLoader(), i.Dir)
 	if err != nil {
 		return err
@@ -86,6 +108,22 @@ func (i *InitOptions) Complete(args []string) error {
 	return nil
 }
 
+// templateForType returns the inventory object template for the passed
+// inventory storage type.
+func templateForType(invType inventory.StorageType) (string, error) {
+	switch invType {
+	case inventory.ConfigMapStorage:
+		return configmap.ConfigMapTemplate, nil
+	case inventory.SecretStorage, inventory.ApplySetStorage:
+		return secret.SecretTemplate, nil
+	case inventory.ResourceGroupStorage:
+		return resourcegroup.ResourceGroupTemplate, nil
+	default:
+		return "", fmt.Errorf("invalid inventory type %q, must be one of %v",
+			invType, inventory.StorageTypes())
+	}
+}
+
 type namespaceLoader interface {
 	Namespace() (string, bool, error)
 }
@@ -231,6 +269,10 @@ func (i *InitOptions) fillInValues() string {
 }
 
 func (i *InitOptions) Run() error {
+	if i.PrintCRD {
+		_, err := fmt.Fprint(i.ioStreams.Out, resourcegroup.ResourceGroupCRD)
+		return err
+	}
 	manifestFilePath := filepath.Join(i.Dir, manifestFilename)
 	if fileExists(manifestFilePath) {
 		return fmt.Errorf("inventory object template file already exists: %s", manifestFilePath)
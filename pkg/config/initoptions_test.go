@@ -14,6 +14,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/inventory/resourcegroup"
 )
 
 // writeFile writes a file under the test directory
@@ -252,6 +254,23 @@ func (f *fakeNamespaceLoader) Namespace() (string, bool, error) {
 	return f.namespace, f.enforceNamespace, nil
 }
 
+func TestPrintCRD(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("foo")
+	defer tf.Cleanup()
+	ioStreams, _, out, _ := genericiooptions.NewTestIOStreams() // nolint:dogsled
+	io := NewInitOptions(tf, ioStreams)
+	io.PrintCRD = true
+
+	err := io.Complete([]string{"foo"})
+	assert.EqualError(t, err, "no 'directory' arg allowed with --print-crd; have 1")
+
+	err = io.Complete(nil)
+	assert.NoError(t, err)
+	err = io.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, resourcegroup.ResourceGroupCRD, out.String())
+}
+
 func TestDefaultInventoryID(t *testing.T) {
 	tf := cmdtesting.NewTestFactory().WithNamespace("foo")
 	defer tf.Cleanup()
@@ -332,12 +351,33 @@ func TestValidateInventoryID(t *testing.T) {
 
 func TestFillInValues(t *testing.T) {
 	tests := map[string]struct {
-		namespace   string
-		inventoryID string
+		namespace     string
+		inventoryID   string
+		inventoryType inventory.StorageType
+		expectedKind  string
 	}{
 		"Basic namespace/inventoryID": {
-			namespace:   "foo",
-			inventoryID: "bar",
+			namespace:    "foo",
+			inventoryID:  "bar",
+			expectedKind: "ConfigMap",
+		},
+		"ConfigMap inventory type": {
+			namespace:     "foo",
+			inventoryID:   "bar",
+			inventoryType: inventory.ConfigMapStorage,
+			expectedKind:  "ConfigMap",
+		},
+		"Secret inventory type": {
+			namespace:     "foo",
+			inventoryID:   "bar",
+			inventoryType: inventory.SecretStorage,
+			expectedKind:  "Secret",
+		},
+		"ResourceGroup inventory type": {
+			namespace:     "foo",
+			inventoryID:   "bar",
+			inventoryType: inventory.ResourceGroupStorage,
+			expectedKind:  "ResourceGroup",
 		},
 	}
 
@@ -349,6 +389,13 @@ func TestFillInValues(t *testing.T) {
 			io := NewInitOptions(tf, ioStreams)
 			io.Namespace = tc.namespace
 			io.InventoryID = tc.inventoryID
+			if tc.inventoryType != "" {
+				template, err := templateForType(tc.inventoryType)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				io.Template = template
+			}
 			actual := io.fillInValues()
 			expectedLabel := fmt.Sprintf("cli-utils.sigs.k8s.io/inventory-id: %s", tc.inventoryID)
 			if !strings.Contains(actual, expectedLabel) {
@@ -365,8 +412,9 @@ func TestFillInValues(t *testing.T) {
 			if !matched {
 				t.Errorf("expected inventory name (e.g. inventory-12345678), got (%s)", actual)
 			}
-			if !strings.Contains(actual, "kind: ConfigMap") {
-				t.Errorf("\nExpected `kind: ConfigMap` not found in inventory object: %s\n", actual)
+			expectedKind := fmt.Sprintf("kind: %s", tc.expectedKind)
+			if !strings.Contains(actual, expectedKind) {
+				t.Errorf("\nExpected `%s` not found in inventory object: %s\n", expectedKind, actual)
 			}
 		})
 	}
@@ -0,0 +1,366 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// ApplySet (KEP-3659) labels and annotations, as used by
+// `kubectl apply --prune --applyset`.
+const (
+	// ApplySetParentIDLabel is the label on the ApplySet parent object,
+	// whose value is the ApplySet ID.
+	ApplySetParentIDLabel = "applyset.kubernetes.io/id"
+	// ApplySetPartOfLabel is the label on the ApplySet members, whose value
+	// is the ApplySet ID.
+	ApplySetPartOfLabel = "applyset.kubernetes.io/part-of"
+	// ApplySetToolingAnnotation is the annotation on the ApplySet parent
+	// object, which identifies the tool managing the ApplySet.
+	ApplySetToolingAnnotation = "applyset.kubernetes.io/tooling"
+	// ApplySetGKsAnnotation is the annotation on the ApplySet parent object,
+	// which lists the group kinds of the members.
+	ApplySetGKsAnnotation = "applyset.kubernetes.io/contains-group-kinds"
+	// ApplySetAdditionalNamespacesAnnotation is the annotation on the
+	// ApplySet parent object, which lists the namespaces of the members,
+	// other than the namespace of the parent object.
+	ApplySetAdditionalNamespacesAnnotation = "applyset.kubernetes.io/additional-namespaces"
+)
+
+// validateApplySetTooling returns an error if the tooling annotation value
+// is not in the form "<name>/<version>".
+func validateApplySetTooling(tooling string) error {
+	if tooling == "" {
+		return errors.New("ApplySet tooling is required")
+	}
+	name, version, found := strings.Cut(tooling, "/")
+	if !found || name == "" || version == "" {
+		return fmt.Errorf("invalid ApplySet tooling %q: must be in the form <name>/<version>", tooling)
+	}
+	return nil
+}
+
+// ApplySetID returns the ID of the ApplySet with the parent object.
+func ApplySetID(parent types.NamespacedName, parentGK schema.GroupKind) string {
+	unencoded := strings.Join([]string{parent.Name, parent.Namespace, parentGK.Kind, parentGK.Group}, ".")
+	hashed := sha256.Sum256([]byte(unencoded))
+	return fmt.Sprintf("applyset-%s-v1", base64.RawURLEncoding.EncodeToString(hashed[:]))
+}
+
+// MemberLabeler is implemented by inventory clients which require the
+// objects in the inventory to have specific labels. The applier adds the
+// labels to the objects it applies.
+type MemberLabeler interface {
+	// MemberLabels returns the labels to add to the objects in the inventory.
+	MemberLabels(inv Info) (map[string]string, error)
+}
+
+var (
+	_ ClientFactory = ApplySetClientFactory{}
+	_ Client        = &ApplySetClient{}
+	_ MemberLabeler = &ApplySetClient{}
+)
+
+// ApplySetClientFactory is a factory that creates instances of inventory
+// clients which are backed by ApplySet parent objects.
+type ApplySetClientFactory struct {
+	// ParentGVK is the type of the ApplySet parent object, either SecretGVK
+	// or ConfigMapGVK. Defaults to SecretGVK, like kubectl.
+	ParentGVK schema.GroupVersionKind
+	// Tooling is the value of the tooling annotation of the parent object,
+	// in the form "<name>/<version>", identifying the tool that manages the
+	// ApplySet. Required.
+	//
+	// kubectl refuses to manage ApplySets whose tooling name is not
+	// "kubectl". To manage the same ApplySet with both kubectl and the
+	// applier, the tooling must be set to a kubectl value, like
+	// "kubectl/v1.34", at the cost of misreporting which tool last
+	// managed the ApplySet.
+	Tooling       string
+	StatusEnabled bool
+}
+
+func (acf ApplySetClientFactory) NewClient(factory cmdutil.Factory) (Client, error) {
+	if err := validateApplySetTooling(acf.Tooling); err != nil {
+		return nil, err
+	}
+	var from FromUnstructuredFunc
+	var to ToUnstructuredFunc
+	switch acf.ParentGVK {
+	case SecretGVK, schema.GroupVersionKind{}:
+		acf.ParentGVK = SecretGVK
+		from = secretToInventory(acf.StatusEnabled, false)
+		to = inventoryToSecret(acf.StatusEnabled, 0, false)
+	case ConfigMapGVK:
+		from = configMapToInventory(acf.StatusEnabled, false)
+		to = inventoryToConfigMap(acf.StatusEnabled, 0, false)
+	default:
+		return nil, fmt.Errorf("unsupported ApplySet parent type: %s", acf.ParentGVK)
+	}
+	parentGK := acf.ParentGVK.GroupKind()
+	uc, err := NewUnstructuredClient(factory,
+		applySetToInventory(from),
+		inventoryToApplySet(to, parentGK, acf.Tooling),
+		nil, acf.ParentGVK)
+	if err != nil {
+		return nil, err
+	}
+	dc, err := factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	return &ApplySetClient{
+		UnstructuredClient: uc,
+		dynamicClient:      dc,
+		mapper:             mapper,
+		parentGK:           parentGK,
+	}, nil
+}
+
+// ApplySetClient implements the inventory client interface for ApplySet
+// parent objects, so the objects can be managed both by the applier and by
+// `kubectl apply --prune --applyset`.
+//
+// The inventory is stored in the parent object, like with the ConfigMap and
+// Secret clients, and the parent object is updated with the ApplySet labels
+// and annotations. The members of the ApplySet, which may have been applied
+// by kubectl, are added to the inventory when it is read. Objects removed
+// from the inventory also have their ApplySet label removed, so kubectl no
+// longer prunes them.
+type ApplySetClient struct {
+	*UnstructuredClient
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+	parentGK      schema.GroupKind
+}
+
+// MemberLabels returns the ApplySet label for the members of the inventory.
+func (c *ApplySetClient) MemberLabels(inv Info) (map[string]string, error) {
+	soi, ok := inv.(*SingleObjectInfo)
+	if !ok {
+		return nil, fmt.Errorf("expected SingleObjectInfo but got %T", inv)
+	}
+	return map[string]string{ApplySetPartOfLabel: c.applySetID(soi)}, nil
+}
+
+// Get the in-cluster inventory, including the ApplySet members.
+func (c *ApplySetClient) Get(ctx context.Context, inv Info, opts GetOptions) (Inventory, error) {
+	soi, ok := inv.(*SingleObjectInfo)
+	if !ok {
+		return nil, fmt.Errorf("expected SingleObjectInfo but got %T", inv)
+	}
+	uInv, err := c.UnstructuredClient.Get(ctx, inv, opts)
+	if err != nil {
+		return nil, err
+	}
+	members, err := c.listMembers(ctx, soi)
+	if err != nil {
+		return nil, err
+	}
+	uInv.SetObjectRefs(uInv.GetObjectRefs().Union(members))
+	return uInv, nil
+}
+
+// CreateOrUpdate the in-cluster inventory, and remove the ApplySet label from
+// the members which are no longer in the inventory.
+func (c *ApplySetClient) CreateOrUpdate(ctx context.Context, inv Inventory, opts UpdateOptions) error {
+	ui, ok := inv.(*SingleObjectInventory)
+	if !ok {
+		return fmt.Errorf("expected SingleObjectInventory")
+	}
+	members, err := c.listMembers(ctx, &ui.SingleObjectInfo)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := c.UnstructuredClient.CreateOrUpdate(ctx, inv, opts); err != nil {
+		return err
+	}
+	applySetID := c.applySetID(&ui.SingleObjectInfo)
+	for _, id := range members.Diff(inv.GetObjectRefs()) {
+		if err := c.removeMemberLabel(ctx, id, applySetID); err != nil {
+			return fmt.Errorf("failed to remove ApplySet label from %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (c *ApplySetClient) applySetID(soi *SingleObjectInfo) string {
+	return ApplySetID(types.NamespacedName{Namespace: soi.GetNamespace(), Name: soi.GetName()}, c.parentGK)
+}
+
+// listMembers returns the objects with the ApplySet label, which have one of
+// the group kinds and namespaces listed by the parent object.
+func (c *ApplySetClient) listMembers(ctx context.Context, soi *SingleObjectInfo) (object.ObjMetadataSet, error) {
+	parent, err := c.client.Namespace(soi.GetNamespace()).Get(ctx, soi.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	annotations := parent.GetAnnotations()
+	namespaces := append([]string{parent.GetNamespace()},
+		splitApplySetList(annotations[ApplySetAdditionalNamespacesAnnotation])...)
+	selector := metav1.FormatLabelSelector(&metav1.LabelSelector{
+		MatchLabels: map[string]string{ApplySetPartOfLabel: c.applySetID(soi)},
+	})
+	var members object.ObjMetadataSet
+	for _, gkStr := range splitApplySetList(annotations[ApplySetGKsAnnotation]) {
+		gk := schema.ParseGroupKind(gkStr)
+		mapping, err := c.mapper.RESTMapping(gk)
+		if meta.IsNoMatchError(err) {
+			klog.V(4).Infof("skipping ApplySet members of unknown type %s", gk)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		listNamespaces := []string{metav1.NamespaceNone}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			listNamespaces = namespaces
+		}
+		for _, ns := range listNamespaces {
+			list, err := c.dynamicClient.Resource(mapping.Resource).Namespace(ns).
+				List(ctx, metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				members = append(members, object.UnstructuredToObjMetadata(&list.Items[i]))
+			}
+		}
+	}
+	return members, nil
+}
+
+// removeMemberLabel removes the ApplySet label from the object, if it is
+// still a member of the ApplySet.
+func (c *ApplySetClient) removeMemberLabel(ctx context.Context, id object.ObjMetadata, applySetID string) error {
+	mapping, err := c.mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		return err
+	}
+	client := c.dynamicClient.Resource(mapping.Resource).Namespace(id.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := client.Get(ctx, id.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		labels := obj.GetLabels()
+		if labels[ApplySetPartOfLabel] != applySetID {
+			return nil
+		}
+		delete(labels, ApplySetPartOfLabel)
+		obj.SetLabels(labels)
+		klog.V(4).Infof("removing ApplySet label (object: %q)", id)
+		_, err = client.Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// applySetToInventory wraps the conversion from the parent object, to accept
+// parent objects created by kubectl, which have no inventory ID label. Their
+// inventory ID is the ApplySet ID.
+func applySetToInventory(from FromUnstructuredFunc) FromUnstructuredFunc {
+	return func(obj *unstructured.Unstructured) (*SingleObjectInventory, error) {
+		inv, err := from(obj)
+		if err != nil {
+			return nil, err
+		}
+		if inv.id == "" {
+			inv.id = ID(obj.GetLabels()[ApplySetParentIDLabel])
+		}
+		return inv, nil
+	}
+}
+
+// inventoryToApplySet wraps the conversion to the parent object, to add the
+// ApplySet labels and annotations for the objects in the inventory.
+func inventoryToApplySet(to ToUnstructuredFunc, parentGK schema.GroupKind, tooling string) ToUnstructuredFunc {
+	return func(obj *unstructured.Unstructured, inv *SingleObjectInventory) (*unstructured.Unstructured, error) {
+		uObj, err := to(obj, inv)
+		if err != nil {
+			return nil, err
+		}
+		labels := uObj.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string, 1)
+		}
+		labels[ApplySetParentIDLabel] = ApplySetID(
+			types.NamespacedName{Namespace: inv.GetNamespace(), Name: inv.GetName()}, parentGK)
+		uObj.SetLabels(labels)
+
+		gks := make(map[string]struct{})
+		namespaces := make(map[string]struct{})
+		for _, id := range inv.GetObjectRefs() {
+			gks[formatApplySetGK(id.GroupKind)] = struct{}{}
+			if id.Namespace != "" && id.Namespace != inv.GetNamespace() {
+				namespaces[id.Namespace] = struct{}{}
+			}
+		}
+		annotations := uObj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 3)
+		}
+		annotations[ApplySetToolingAnnotation] = tooling
+		annotations[ApplySetGKsAnnotation] = joinApplySetList(gks)
+		if len(namespaces) > 0 {
+			annotations[ApplySetAdditionalNamespacesAnnotation] = joinApplySetList(namespaces)
+		} else {
+			delete(annotations, ApplySetAdditionalNamespacesAnnotation)
+		}
+		uObj.SetAnnotations(annotations)
+		return uObj, nil
+	}
+}
+
+// formatApplySetGK formats the group kind like kubectl, as Kind.group, or
+// Kind for the core group.
+func formatApplySetGK(gk schema.GroupKind) string {
+	if gk.Group == "" {
+		return gk.Kind
+	}
+	return gk.Kind + "." + gk.Group
+}
+
+func joinApplySetList(set map[string]struct{}) string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+func splitApplySetList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
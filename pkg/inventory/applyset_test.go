@@ -0,0 +1,148 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestApplySetID(t *testing.T) {
+	id := ApplySetID(types.NamespacedName{Namespace: "my-ns", Name: "my-set"}, SecretGVK.GroupKind())
+	assert.Equal(t, "applyset-XPS7DQcglYD3_BOTiwpLtirwmT9y1Q06wbJ7TyrjGmY-v1", id)
+}
+
+func TestApplySetClient(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+	defer tf.Cleanup()
+	ctx := context.TODO()
+
+	invClient, err := ApplySetClientFactory{Tooling: "kapply/v0.1.0"}.NewClient(tf)
+	require.NoError(t, err)
+	nn := types.NamespacedName{Namespace: testNamespace, Name: "my-set"}
+	applySetID := ApplySetID(nn, SecretGVK.GroupKind())
+	info := NewSingleObjectInfo(ID(applySetID), nn)
+	labels, err := invClient.(MemberLabeler).MemberLabels(info)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{ApplySetPartOfLabel: applySetID}, labels)
+
+	// An ApplySet created by kubectl, with one Pod in another namespace.
+	parent := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: my-set
+  namespace: test-inventory-namespace
+  annotations:
+    applyset.kubernetes.io/tooling: kubectl/v1.34
+    applyset.kubernetes.io/contains-group-kinds: Pod
+    applyset.kubernetes.io/additional-namespaces: other
+`)
+	parent.SetLabels(map[string]string{ApplySetParentIDLabel: applySetID})
+	secrets := tf.FakeDynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"})
+	_, err = secrets.Namespace(testNamespace).Create(ctx, parent, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	pods := tf.FakeDynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "pods"})
+	member := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: member
+  namespace: other
+`)
+	member.SetLabels(map[string]string{ApplySetPartOfLabel: applySetID})
+	_, err = pods.Namespace("other").Create(ctx, member, metav1.CreateOptions{})
+	require.NoError(t, err)
+	memberID := object.UnstructuredToObjMetadata(member)
+
+	// The members are read from the cluster.
+	inv, err := invClient.Get(ctx, info, GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, ID(applySetID), inv.Info().GetID())
+	testutil.AssertEqual(t, object.ObjMetadataSet{memberID}, inv.GetObjectRefs())
+
+	// Replace the member with a ConfigMap in the parent namespace.
+	configMapID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: testNamespace,
+		Name:      "config",
+	}
+	inv.SetObjectRefs(object.ObjMetadataSet{configMapID})
+	require.NoError(t, invClient.CreateOrUpdate(ctx, inv, UpdateOptions{}))
+
+	parent, err = secrets.Namespace(testNamespace).Get(ctx, "my-set", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, applySetID, parent.GetLabels()[ApplySetParentIDLabel])
+	assert.Equal(t, map[string]string{
+		ApplySetToolingAnnotation: "kapply/v0.1.0",
+		ApplySetGKsAnnotation:     "ConfigMap",
+	}, parent.GetAnnotations())
+
+	// The removed member is no longer part of the ApplySet.
+	member, err = pods.Namespace("other").Get(ctx, "member", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, member.GetLabels(), ApplySetPartOfLabel)
+
+	inv, err = invClient.Get(ctx, info, GetOptions{})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, object.ObjMetadataSet{configMapID}, inv.GetObjectRefs())
+}
+
+func TestApplySetClient_UnsupportedInfo(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+	defer tf.Cleanup()
+
+	invClient, err := ApplySetClientFactory{Tooling: "kapply/v0.1.0"}.NewClient(tf)
+	require.NoError(t, err)
+	info := NewSimpleInfo("my-set", testNamespace)
+
+	_, err = invClient.(MemberLabeler).MemberLabels(info)
+	require.EqualError(t, err, "expected SingleObjectInfo but got *inventory.SimpleInfo")
+	_, err = invClient.Get(context.TODO(), info, GetOptions{})
+	require.EqualError(t, err, "expected SingleObjectInfo but got *inventory.SimpleInfo")
+}
+
+func TestApplySetClientFactory_Tooling(t *testing.T) {
+	tests := map[string]struct {
+		tooling       string
+		expectedError string
+	}{
+		"missing": {
+			tooling:       "",
+			expectedError: "ApplySet tooling is required",
+		},
+		"no version": {
+			tooling:       "kapply",
+			expectedError: `invalid ApplySet tooling "kapply"`,
+		},
+		"valid": {
+			tooling: "kapply/v0.1.0",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+			defer tf.Cleanup()
+
+			_, err := ApplySetClientFactory{Tooling: tc.tooling}.NewClient(tf)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
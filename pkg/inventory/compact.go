@@ -0,0 +1,98 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+//
+// Introduces the compact inventory encoding, which stores the object
+// references and statuses as a single compressed JSON blob, instead of one
+// key per object. This makes the inventory object much smaller, allowing more
+// objects to be tracked and reducing the size of every update.
+
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// compactInventoryKey is the key of the compact encoded inventory in the
+// ConfigMap binaryData or the Secret data.
+const compactInventoryKey = "inventory"
+
+// compactInventory is the structured blob stored by the compact encoding.
+type compactInventory struct {
+	Objects  []actuation.ObjectReference `json:"objects,omitempty"`
+	Statuses []actuation.ObjectStatus    `json:"statuses,omitempty"`
+}
+
+// encodeCompactInventory returns the object references and statuses as
+// compressed JSON, base64 encoded. Like buildDataMap, statuses of objects
+// not in the object references are dropped.
+func encodeCompactInventory(objMetas object.ObjMetadataSet, objStatus object.ObjectStatusSet, statusEnabled bool) (string, error) {
+	blob := compactInventory{}
+	for _, id := range objMetas {
+		blob.Objects = append(blob.Objects, ObjectReferenceFromObjMetadata(id))
+	}
+	if statusEnabled {
+		objMap := objMetas.ToMap()
+		for _, status := range objStatus {
+			if _, found := objMap[ObjMetadataFromObjectReference(status.ObjectReference)]; found {
+				blob.Statuses = append(blob.Statuses, status)
+			}
+		}
+	}
+	data, err := json.Marshal(blob)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode inventory: %w", err)
+	}
+	return compress(data)
+}
+
+// decodeCompactInventory returns the object references and statuses from
+// the compressed JSON, base64 encoded.
+func decodeCompactInventory(str string, statusEnabled bool) (object.ObjMetadataSet, object.ObjectStatusSet, error) {
+	data, err := decompress(str)
+	if err != nil {
+		return nil, nil, err
+	}
+	var blob compactInventory
+	if err := json.Unmarshal(data, &blob); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode inventory: %w", err)
+	}
+	var objRefList object.ObjMetadataSet
+	for _, ref := range blob.Objects {
+		objRefList = append(objRefList, ObjMetadataFromObjectReference(ref))
+	}
+	var objStatusList object.ObjectStatusSet
+	if statusEnabled {
+		objStatusList = blob.Statuses
+	}
+	return objRefList, objStatusList, nil
+}
+
+// setBinaryDataKey sets the value of a key in the ConfigMap binaryData,
+// preserving any other keys. An empty value removes the key.
+func setBinaryDataKey(uObj *unstructured.Unstructured, key, value string) error {
+	binaryDataMap, _, err := unstructured.NestedStringMap(uObj.Object, "binaryData")
+	if err != nil {
+		return fmt.Errorf("failed to read binaryData field from ConfigMap inventory object: %w", err)
+	}
+	if value == "" {
+		if _, found := binaryDataMap[key]; !found {
+			return nil
+		}
+		delete(binaryDataMap, key)
+	} else {
+		if binaryDataMap == nil {
+			binaryDataMap = make(map[string]string, 1)
+		}
+		binaryDataMap[key] = value
+	}
+	if len(binaryDataMap) == 0 {
+		unstructured.RemoveNestedField(uObj.Object, "binaryData")
+		return nil
+	}
+	return unstructured.SetNestedStringMap(uObj.Object, binaryDataMap, "binaryData")
+}
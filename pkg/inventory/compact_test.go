@@ -0,0 +1,107 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestCompactEncoding(t *testing.T) {
+	objs := object.ObjMetadataSet{
+		toObjMeta(t, pod1Info),
+		toObjMeta(t, pod2Info),
+	}
+	statuses := object.ObjectStatusSet{
+		podStatus(t, pod1Info),
+		podStatus(t, pod2Info),
+		// Not in the object references, so not stored
+		podStatus(t, pod3Info),
+	}
+
+	tests := map[string]struct {
+		to               func(compact bool) ToUnstructuredFunc
+		from             FromUnstructuredFunc
+		expectedStatuses object.ObjectStatusSet
+	}{
+		"ConfigMap": {
+			to: func(compact bool) ToUnstructuredFunc {
+				return inventoryToConfigMap(true, 0, compact)
+			},
+			from:             configMapToInventory(true, false),
+			expectedStatuses: statuses[:2],
+		},
+		"ConfigMap with status disabled": {
+			to: func(compact bool) ToUnstructuredFunc {
+				return inventoryToConfigMap(false, 0, compact)
+			},
+			from: configMapToInventory(false, false),
+		},
+		"Secret": {
+			to: func(compact bool) ToUnstructuredFunc {
+				return inventoryToSecret(true, 0, compact)
+			},
+			from:             secretToInventory(true, false),
+			expectedStatuses: statuses[:2],
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			inv := NewSingleObjectInventory(emptyInventoryObject())
+			inv.SetObjectRefs(objs)
+			inv.SetObjectStatuses(statuses)
+
+			// Write the per-key encoding, then switch to the compact encoding
+			uObj, err := tc.to(false)(emptyInventoryObject(), inv)
+			require.NoError(t, err)
+			uObj, err = tc.to(true)(uObj, inv)
+			require.NoError(t, err)
+			assertCompactKeys(t, uObj, true)
+
+			actual, err := tc.from(uObj)
+			require.NoError(t, err)
+			testutil.AssertEqual(t, objs, actual.GetObjectRefs())
+			testutil.AssertEqual(t, tc.expectedStatuses, actual.GetObjectStatuses())
+
+			// Switch back to the per-key encoding
+			uObj, err = tc.to(false)(uObj, inv)
+			require.NoError(t, err)
+			assertCompactKeys(t, uObj, false)
+
+			actual, err = tc.from(uObj)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, objs, actual.GetObjectRefs())
+			assert.ElementsMatch(t, tc.expectedStatuses, actual.GetObjectStatuses())
+		})
+	}
+}
+
+// assertCompactKeys asserts whether the inventory object uses the compact
+// encoding, with no per-object keys.
+func assertCompactKeys(t *testing.T, uObj *unstructured.Unstructured, compact bool) {
+	dataMap, _, err := unstructured.NestedStringMap(uObj.Object, "data")
+	require.NoError(t, err)
+	binaryDataMap, _, err := unstructured.NestedStringMap(uObj.Object, "binaryData")
+	require.NoError(t, err)
+	keys := make(map[string]string, len(dataMap)+len(binaryDataMap))
+	for key, value := range dataMap {
+		keys[key] = value
+	}
+	for key, value := range binaryDataMap {
+		keys[key] = value
+	}
+	if compact {
+		assert.Len(t, keys, 1)
+		assert.Contains(t, keys, compactInventoryKey)
+	} else {
+		assert.Len(t, keys, 2)
+		assert.NotContains(t, keys, compactInventoryKey)
+	}
+}
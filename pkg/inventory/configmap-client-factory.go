@@ -20,8 +20,24 @@ type ClientFactory interface {
 // which are backed by ConfigMaps.
 type ConfigMapClientFactory struct {
 	StatusEnabled bool
+	// RevisionHistoryLimit is the number of revisions of applied objects to
+	// keep in the inventory, which can be used to roll back. If zero,
+	// revisions are not stored.
+	RevisionHistoryLimit int
+	// MaxObjectsPerShard is the maximum number of object references to store
+	// in each inventory object, before splitting the inventory across
+	// multiple objects. If zero, the inventory is not sharded.
+	MaxObjectsPerShard int
+	// CompactEncoding stores the object references and statuses as a single
+	// compressed value, instead of one key per object. Inventories in either
+	// encoding can always be read.
+	CompactEncoding bool
 }
 
 func (ccf ConfigMapClientFactory) NewClient(factory cmdutil.Factory) (Client, error) {
-	return NewUnstructuredClient(factory, configMapToInventory(ccf.StatusEnabled), inventoryToConfigMap(ccf.StatusEnabled), nil, ConfigMapGVK)
+	revisionsEnabled := ccf.RevisionHistoryLimit > 0
+	return NewUnstructuredClient(factory,
+		configMapToInventory(ccf.StatusEnabled, revisionsEnabled),
+		inventoryToConfigMap(ccf.StatusEnabled, ccf.RevisionHistoryLimit, ccf.CompactEncoding),
+		nil, ConfigMapGVK, WithMaxObjectsPerShard(ccf.MaxObjectsPerShard))
 }
@@ -194,7 +194,7 @@ func (ui *SingleObjectInventory) Info() Info {
 	return &ui.SingleObjectInfo
 }
 
-var _ Inventory = &SingleObjectInventory{}
+var _ RevisionInventory = &SingleObjectInventory{}
 
 // InventoryContents is a boilerplate struct that contains the basic methods
 // to implement Inventory. Can be extended for different inventory implementations.
@@ -204,6 +204,9 @@ type InventoryContents struct {
 	// read and manipulated by the applier.
 	ObjectRefs     object.ObjMetadataSet
 	ObjectStatuses object.ObjectStatusSet
+	// Revisions are the previously applied object sets, if the inventory
+	// client stores revisions.
+	Revisions []Revision
 }
 
 // GetObjectRefs returns the list of object references tracked in the inventory
@@ -228,6 +231,17 @@ func (inv *InventoryContents) SetObjectStatuses(statuses object.ObjectStatusSet)
 	inv.ObjectStatuses = statuses
 }
 
+// GetRevisions returns the list of revisions stored in the inventory
+func (inv *InventoryContents) GetRevisions() []Revision {
+	return inv.Revisions
+}
+
+// SetRevisions updates the local cache of revisions stored in the inventory.
+// This will be persisted to the cluster when the Inventory is passed to CreateOrUpdate.
+func (inv *InventoryContents) SetRevisions(revisions []Revision) {
+	inv.Revisions = revisions
+}
+
 // FromUnstructuredFunc is used by UnstructuredClient to convert an unstructured
 // object, usually fetched from the cluster, to an Inventory object for use by
 // the applier/destroyer.
@@ -258,6 +272,24 @@ type UnstructuredClient struct {
 	toUnstructuredStatus ToUnstructuredFunc
 	// gvk is the GroupVersionKind of the inventory object in the Kubernetes API.
 	gvk schema.GroupVersionKind
+	// maxObjectsPerShard is the maximum number of object references to store
+	// in each inventory object, before splitting the inventory into shards.
+	// Zero disables sharding.
+	maxObjectsPerShard int
+}
+
+// UnstructuredClientOption configures optional behavior of an
+// UnstructuredClient.
+type UnstructuredClientOption func(*UnstructuredClient)
+
+// WithMaxObjectsPerShard enables inventory sharding, storing at most n object
+// references in each inventory object. Use this to avoid exceeding the
+// maximum object size when the inventory tracks many objects.
+// Zero disables sharding.
+func WithMaxObjectsPerShard(n int) UnstructuredClientOption {
+	return func(cic *UnstructuredClient) {
+		cic.maxObjectsPerShard = n
+	}
 }
 
 // NewUnstructuredClient constructs an instance of UnstructuredClient.
@@ -268,6 +300,7 @@ func NewUnstructuredClient(factory cmdutil.Factory,
 	to ToUnstructuredFunc,
 	toStatus ToUnstructuredFunc,
 	gvk schema.GroupVersionKind,
+	opts ...UnstructuredClientOption,
 ) (*UnstructuredClient, error) {
 	dc, err := factory.DynamicClient()
 	if err != nil {
@@ -288,6 +321,9 @@ func NewUnstructuredClient(factory cmdutil.Factory,
 		toUnstructuredStatus: toStatus,
 		gvk:                  gvk,
 	}
+	for _, opt := range opts {
+		opt(unstructuredClient)
+	}
 	return unstructuredClient, nil
 }
 
@@ -323,19 +359,41 @@ func (cic *UnstructuredClient) Get(ctx context.Context, inv Info, _ GetOptions)
 	if err != nil {
 		return nil, err
 	}
-	return cic.fromUnstructured(obj)
+	return cic.fromUnstructuredShards(obj, func(name string) (*unstructured.Unstructured, error) {
+		return cic.client.Namespace(soi.GetNamespace()).Get(ctx, name, metav1.GetOptions{})
+	})
 }
 
 // List the in-cluster inventory
 // Used by the CLI commands
+//
+// Only the objects with the inventory label are listed, so that other
+// objects of the same kind are not parsed as inventories. Shard objects are
+// listed by their own label.
 func (cic *UnstructuredClient) List(ctx context.Context, _ ListOptions) ([]Inventory, error) {
-	objs, err := cic.client.List(ctx, metav1.ListOptions{})
+	objs, err := cic.client.List(ctx, metav1.ListOptions{LabelSelector: common.InventoryLabel})
 	if err != nil {
 		return nil, err
 	}
+	shardObjs, err := cic.client.List(ctx, metav1.ListOptions{LabelSelector: ShardLabel})
+	if err != nil {
+		return nil, err
+	}
+	shards := make(map[types.NamespacedName]*unstructured.Unstructured, len(shardObjs.Items))
+	for i := range shardObjs.Items {
+		obj := &shardObjs.Items[i]
+		shards[types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}] = obj
+	}
 	var inventories []Inventory
-	for _, obj := range objs.Items {
-		uInv, err := cic.fromUnstructured(&obj)
+	for i := range objs.Items {
+		obj := &objs.Items[i]
+		uInv, err := cic.fromUnstructuredShards(obj, func(name string) (*unstructured.Unstructured, error) {
+			shard, found := shards[types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}]
+			if !found {
+				return nil, fmt.Errorf("inventory shard not found: %s/%s", obj.GetNamespace(), name)
+			}
+			return shard, nil
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -344,9 +402,42 @@ func (cic *UnstructuredClient) List(ctx context.Context, _ ListOptions) ([]Inven
 	return inventories, nil
 }
 
+// fromUnstructuredShards converts the inventory object to an Inventory,
+// including the contents of any shards linked from the inventory object.
+func (cic *UnstructuredClient) fromUnstructuredShards(obj *unstructured.Unstructured,
+	getShard func(name string) (*unstructured.Unstructured, error),
+) (*SingleObjectInventory, error) {
+	uInv, err := cic.fromUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := readShardRefs(obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range refs.names(obj.GetName()) {
+		shardObj, err := getShard(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inventory shard: %w", err)
+		}
+		shard, err := cic.fromUnstructured(shardObj)
+		if err != nil {
+			return nil, err
+		}
+		mergeShard(uInv, shard)
+	}
+	return uInv, nil
+}
+
 // CreateOrUpdate the in-cluster inventory
 // Updates the unstructured object, or creates it if it doesn't exist
-func (cic *UnstructuredClient) CreateOrUpdate(ctx context.Context, inv Inventory, opts UpdateOptions) error {
+//
+// If sharding is enabled and the inventory tracks more objects than fit in
+// one shard, the extra shards are written first, as new objects. Then the
+// inventory object is updated to link to them. Finally, the shards from the
+// previous update are deleted. So a failure at any step leaves the inventory
+// object linked to a complete set of shards.
+func (cic *UnstructuredClient) CreateOrUpdate(ctx context.Context, inv Inventory, _ UpdateOptions) error {
 	ui, ok := inv.(*SingleObjectInventory)
 	if !ok {
 		return fmt.Errorf("expected SingleObjectInventory")
@@ -354,6 +445,66 @@ func (cic *UnstructuredClient) CreateOrUpdate(ctx context.Context, inv Inventory
 	if ui == nil {
 		return fmt.Errorf("inventory is nil")
 	}
+	var epoch int64
+	var owner *unstructured.Unstructured
+	if cic.maxObjectsPerShard > 0 && len(ui.GetObjectRefs()) > cic.maxObjectsPerShard {
+		obj, err := cic.client.Namespace(ui.GetNamespace()).Get(ctx, ui.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// The shards are owned by the inventory object, so it is created
+			// first, without any objects. This is the first inventory update,
+			// so none of the objects have been applied yet.
+			err = cic.createOrUpdateObject(ctx, &SingleObjectInventory{SingleObjectInfo: ui.SingleObjectInfo}, nil)
+			if err != nil {
+				return err
+			}
+			obj, err = cic.client.Namespace(ui.GetNamespace()).Get(ctx, ui.GetName(), metav1.GetOptions{})
+		}
+		if err != nil {
+			return err
+		}
+		refs, err := readShardRefs(obj)
+		if err != nil {
+			return err
+		}
+		epoch = refs.epoch + 1
+		owner = obj
+	}
+	shards := splitInventory(ui, cic.maxObjectsPerShard, epoch)
+	for _, shard := range shards[1:] {
+		err := cic.createOrUpdateObject(ctx, shard, func(uObj *unstructured.Unstructured) error {
+			markShardObject(uObj, ui.GetID(), owner)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update inventory shard: %w", err)
+		}
+	}
+	newRefs := shardRefs{epoch: epoch, count: len(shards)}
+	var oldRefs shardRefs
+	err := cic.createOrUpdateObject(ctx, shards[0], func(uObj *unstructured.Unstructured) error {
+		var err error
+		oldRefs, err = readShardRefs(uObj)
+		if err != nil {
+			return err
+		}
+		writeShardRefs(uObj, newRefs)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if oldRefs.epoch == newRefs.epoch {
+		return nil
+	}
+	return cic.deleteShards(ctx, ui.GetNamespace(), oldRefs.names(ui.GetName()))
+}
+
+// createOrUpdateObject creates or updates a single inventory object, and its
+// status, if enabled. The setMeta function is called on the object before
+// each create or update attempt.
+func (cic *UnstructuredClient) createOrUpdateObject(ctx context.Context, ui *SingleObjectInventory,
+	setMeta func(*unstructured.Unstructured) error,
+) error {
 	// Attempt to retry on a resource conflict error to avoid needing to retry the
 	// entire Apply/Destroy when there's a transient conflict.
 	attempt := 0
@@ -375,6 +526,11 @@ func (cic *UnstructuredClient) CreateOrUpdate(ctx context.Context, inv Inventory
 		if err != nil {
 			return err
 		}
+		if setMeta != nil {
+			if err := setMeta(uObj); err != nil {
+				return err
+			}
+		}
 		if create {
 			klog.V(4).Infof("[attempt %d] creating inventory object %s/%s/%s",
 				attempt, cic.gvk, uObj.GetNamespace(), uObj.GetName())
@@ -418,7 +574,7 @@ func (cic *UnstructuredClient) CreateOrUpdate(ctx context.Context, inv Inventory
 		// If using a custom inventory resource, make sure the observedGeneration
 		// defaults to 0. That will ensure the observedGeneration is updated here, and
 		// the kstatus computes as InProgress after the object is created.
-		_, ok, err = unstructured.NestedInt64(uObj.Object, "status", "observedGeneration")
+		_, ok, err := unstructured.NestedInt64(uObj.Object, "status", "observedGeneration")
 		if err != nil {
 			return err
 		}
@@ -439,7 +595,8 @@ func (cic *UnstructuredClient) CreateOrUpdate(ctx context.Context, inv Inventory
 }
 
 // Delete the in-cluster inventory
-// Performs a simple deletion of the unstructured object
+// Performs a simple deletion of the unstructured object, after deleting any
+// shards linked from it.
 func (cic *UnstructuredClient) Delete(ctx context.Context, inv Info, _ DeleteOptions) error {
 	if inv == nil {
 		return fmt.Errorf("inventory Info is nil")
@@ -448,9 +605,36 @@ func (cic *UnstructuredClient) Delete(ctx context.Context, inv Info, _ DeleteOpt
 	if !ok {
 		return fmt.Errorf("expected SingleObjectInfo but got %T", inv)
 	}
-	err := cic.client.Namespace(soi.GetNamespace()).Delete(ctx, soi.GetName(), metav1.DeleteOptions{})
+	obj, err := cic.client.Namespace(soi.GetNamespace()).Get(ctx, soi.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	refs, err := readShardRefs(obj)
+	if err != nil {
+		return err
+	}
+	if err := cic.deleteShards(ctx, soi.GetNamespace(), refs.names(soi.GetName())); err != nil {
+		return err
+	}
+	err = cic.client.Namespace(soi.GetNamespace()).Delete(ctx, soi.GetName(), metav1.DeleteOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("delete: %w", err)
 	}
 	return nil
 }
+
+// deleteShards deletes the named inventory shards, ignoring any that are
+// already deleted.
+func (cic *UnstructuredClient) deleteShards(ctx context.Context, namespace string, names []string) error {
+	for _, name := range names {
+		klog.V(4).Infof("deleting inventory shard %s/%s/%s", cic.gvk, namespace, name)
+		err := cic.client.Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete inventory shard: %w", err)
+		}
+	}
+	return nil
+}
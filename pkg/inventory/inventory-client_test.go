@@ -70,7 +70,7 @@ func TestGet(t *testing.T) {
 				inv := NewSingleObjectInventory(emptyInventoryObject())
 				inv.SetObjectRefs(tc.localObjs)
 				inv.SetObjectStatuses(tc.objStatus)
-				cm, _ := inventoryToConfigMap(tc.statusEnabled)(emptyInventoryObject(), inv)
+				cm, _ := inventoryToConfigMap(tc.statusEnabled, 0, false)(emptyInventoryObject(), inv)
 				return true, cm, nil
 			})
 			invClient, err := ConfigMapClientFactory{StatusEnabled: tc.statusEnabled}.NewClient(tf)
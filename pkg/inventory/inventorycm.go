@@ -28,7 +28,7 @@ var ConfigMapGVK = schema.GroupVersionKind{
 // wraps it with the ConfigMap and upcasts the wrapper as
 // an the Inventory interface.
 func ConfigMapToInventoryObj(uObj *unstructured.Unstructured) (Inventory, error) {
-	return configMapToInventory(true)(uObj)
+	return configMapToInventory(true, true)(uObj)
 }
 
 // ConfigMapToInventoryInfo takes a passed ConfigMap (as a resource.Info),
@@ -48,9 +48,12 @@ func buildDataMap(objMetas object.ObjMetadataSet, objStatus object.ObjectStatusS
 		for _, status := range objStatus {
 			// Copy ObjectStatus to remove ObjectReference
 			objStatusMap[ObjMetadataFromObjectReference(status.ObjectReference)] = actuation.ObjectStatus{
-				Strategy:  status.Strategy,
-				Actuation: status.Actuation,
-				Reconcile: status.Reconcile,
+				Strategy:   status.Strategy,
+				Actuation:  status.Actuation,
+				Reconcile:  status.Reconcile,
+				UID:        status.UID,
+				Generation: status.Generation,
+				Hash:       status.Hash,
 			}
 		}
 	}
@@ -94,20 +97,39 @@ func parseDataMap(objMap map[string]string, statusEnabled bool) (object.ObjMetad
 	return objRefList, objStatusList, nil
 }
 
-func configMapToInventory(statusEnabled bool) FromUnstructuredFunc {
+func configMapToInventory(statusEnabled, revisionsEnabled bool) FromUnstructuredFunc {
 	return func(configMap *unstructured.Unstructured) (*SingleObjectInventory, error) {
 		inv := NewSingleObjectInventory(configMap)
-		dataMap, exists, err := unstructured.NestedStringMap(configMap.Object, "data")
+		binaryDataMap, _, err := unstructured.NestedStringMap(configMap.Object, "binaryData")
 		if err != nil {
-			return nil, fmt.Errorf("failed to read data field from ConfigMap inventory object: %w", err)
+			return nil, fmt.Errorf("failed to read binaryData field from ConfigMap inventory object: %w", err)
 		}
-		if exists {
-			objRefList, objStatusList, err := parseDataMap(dataMap, statusEnabled)
+		if compactStr, found := binaryDataMap[compactInventoryKey]; found {
+			objRefList, objStatusList, err := decodeCompactInventory(compactStr, statusEnabled)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse data field from ConfigMap inventory object: %w", err)
+				return nil, fmt.Errorf("failed to parse binaryData field from ConfigMap inventory object: %w", err)
 			}
 			inv.ObjectRefs = objRefList
 			inv.ObjectStatuses = objStatusList
+		} else {
+			dataMap, exists, err := unstructured.NestedStringMap(configMap.Object, "data")
+			if err != nil {
+				return nil, fmt.Errorf("failed to read data field from ConfigMap inventory object: %w", err)
+			}
+			if exists {
+				objRefList, objStatusList, err := parseDataMap(dataMap, statusEnabled)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse data field from ConfigMap inventory object: %w", err)
+				}
+				inv.ObjectRefs = objRefList
+				inv.ObjectStatuses = objStatusList
+			}
+		}
+		if revisionsEnabled {
+			inv.Revisions, err = parseRevisionMap(binaryDataMap)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse binaryData field from ConfigMap inventory object: %w", err)
+			}
 		}
 		return inv, nil
 	}
@@ -115,24 +137,75 @@ func configMapToInventory(statusEnabled bool) FromUnstructuredFunc {
 
 // ConfigMap does not have an actual status, so the object statuses are persisted
 // as values in the ConfigMap key/value pairs.
-func inventoryToConfigMap(statusEnabled bool) ToUnstructuredFunc {
+// If revisionHistoryLimit is positive, up to that many of the newest revisions
+// are persisted as compressed values in the ConfigMap binaryData.
+// If compact is true, the object references and statuses are persisted as a
+// single compressed value in the ConfigMap binaryData, instead of the data.
+func inventoryToConfigMap(statusEnabled bool, revisionHistoryLimit int, compact bool) ToUnstructuredFunc {
 	return func(uObj *unstructured.Unstructured, inv *SingleObjectInventory) (*unstructured.Unstructured, error) {
-		var dataMap map[string]string
 		var err error
-		dataMap, err = buildDataMap(inv.GetObjectRefs(), inv.GetObjectStatuses(), statusEnabled)
-		if err != nil {
-			return nil, err
+		if compact {
+			compactStr, err := encodeCompactInventory(inv.GetObjectRefs(), inv.GetObjectStatuses(), statusEnabled)
+			if err != nil {
+				return nil, err
+			}
+			unstructured.RemoveNestedField(uObj.Object, "data")
+			err = setBinaryDataKey(uObj, compactInventoryKey, compactStr)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			dataMap, err := buildDataMap(inv.GetObjectRefs(), inv.GetObjectStatuses(), statusEnabled)
+			if err != nil {
+				return nil, err
+			}
+			// Adds the inventory map to the ConfigMap "data" section.
+			err = unstructured.SetNestedStringMap(uObj.UnstructuredContent(),
+				dataMap, "data")
+			if err != nil {
+				return nil, err
+			}
+			// Removes the compact encoded inventory, if previously written.
+			err = setBinaryDataKey(uObj, compactInventoryKey, "")
+			if err != nil {
+				return nil, err
+			}
 		}
-		// Adds the inventory map to the ConfigMap "data" section.
-		err = unstructured.SetNestedStringMap(uObj.UnstructuredContent(),
-			dataMap, "data")
-		if err != nil {
-			return nil, err
+		if revisionHistoryLimit > 0 {
+			err = setRevisions(uObj, inv.GetRevisions(), revisionHistoryLimit)
+			if err != nil {
+				return nil, err
+			}
 		}
 		return uObj, err
 	}
 }
 
+// setRevisions replaces the revisions in the ConfigMap binaryData, preserving
+// any other keys.
+func setRevisions(uObj *unstructured.Unstructured, revisions []Revision, limit int) error {
+	binaryDataMap, _, err := unstructured.NestedStringMap(uObj.Object, "binaryData")
+	if err != nil {
+		return fmt.Errorf("failed to read binaryData field from ConfigMap inventory object: %w", err)
+	}
+	for key := range binaryDataMap {
+		if isRevisionKey(key) {
+			delete(binaryDataMap, key)
+		}
+	}
+	revisionMap, err := buildRevisionMap(revisions, limit)
+	if err != nil {
+		return err
+	}
+	if binaryDataMap == nil {
+		binaryDataMap = make(map[string]string, len(revisionMap))
+	}
+	for key, value := range revisionMap {
+		binaryDataMap[key] = value
+	}
+	return unstructured.SetNestedStringMap(uObj.Object, binaryDataMap, "binaryData")
+}
+
 func formatObjectStatus(status actuation.ObjectStatus) (string, error) {
 	data, err := json.Marshal(status)
 	if err != nil || string(data) == "{}" {
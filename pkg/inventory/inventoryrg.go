@@ -0,0 +1,128 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+//
+// Introduces the conversion functions for ResourceGroup inventory objects.
+// ResourceGroup is a custom resource which stores the object references in
+// the spec and the object statuses in the status subresource. The
+// ResourceGroup CustomResourceDefinition must be installed in the cluster.
+// See the resourcegroup package for the CRD manifest.
+
+package inventory
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+)
+
+var ResourceGroupGVK = schema.GroupVersionKind{
+	Group:   "cli-utils.sigs.k8s.io",
+	Kind:    "ResourceGroup",
+	Version: "v1alpha1",
+}
+
+// ResourceGroupToInventoryObj takes a passed ResourceGroup, wraps it with the
+// SingleObjectInventory and upcasts the wrapper as an the Inventory interface.
+func ResourceGroupToInventoryObj(uObj *unstructured.Unstructured) (Inventory, error) {
+	return resourceGroupToInventory(true)(uObj)
+}
+
+func resourceGroupToInventory(statusEnabled bool) FromUnstructuredFunc {
+	return func(rg *unstructured.Unstructured) (*SingleObjectInventory, error) {
+		inv := NewSingleObjectInventory(rg)
+		objRefs, _, err := unstructured.NestedSlice(rg.Object, "spec", "objects")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spec field from ResourceGroup inventory object: %w", err)
+		}
+		for _, item := range objRefs {
+			var objRef actuation.ObjectReference
+			if err := fromUnstructuredField(item, &objRef); err != nil {
+				return nil, fmt.Errorf("failed to parse spec field from ResourceGroup inventory object: %w", err)
+			}
+			inv.ObjectRefs = append(inv.ObjectRefs, ObjMetadataFromObjectReference(objRef))
+		}
+		if !statusEnabled {
+			return inv, nil
+		}
+		objStatuses, _, err := unstructured.NestedSlice(rg.Object, "status", "objects")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status field from ResourceGroup inventory object: %w", err)
+		}
+		for _, item := range objStatuses {
+			var objStatus actuation.ObjectStatus
+			if err := fromUnstructuredField(item, &objStatus); err != nil {
+				return nil, fmt.Errorf("failed to parse status field from ResourceGroup inventory object: %w", err)
+			}
+			inv.ObjectStatuses = append(inv.ObjectStatuses, objStatus)
+		}
+		return inv, nil
+	}
+}
+
+// inventoryToResourceGroup populates the ResourceGroup spec with the object
+// references. The status is removed, because it is ignored by the server when
+// calling Create or Update.
+func inventoryToResourceGroup(uObj *unstructured.Unstructured, inv *SingleObjectInventory) (*unstructured.Unstructured, error) {
+	var objRefs []any
+	for _, id := range inv.GetObjectRefs() {
+		objRef := ObjectReferenceFromObjMetadata(id)
+		item, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&objRef)
+		if err != nil {
+			return nil, err
+		}
+		objRefs = append(objRefs, item)
+	}
+	if len(objRefs) > 0 {
+		err := unstructured.SetNestedSlice(uObj.Object, objRefs, "spec", "objects")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		unstructured.RemoveNestedField(uObj.Object, "spec", "objects")
+	}
+	unstructured.RemoveNestedField(uObj.Object, "status")
+	return uObj, nil
+}
+
+// inventoryToResourceGroupStatus populates the ResourceGroup status with the
+// object statuses. The spec is removed, because it is ignored by the server
+// when calling UpdateStatus.
+func inventoryToResourceGroupStatus(uObj *unstructured.Unstructured, inv *SingleObjectInventory) (*unstructured.Unstructured, error) {
+	unstructured.RemoveNestedField(uObj.Object, "spec")
+	var objStatuses []any
+	for _, objStatus := range inv.GetObjectStatuses() {
+		item, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&objStatus)
+		if err != nil {
+			return nil, err
+		}
+		objStatuses = append(objStatuses, item)
+	}
+	if len(objStatuses) > 0 {
+		err := unstructured.SetNestedSlice(uObj.Object, objStatuses, "status", "objects")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		unstructured.RemoveNestedField(uObj.Object, "status", "objects")
+	}
+	// Record that the status reflects the current spec, so the kstatus of
+	// the ResourceGroup computes as Current.
+	err := unstructured.SetNestedField(uObj.Object, uObj.GetGeneration(), "status", "observedGeneration")
+	if err != nil {
+		return nil, err
+	}
+	return uObj, nil
+}
+
+// fromUnstructuredField converts a field value from an unstructured object
+// to the specified typed object.
+func fromUnstructuredField(item any, obj any) error {
+	m, ok := item.(map[string]any)
+	if !ok {
+		return fmt.Errorf("expected map but got %T", item)
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(m, obj)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestResourceGroupInventory(t *testing.T) {
+	podID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "Pod"},
+		Namespace: "default",
+		Name:      "pod",
+	}
+	deploymentID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "deployment",
+	}
+	statuses := object.ObjectStatusSet{
+		{
+			ObjectReference: ObjectReferenceFromObjMetadata(podID),
+			Strategy:        actuation.ActuationStrategyApply,
+			Actuation:       actuation.ActuationSucceeded,
+			Reconcile:       actuation.ReconcileSucceeded,
+			UID:             "pod-uid",
+			Generation:      1,
+			Hash:            "abc123",
+		},
+		{
+			ObjectReference: ObjectReferenceFromObjMetadata(deploymentID),
+			Strategy:        actuation.ActuationStrategyApply,
+			Actuation:       actuation.ActuationFailed,
+			Reconcile:       actuation.ReconcileSkipped,
+		},
+	}
+
+	tests := map[string]struct {
+		objRefs          object.ObjMetadataSet
+		statusEnabled    bool
+		expectedStatuses object.ObjectStatusSet
+	}{
+		"empty inventory": {
+			statusEnabled: true,
+		},
+		"status disabled": {
+			objRefs: object.ObjMetadataSet{podID, deploymentID},
+		},
+		"status enabled": {
+			objRefs:          object.ObjMetadataSet{podID, deploymentID},
+			statusEnabled:    true,
+			expectedStatuses: statuses,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rg := emptyInventoryObject()
+			rg.SetGroupVersionKind(ResourceGroupGVK)
+			rg.SetGeneration(2)
+
+			inv := NewSingleObjectInventory(rg)
+			inv.SetObjectRefs(tc.objRefs)
+			if len(tc.objRefs) > 0 {
+				inv.SetObjectStatuses(statuses)
+			}
+
+			// Spec and status are updated separately, like the server would.
+			specObj, err := inventoryToResourceGroup(rg.DeepCopy(), inv)
+			require.NoError(t, err)
+			_, found, err := unstructured.NestedFieldNoCopy(specObj.Object, "status")
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			statusObj, err := inventoryToResourceGroupStatus(rg.DeepCopy(), inv)
+			require.NoError(t, err)
+			observedGeneration, _, err := unstructured.NestedInt64(statusObj.Object, "status", "observedGeneration")
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), observedGeneration)
+
+			if status, found := statusObj.Object["status"]; found {
+				specObj.Object["status"] = status
+			}
+			actual, err := resourceGroupToInventory(tc.statusEnabled)(specObj)
+			require.NoError(t, err)
+			testutil.AssertEqual(t, tc.objRefs, actual.GetObjectRefs())
+			testutil.AssertEqual(t, tc.expectedStatuses, actual.GetObjectStatuses())
+		})
+	}
+}
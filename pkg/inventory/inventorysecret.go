@@ -0,0 +1,140 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+//
+// Introduces the conversion functions for Secret inventory objects, which
+// store the inventory the same way as ConfigMap inventory objects, but with
+// base64 encoded values. This allows using an inventory in environments that
+// forbid writing ConfigMaps.
+
+package inventory
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+var SecretGVK = schema.GroupVersionKind{
+	Group:   "",
+	Kind:    "Secret",
+	Version: "v1",
+}
+
+// SecretType is the type of Secret inventory objects.
+const SecretType = "cli-utils.sigs.k8s.io/inventory"
+
+// SecretToInventoryObj takes a passed Secret, wraps it with the
+// SingleObjectInventory and upcasts the wrapper as an the Inventory interface.
+func SecretToInventoryObj(uObj *unstructured.Unstructured) (Inventory, error) {
+	return secretToInventory(true, true)(uObj)
+}
+
+func secretToInventory(statusEnabled, revisionsEnabled bool) FromUnstructuredFunc {
+	return func(secret *unstructured.Unstructured) (*SingleObjectInventory, error) {
+		inv := NewSingleObjectInventory(secret)
+		encodedMap, _, err := unstructured.NestedStringMap(secret.Object, "data")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read data field from Secret inventory object: %w", err)
+		}
+		// Revisions are stored in the same map as the objects. Their values
+		// are already base64 encoded, so they are parsed without decoding.
+		// The compact encoded inventory is also already base64 encoded.
+		dataMap := make(map[string]string, len(encodedMap))
+		revisionMap := make(map[string]string)
+		compactStr, compact := encodedMap[compactInventoryKey]
+		for key, value := range encodedMap {
+			if isRevisionKey(key) {
+				revisionMap[key] = value
+				continue
+			}
+			if compact {
+				continue
+			}
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s in Secret inventory object: %w", key, err)
+			}
+			dataMap[key] = string(decoded)
+		}
+		var objRefList object.ObjMetadataSet
+		var objStatusList object.ObjectStatusSet
+		if compact {
+			objRefList, objStatusList, err = decodeCompactInventory(compactStr, statusEnabled)
+		} else {
+			objRefList, objStatusList, err = parseDataMap(dataMap, statusEnabled)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse data field from Secret inventory object: %w", err)
+		}
+		inv.ObjectRefs = objRefList
+		inv.ObjectStatuses = objStatusList
+		if revisionsEnabled {
+			inv.Revisions, err = parseRevisionMap(revisionMap)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse data field from Secret inventory object: %w", err)
+			}
+		}
+		return inv, nil
+	}
+}
+
+// Secret does not have an actual status, so the object statuses are persisted
+// as base64 encoded values in the Secret data, like in ConfigMap inventory
+// objects. If revisionHistoryLimit is positive, up to that many of the newest
+// revisions are persisted as compressed values in the Secret data.
+// If compact is true, the object references and statuses are persisted as a
+// single compressed value in the Secret data, instead of one key per object.
+func inventoryToSecret(statusEnabled bool, revisionHistoryLimit int, compact bool) ToUnstructuredFunc {
+	return func(uObj *unstructured.Unstructured, inv *SingleObjectInventory) (*unstructured.Unstructured, error) {
+		var encodedMap map[string]string
+		if compact {
+			compactStr, err := encodeCompactInventory(inv.GetObjectRefs(), inv.GetObjectStatuses(), statusEnabled)
+			if err != nil {
+				return nil, err
+			}
+			encodedMap = map[string]string{compactInventoryKey: compactStr}
+		} else {
+			dataMap, err := buildDataMap(inv.GetObjectRefs(), inv.GetObjectStatuses(), statusEnabled)
+			if err != nil {
+				return nil, err
+			}
+			encodedMap = make(map[string]string, len(dataMap))
+			for key, value := range dataMap {
+				encodedMap[key] = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+		}
+		if revisionHistoryLimit > 0 {
+			revisionMap, err := buildRevisionMap(inv.GetRevisions(), revisionHistoryLimit)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range revisionMap {
+				encodedMap[key] = value
+			}
+		} else {
+			// Keep the stored revisions, if any.
+			oldMap, _, err := unstructured.NestedStringMap(uObj.Object, "data")
+			if err != nil {
+				return nil, fmt.Errorf("failed to read data field from Secret inventory object: %w", err)
+			}
+			for key, value := range oldMap {
+				if isRevisionKey(key) {
+					encodedMap[key] = value
+				}
+			}
+		}
+		if uObj.Object["type"] == nil {
+			uObj.Object["type"] = SecretType
+		}
+		// Adds the inventory map to the Secret "data" section.
+		err := unstructured.SetNestedStringMap(uObj.UnstructuredContent(),
+			encodedMap, "data")
+		if err != nil {
+			return nil, err
+		}
+		return uObj, nil
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestSecretInventory(t *testing.T) {
+	pod := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: default
+`)
+	podID := object.UnstructuredToObjMetadata(pod)
+	podStatus := actuation.ObjectStatus{
+		ObjectReference: ObjectReferenceFromObjMetadata(podID),
+		Strategy:        actuation.ActuationStrategyApply,
+		Actuation:       actuation.ActuationSucceeded,
+		Reconcile:       actuation.ReconcileSucceeded,
+		UID:             "pod-uid",
+		Generation:      1,
+	}
+	revisions := []Revision{
+		newTestRevision(1, true, pod),
+		newTestRevision(2, false, pod),
+	}
+
+	tests := map[string]struct {
+		statusEnabled     bool
+		limit             int
+		expectedStatuses  object.ObjectStatusSet
+		expectedRevisions []Revision
+	}{
+		"status and revisions disabled": {},
+		"status enabled": {
+			statusEnabled:    true,
+			expectedStatuses: object.ObjectStatusSet{podStatus},
+		},
+		"revisions enabled": {
+			limit:             1,
+			expectedRevisions: revisions[1:],
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := emptyInventoryObject()
+			secret.SetGroupVersionKind(SecretGVK)
+
+			inv := NewSingleObjectInventory(secret)
+			inv.SetObjectRefs(object.ObjMetadataSet{podID})
+			inv.SetObjectStatuses(object.ObjectStatusSet{podStatus})
+			inv.SetRevisions(revisions)
+			secret, err := inventoryToSecret(tc.statusEnabled, tc.limit, false)(secret, inv)
+			require.NoError(t, err)
+			assert.Equal(t, SecretType, secret.Object["type"])
+
+			// Object references are stored as base64 encoded data keys
+			data, _, err := unstructured.NestedStringMap(secret.Object, "data")
+			require.NoError(t, err)
+			assert.Len(t, data, 1+len(tc.expectedRevisions))
+			_, err = base64.StdEncoding.DecodeString(data[podID.String()])
+			require.NoError(t, err)
+
+			actual, err := secretToInventory(tc.statusEnabled, tc.limit > 0)(secret)
+			require.NoError(t, err)
+			testutil.AssertEqual(t, object.ObjMetadataSet{podID}, actual.GetObjectRefs())
+			testutil.AssertEqual(t, tc.expectedStatuses, actual.GetObjectStatuses())
+			testutil.AssertEqual(t, tc.expectedRevisions, actual.GetRevisions())
+		})
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+// DefaultLockStealTimeout is the default duration after which a lock that
+// has not been renewed is considered abandoned.
+const DefaultLockStealTimeout = time.Minute
+
+// lockRetryInterval is how often a lock held by someone else is checked
+// while waiting for it to be released.
+const lockRetryInterval = time.Second
+
+var leaseGVR = coordinationv1.SchemeGroupVersion.WithResource("leases")
+
+// LockOptions configures the lease used to lock an inventory.
+type LockOptions struct {
+	// Holder identifies the holder of the lock. Defaults to the hostname
+	// followed by a random suffix.
+	Holder string
+
+	// WaitTimeout defines how long to wait for a lock held by someone else
+	// to be released. If zero, acquiring a held lock fails immediately.
+	WaitTimeout time.Duration
+
+	// StealTimeout defines how long a lock can go without being renewed
+	// before it is considered abandoned and can be taken over. The holder
+	// renews the lock every third of this duration. Defaults to
+	// DefaultLockStealTimeout.
+	StealTimeout time.Duration
+}
+
+// LockHeldError is returned when an inventory is locked by another holder.
+type LockHeldError struct {
+	Inventory Info
+	Holder    string
+	RenewTime time.Time
+}
+
+func (e *LockHeldError) Error() string {
+	return fmt.Sprintf("inventory %s/%s is locked by %q (last renewed %s)",
+		e.Inventory.GetNamespace(), inventoryName(e.Inventory), e.Holder, e.RenewTime.Format(time.RFC3339))
+}
+
+// LockLostError is returned when an inventory lock is lost before it was
+// released, either because it was taken over by someone else, or because it
+// could not be renewed before it expired.
+type LockLostError struct {
+	Inventory Info
+	Err       error
+}
+
+func (e *LockLostError) Error() string {
+	return fmt.Sprintf("lost inventory lock for %s/%s: %v",
+		e.Inventory.GetNamespace(), inventoryName(e.Inventory), e.Err)
+}
+
+func (e *LockLostError) Unwrap() error {
+	return e.Err
+}
+
+// Lock is a lease-based lock on an inventory, acquired with AcquireLock.
+// The lease is renewed in the background until Release is called.
+type Lock struct {
+	client    dynamic.ResourceInterface
+	inv       Info
+	name      string
+	holder    string
+	duration  time.Duration
+	cancel    context.CancelFunc
+	renewDone chan struct{}
+	lost      chan struct{}
+	lostErr   error
+}
+
+// LockName returns the name of the Lease used to lock the inventory.
+// The Lease is in the namespace of the inventory, or the default namespace
+// for cluster-scoped inventories.
+func LockName(inv Info) string {
+	return inventoryName(inv) + "-lock"
+}
+
+// inventoryName returns the name of the inventory object, if known, or the
+// inventory ID otherwise.
+func inventoryName(inv Info) string {
+	if named, ok := inv.(interface{ GetName() string }); ok {
+		return named.GetName()
+	}
+	return string(inv.GetID())
+}
+
+// AcquireLock acquires a lease on the inventory, so that concurrent applies
+// and destroys of the same inventory do not race with each other. If the
+// lease is held by someone else, it waits up to WaitTimeout for the lease to
+// be released or to expire, and then returns a LockHeldError. Concurrent
+// updates of the lease are retried with backoff.
+//
+// If the lease is taken over or can't be renewed before it expires, the lock
+// is lost, and the Lost channel is closed.
+func AcquireLock(ctx context.Context, client dynamic.Interface, inv Info, opts LockOptions) (*Lock, error) {
+	if opts.StealTimeout <= 0 {
+		opts.StealTimeout = DefaultLockStealTimeout
+	}
+	if opts.Holder == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "unknown"
+		}
+		opts.Holder = hostname + "-" + utilrand.String(5)
+	}
+	namespace := inv.GetNamespace()
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	l := &Lock{
+		client:   client.Resource(leaseGVR).Namespace(namespace),
+		inv:      inv,
+		name:     LockName(inv),
+		holder:   opts.Holder,
+		duration: opts.StealTimeout,
+		lost:     make(chan struct{}),
+	}
+
+	deadline := time.Now().Add(opts.WaitTimeout)
+	for {
+		// Retry with backoff if someone else updated the lease since we
+		// read it.
+		err := retry.OnError(retry.DefaultBackoff, isLeaseConflict, func() error {
+			return l.tryAcquire(ctx)
+		})
+		if err == nil {
+			break
+		}
+		var heldErr *LockHeldError
+		if !errors.As(err, &heldErr) {
+			return nil, fmt.Errorf("failed to acquire inventory lock: %w", err)
+		}
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, heldErr
+		}
+		klog.V(4).Infof("waiting for inventory lock %s/%s held by %q", namespace, l.name, heldErr.Holder)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(wait, lockRetryInterval)):
+		}
+	}
+
+	renewCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	l.cancel = cancel
+	l.renewDone = make(chan struct{})
+	go l.renew(renewCtx)
+	return l, nil
+}
+
+// Lost returns a channel that is closed if the lock is lost before it is
+// released. Err then returns the reason.
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Err returns a LockLostError if the lock was lost, or nil otherwise.
+func (l *Lock) Err() error {
+	select {
+	case <-l.lost:
+		return l.lostErr
+	default:
+		return nil
+	}
+}
+
+// Release stops renewing the lease and deletes it, if it is still held.
+func (l *Lock) Release(ctx context.Context) error {
+	l.cancel()
+	<-l.renewDone
+
+	lease, err := l.get(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != l.holder {
+		klog.Warningf("inventory lock %s was taken over by %q", l.name, ptr.Deref(lease.Spec.HolderIdentity, ""))
+		return nil
+	}
+	err = l.client.Delete(ctx, l.name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// tryAcquire creates or takes over the lease, if it is not held by someone
+// else. Otherwise it returns a LockHeldError.
+func (l *Lock) tryAcquire(ctx context.Context) error {
+	now := metav1.NowMicro()
+	lease, err := l.get(ctx)
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: coordinationv1.SchemeGroupVersion.String(),
+				Kind:       "Lease",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: l.name},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(l.holder),
+				LeaseDurationSeconds: ptr.To(int32(l.duration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		obj, err := toUnstructuredLease(lease)
+		if err != nil {
+			return err
+		}
+		_, err = l.client.Create(ctx, obj, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if holder != "" && holder != l.holder && !leaseExpired(lease, now.Time) {
+		return &LockHeldError{Inventory: l.inv, Holder: holder, RenewTime: leaseRenewTime(lease)}
+	}
+	if holder != l.holder {
+		if holder != "" {
+			klog.Warningf("taking over inventory lock %s from %q: not renewed since %s",
+				l.name, holder, leaseRenewTime(lease).Format(time.RFC3339))
+		}
+		lease.Spec.HolderIdentity = ptr.To(l.holder)
+		lease.Spec.AcquireTime = &now
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	}
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(l.duration.Seconds()))
+	lease.Spec.RenewTime = &now
+	obj, err := toUnstructuredLease(lease)
+	if err != nil {
+		return err
+	}
+	_, err = l.client.Update(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
+
+// renew periodically renews the lease until the context is cancelled.
+// Failed renewals are retried on the next tick, until the lease expires.
+// If the lease is taken over or expires, the lock is lost and renew stops.
+func (l *Lock) renew(ctx context.Context) {
+	defer close(l.renewDone)
+	ticker := time.NewTicker(l.duration / 3)
+	defer ticker.Stop()
+	lastRenew := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := l.tryAcquire(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			lastRenew = time.Now()
+			continue
+		}
+		var heldErr *LockHeldError
+		if errors.As(err, &heldErr) || time.Since(lastRenew) >= l.duration {
+			l.lostErr = &LockLostError{Inventory: l.inv, Err: err}
+			close(l.lost)
+			return
+		}
+		klog.Warningf("failed to renew inventory lock %s: %v", l.name, err)
+	}
+}
+
+// isLeaseConflict returns true if the lease was created or updated by
+// someone else since it was read.
+func isLeaseConflict(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+}
+
+func (l *Lock) get(ctx context.Context) (*coordinationv1.Lease, error) {
+	obj, err := l.client.Get(ctx, l.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	lease := &coordinationv1.Lease{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, lease); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+func toUnstructuredLease(lease *coordinationv1.Lease) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(lease)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// leaseRenewTime returns the last time the lease was renewed or acquired.
+func leaseRenewTime(lease *coordinationv1.Lease) time.Time {
+	switch {
+	case lease.Spec.RenewTime != nil:
+		return lease.Spec.RenewTime.Time
+	case lease.Spec.AcquireTime != nil:
+		return lease.Spec.AcquireTime.Time
+	default:
+		return lease.CreationTimestamp.Time
+	}
+}
+
+// leaseExpired returns true if the lease has not been renewed within its
+// duration.
+func leaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	duration := time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, 0)) * time.Second
+	return leaseRenewTime(lease).Add(duration).Before(now)
+}
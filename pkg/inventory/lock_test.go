@@ -0,0 +1,171 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/retry"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+func TestLock(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+	defer tf.Cleanup()
+	ctx := context.TODO()
+
+	info := NewSingleObjectInfo("test-id",
+		types.NamespacedName{Namespace: testNamespace, Name: inventoryObjName})
+	leases := tf.FakeDynamicClient.Resource(leaseGVR).Namespace(testNamespace)
+	holder := func() string {
+		obj, err := leases.Get(ctx, LockName(info), metav1.GetOptions{})
+		require.NoError(t, err)
+		holder, _, err := unstructured.NestedString(obj.Object, "spec", "holderIdentity")
+		require.NoError(t, err)
+		return holder
+	}
+
+	// Acquire a free lock.
+	lockA, err := AcquireLock(ctx, tf.FakeDynamicClient, info, LockOptions{Holder: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "a", holder())
+
+	// A held lock can't be acquired by someone else.
+	_, err = AcquireLock(ctx, tf.FakeDynamicClient, info, LockOptions{Holder: "b"})
+	var heldErr *LockHeldError
+	require.True(t, errors.As(err, &heldErr), "expected LockHeldError, got %v", err)
+	assert.Equal(t, "a", heldErr.Holder)
+
+	// Wait for a held lock to be released.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		assert.NoError(t, lockA.Release(ctx))
+	}()
+	lockB, err := AcquireLock(ctx, tf.FakeDynamicClient, info, LockOptions{
+		Holder:      "b",
+		WaitTimeout: 10 * time.Second,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "b", holder())
+
+	// Stop renewing the lock, and make it stale.
+	lockB.cancel()
+	<-lockB.renewDone
+	obj, err := leases.Get(ctx, LockName(info), metav1.GetOptions{})
+	require.NoError(t, err)
+	stale := metav1.NewMicroTime(time.Now().Add(-2 * DefaultLockStealTimeout))
+	require.NoError(t, unstructured.SetNestedField(obj.Object, stale.Format(metav1.RFC3339Micro), "spec", "renewTime"))
+	_, err = leases.Update(ctx, obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// A stale lock is taken over.
+	lockC, err := AcquireLock(ctx, tf.FakeDynamicClient, info, LockOptions{Holder: "c"})
+	require.NoError(t, err)
+	assert.Equal(t, "c", holder())
+
+	// Releasing a lock that was taken over leaves it alone.
+	require.NoError(t, lockB.Release(ctx))
+	assert.Equal(t, "c", holder())
+
+	require.NoError(t, lockC.Release(ctx))
+	_, err = leases.Get(ctx, LockName(info), metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "expected lease to be deleted, got %v", err)
+}
+
+func TestLock_Conflict(t *testing.T) {
+	tests := map[string]struct {
+		conflicts     int
+		expectedError bool
+	}{
+		"conflicts are retried": {
+			conflicts: 2,
+		},
+		"persistent conflicts fail": {
+			conflicts:     100,
+			expectedError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+			defer tf.Cleanup()
+
+			info := NewSingleObjectInfo("test-id",
+				types.NamespacedName{Namespace: testNamespace, Name: inventoryObjName})
+			attempts := 0
+			tf.FakeDynamicClient.PrependReactor("create", "leases", func(clienttesting.Action) (bool, runtime.Object, error) {
+				attempts++
+				if attempts <= tc.conflicts {
+					return true, nil, apierrors.NewAlreadyExists(leaseGVR.GroupResource(), LockName(info))
+				}
+				return false, nil, nil
+			})
+
+			lock, err := AcquireLock(t.Context(), tf.FakeDynamicClient, info, LockOptions{Holder: "a"})
+			if tc.expectedError {
+				require.Error(t, err)
+				assert.True(t, apierrors.IsAlreadyExists(err), "expected AlreadyExists, got %v", err)
+				// The retries are bounded.
+				assert.Equal(t, retry.DefaultBackoff.Steps, attempts)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.conflicts+1, attempts)
+			require.NoError(t, lock.Release(t.Context()))
+		})
+	}
+}
+
+func TestLock_Lost(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+	defer tf.Cleanup()
+
+	info := NewSingleObjectInfo("test-id",
+		types.NamespacedName{Namespace: testNamespace, Name: inventoryObjName})
+	leases := tf.FakeDynamicClient.Resource(leaseGVR).Namespace(testNamespace)
+
+	// The lock is renewed every second.
+	lock, err := AcquireLock(t.Context(), tf.FakeDynamicClient, info, LockOptions{
+		Holder:       "a",
+		StealTimeout: 3 * time.Second,
+	})
+	require.NoError(t, err)
+	assert.NoError(t, lock.Err())
+
+	// Someone else takes over the lock.
+	obj, err := leases.Get(t.Context(), LockName(info), metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "b", "spec", "holderIdentity"))
+	_, err = leases.Update(t.Context(), obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case <-lock.Lost():
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the lock to be lost")
+	}
+	var lostErr *LockLostError
+	require.True(t, errors.As(lock.Err(), &lostErr), "expected LockLostError, got %v", lock.Err())
+	var heldErr *LockHeldError
+	require.True(t, errors.As(lostErr, &heldErr), "expected LockHeldError, got %v", lostErr.Err)
+	assert.Equal(t, "b", heldErr.Holder)
+	assert.Contains(t, lostErr.Error(), "lost inventory lock")
+
+	// Releasing a lost lock leaves it alone.
+	require.NoError(t, lock.Release(t.Context()))
+	_, err = leases.Get(t.Context(), LockName(info), metav1.GetOptions{})
+	assert.NoError(t, err)
+}
@@ -5,6 +5,8 @@ package inventory
 
 import (
 	"fmt"
+	"slices"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -13,7 +15,10 @@ import (
 )
 
 // Manager wraps an Inventory with convenience methods that use ObjMetadata.
+// Manager is safe for concurrent use by tasks that run in parallel.
 type Manager struct {
+	// mu protects the inventory
+	mu        sync.RWMutex
 	inventory InventoryContents
 }
 
@@ -24,14 +29,30 @@ func NewManager() *Manager {
 	}
 }
 
-// Inventory returns the in-memory version of the managed inventory.
+// Inventory returns a copy of the in-memory version of the managed inventory.
 func (tc *Manager) Inventory() InventoryContents {
-	return tc.inventory
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return InventoryContents{
+		ObjectRefs:     slices.Clone(tc.inventory.ObjectRefs),
+		ObjectStatuses: slices.Clone(tc.inventory.ObjectStatuses),
+	}
 }
 
-// ObjectStatus retrieves the status of an object with the specified ID.
-// The returned status is a pointer and can be updated in-place for efficiency.
+// ObjectStatus retrieves a copy of the status of an object with the
+// specified ID. Use SetObjectStatus to update it.
 func (tc *Manager) ObjectStatus(id object.ObjMetadata) (*actuation.ObjectStatus, bool) {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
+	if !found {
+		return nil, false
+	}
+	statusCopy := *objStatus
+	return &statusCopy, true
+}
+
+func (tc *Manager) objectStatus(id object.ObjMetadata) (*actuation.ObjectStatus, bool) {
 	ref := ObjectReferenceFromObjMetadata(id)
 	for i, objStatus := range tc.inventory.ObjectStatuses {
 		if objStatus.ObjectReference == ref {
@@ -44,6 +65,12 @@ func (tc *Manager) ObjectStatus(id object.ObjMetadata) (*actuation.ObjectStatus,
 // ObjectsWithActuationStatus retrieves the set of objects with the
 // specified actuation strategy and status.
 func (tc *Manager) ObjectsWithActuationStatus(strategy actuation.ActuationStrategy, status actuation.ActuationStatus) object.ObjMetadataSet {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(strategy, status)
+}
+
+func (tc *Manager) objectsWithActuationStatus(strategy actuation.ActuationStrategy, status actuation.ActuationStatus) object.ObjMetadataSet {
 	var ids object.ObjMetadataSet
 	for _, objStatus := range tc.inventory.ObjectStatuses {
 		if objStatus.Strategy == strategy && objStatus.Actuation == status {
@@ -56,6 +83,12 @@ func (tc *Manager) ObjectsWithActuationStatus(strategy actuation.ActuationStrate
 // ObjectsWithActuationStatus retrieves the set of objects with the
 // specified reconcile status, regardless of actuation strategy.
 func (tc *Manager) ObjectsWithReconcileStatus(status actuation.ReconcileStatus) object.ObjMetadataSet {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithReconcileStatus(status)
+}
+
+func (tc *Manager) objectsWithReconcileStatus(status actuation.ReconcileStatus) object.ObjMetadataSet {
 	var ids object.ObjMetadataSet
 	for _, objStatus := range tc.inventory.ObjectStatuses {
 		if objStatus.Reconcile == status {
@@ -67,6 +100,12 @@ func (tc *Manager) ObjectsWithReconcileStatus(status actuation.ReconcileStatus)
 
 // SetObjectStatus updates or adds an ObjectStatus record to the inventory.
 func (tc *Manager) SetObjectStatus(newObjStatus actuation.ObjectStatus) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(newObjStatus)
+}
+
+func (tc *Manager) setObjectStatus(newObjStatus actuation.ObjectStatus) {
 	for i, oldObjStatus := range tc.inventory.ObjectStatuses {
 		if oldObjStatus.ObjectReference == newObjStatus.ObjectReference {
 			tc.inventory.ObjectStatuses[i] = newObjStatus
@@ -78,7 +117,9 @@ func (tc *Manager) SetObjectStatus(newObjStatus actuation.ObjectStatus) {
 
 // IsSuccessfulApply returns true if the object apply was successful
 func (tc *Manager) IsSuccessfulApply(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -90,7 +131,9 @@ func (tc *Manager) IsSuccessfulApply(id object.ObjMetadata) bool {
 // resource identified by the provided id. Currently, we keep information
 // about the generation of the resource after the apply operation completed.
 func (tc *Manager) AddSuccessfulApply(id object.ObjMetadata, uid types.UID, gen int64) {
-	tc.SetObjectStatus(actuation.ObjectStatus{
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(actuation.ObjectStatus{
 		ObjectReference: ObjectReferenceFromObjMetadata(id),
 		Strategy:        actuation.ActuationStrategyApply,
 		Actuation:       actuation.ActuationSucceeded,
@@ -103,21 +146,29 @@ func (tc *Manager) AddSuccessfulApply(id object.ObjMetadata, uid types.UID, gen
 // SuccessfulApplies returns all the objects (as ObjMetadata) that
 // were added as applied resources to the Manager.
 func (tc *Manager) SuccessfulApplies() object.ObjMetadataSet {
-	return tc.ObjectsWithActuationStatus(actuation.ActuationStrategyApply,
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(actuation.ActuationStrategyApply,
 		actuation.ActuationSucceeded)
 }
 
 // AppliedResourceUID looks up the UID of a successfully applied resource
 func (tc *Manager) AppliedResourceUID(id object.ObjMetadata) (types.UID, bool) {
-	objStatus, found := tc.ObjectStatus(id)
-	return objStatus.UID, found &&
-		objStatus.Strategy == actuation.ActuationStrategyApply &&
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
+	if !found {
+		return "", false
+	}
+	return objStatus.UID, objStatus.Strategy == actuation.ActuationStrategyApply &&
 		objStatus.Actuation == actuation.ActuationSucceeded
 }
 
 // AppliedResourceUIDs returns a set with the UIDs of all the
 // successfully applied resources.
 func (tc *Manager) AppliedResourceUIDs() sets.Set[types.UID] { // nolint:staticcheck
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
 	uids := sets.New[types.UID]()
 	for _, objStatus := range tc.inventory.ObjectStatuses {
 		if objStatus.Strategy == actuation.ActuationStrategyApply &&
@@ -133,16 +184,45 @@ func (tc *Manager) AppliedResourceUIDs() sets.Set[types.UID] { // nolint:staticc
 // AppliedGeneration looks up the generation of the given resource
 // after it was applied.
 func (tc *Manager) AppliedGeneration(id object.ObjMetadata) (int64, bool) {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return 0, false
 	}
 	return objStatus.Generation, true
 }
 
+// SetAppliedHash registers the hash of the object configuration that was
+// applied.
+func (tc *Manager) SetAppliedHash(id object.ObjMetadata, hash string) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	objStatus, found := tc.objectStatus(id)
+	if !found {
+		return fmt.Errorf("object not in inventory: %q", id)
+	}
+	objStatus.Hash = hash
+	return nil
+}
+
+// AppliedHash looks up the hash of the object configuration that was
+// applied.
+func (tc *Manager) AppliedHash(id object.ObjMetadata) (string, bool) {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
+	if !found {
+		return "", false
+	}
+	return objStatus.Hash, true
+}
+
 // IsSuccessfulDelete returns true if the object delete was successful
 func (tc *Manager) IsSuccessfulDelete(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -156,7 +236,9 @@ func (tc *Manager) IsSuccessfulDelete(id object.ObjMetadata) bool {
 // object was scheduled to be deleted asynchronously, which might cause further
 // updates by finalizers. The UID will change if the object is re-created.
 func (tc *Manager) AddSuccessfulDelete(id object.ObjMetadata, uid types.UID) {
-	tc.SetObjectStatus(actuation.ObjectStatus{
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(actuation.ObjectStatus{
 		ObjectReference: ObjectReferenceFromObjMetadata(id),
 		Strategy:        actuation.ActuationStrategyDelete,
 		Actuation:       actuation.ActuationSucceeded,
@@ -168,13 +250,17 @@ func (tc *Manager) AddSuccessfulDelete(id object.ObjMetadata, uid types.UID) {
 // SuccessfulDeletes returns all the objects (as ObjMetadata) that
 // were successfully deleted.
 func (tc *Manager) SuccessfulDeletes() object.ObjMetadataSet {
-	return tc.ObjectsWithActuationStatus(actuation.ActuationStrategyDelete,
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(actuation.ActuationStrategyDelete,
 		actuation.ActuationSucceeded)
 }
 
 // IsFailedApply returns true if the object failed to apply
 func (tc *Manager) IsFailedApply(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -184,7 +270,9 @@ func (tc *Manager) IsFailedApply(id object.ObjMetadata) bool {
 
 // AddFailedApply registers that the object failed to apply
 func (tc *Manager) AddFailedApply(id object.ObjMetadata) {
-	tc.SetObjectStatus(actuation.ObjectStatus{
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(actuation.ObjectStatus{
 		ObjectReference: ObjectReferenceFromObjMetadata(id),
 		Strategy:        actuation.ActuationStrategyApply,
 		Actuation:       actuation.ActuationFailed,
@@ -194,12 +282,16 @@ func (tc *Manager) AddFailedApply(id object.ObjMetadata) {
 
 // FailedApplies returns all the objects that failed to apply
 func (tc *Manager) FailedApplies() object.ObjMetadataSet {
-	return tc.ObjectsWithActuationStatus(actuation.ActuationStrategyApply, actuation.ActuationFailed)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(actuation.ActuationStrategyApply, actuation.ActuationFailed)
 }
 
 // IsFailedDelete returns true if the object failed to delete
 func (tc *Manager) IsFailedDelete(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -209,7 +301,9 @@ func (tc *Manager) IsFailedDelete(id object.ObjMetadata) bool {
 
 // AddFailedDelete registers that the object failed to delete
 func (tc *Manager) AddFailedDelete(id object.ObjMetadata) {
-	tc.SetObjectStatus(actuation.ObjectStatus{
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(actuation.ObjectStatus{
 		ObjectReference: ObjectReferenceFromObjMetadata(id),
 		Strategy:        actuation.ActuationStrategyDelete,
 		Actuation:       actuation.ActuationFailed,
@@ -219,13 +313,17 @@ func (tc *Manager) AddFailedDelete(id object.ObjMetadata) {
 
 // FailedDeletes returns all the objects that failed to delete
 func (tc *Manager) FailedDeletes() object.ObjMetadataSet {
-	return tc.ObjectsWithActuationStatus(actuation.ActuationStrategyDelete,
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(actuation.ActuationStrategyDelete,
 		actuation.ActuationFailed)
 }
 
 // IsSkippedApply returns true if the object apply was skipped
 func (tc *Manager) IsSkippedApply(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -235,7 +333,9 @@ func (tc *Manager) IsSkippedApply(id object.ObjMetadata) bool {
 
 // AddSkippedApply registers that the object apply was skipped
 func (tc *Manager) AddSkippedApply(id object.ObjMetadata) {
-	tc.SetObjectStatus(actuation.ObjectStatus{
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(actuation.ObjectStatus{
 		ObjectReference: ObjectReferenceFromObjMetadata(id),
 		Strategy:        actuation.ActuationStrategyApply,
 		Actuation:       actuation.ActuationSkipped,
@@ -245,12 +345,16 @@ func (tc *Manager) AddSkippedApply(id object.ObjMetadata) {
 
 // SkippedApplies returns all the objects where apply was skipped
 func (tc *Manager) SkippedApplies() object.ObjMetadataSet {
-	return tc.ObjectsWithActuationStatus(actuation.ActuationStrategyApply, actuation.ActuationSkipped)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(actuation.ActuationStrategyApply, actuation.ActuationSkipped)
 }
 
 // IsSkippedDelete returns true if the object delete was skipped
 func (tc *Manager) IsSkippedDelete(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -260,7 +364,9 @@ func (tc *Manager) IsSkippedDelete(id object.ObjMetadata) bool {
 
 // AddSkippedDelete registers that the object delete was skipped
 func (tc *Manager) AddSkippedDelete(id object.ObjMetadata) {
-	tc.SetObjectStatus(actuation.ObjectStatus{
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(actuation.ObjectStatus{
 		ObjectReference: ObjectReferenceFromObjMetadata(id),
 		Strategy:        actuation.ActuationStrategyDelete,
 		Actuation:       actuation.ActuationSkipped,
@@ -270,13 +376,17 @@ func (tc *Manager) AddSkippedDelete(id object.ObjMetadata) {
 
 // SkippedDeletes returns all the objects where deletion was skipped
 func (tc *Manager) SkippedDeletes() object.ObjMetadataSet {
-	return tc.ObjectsWithActuationStatus(actuation.ActuationStrategyDelete,
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(actuation.ActuationStrategyDelete,
 		actuation.ActuationSkipped)
 }
 
 // IsSuccessfulReconcile returns true if the object is reconciled
 func (tc *Manager) IsSuccessfulReconcile(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -285,7 +395,9 @@ func (tc *Manager) IsSuccessfulReconcile(id object.ObjMetadata) bool {
 
 // SetSuccessfulReconcile registers that the object is reconciled
 func (tc *Manager) SetSuccessfulReconcile(id object.ObjMetadata) error {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return fmt.Errorf("object not in inventory: %q", id)
 	}
@@ -295,12 +407,16 @@ func (tc *Manager) SetSuccessfulReconcile(id object.ObjMetadata) error {
 
 // SuccessfulReconciles returns all the reconciled objects
 func (tc *Manager) SuccessfulReconciles() object.ObjMetadataSet {
-	return tc.ObjectsWithReconcileStatus(actuation.ReconcileSucceeded)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithReconcileStatus(actuation.ReconcileSucceeded)
 }
 
 // IsFailedReconcile returns true if the object failed to reconcile
 func (tc *Manager) IsFailedReconcile(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -309,7 +425,9 @@ func (tc *Manager) IsFailedReconcile(id object.ObjMetadata) bool {
 
 // SetFailedReconcile registers that the object failed to reconcile
 func (tc *Manager) SetFailedReconcile(id object.ObjMetadata) error {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return fmt.Errorf("object not in inventory: %q", id)
 	}
@@ -319,12 +437,16 @@ func (tc *Manager) SetFailedReconcile(id object.ObjMetadata) error {
 
 // FailedReconciles returns all the objects that failed to reconcile
 func (tc *Manager) FailedReconciles() object.ObjMetadataSet {
-	return tc.ObjectsWithReconcileStatus(actuation.ReconcileFailed)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithReconcileStatus(actuation.ReconcileFailed)
 }
 
 // IsSkippedReconcile returns true if the object reconcile was skipped
 func (tc *Manager) IsSkippedReconcile(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -333,7 +455,9 @@ func (tc *Manager) IsSkippedReconcile(id object.ObjMetadata) bool {
 
 // SetSkippedReconcile registers that the object reconcile was skipped
 func (tc *Manager) SetSkippedReconcile(id object.ObjMetadata) error {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return fmt.Errorf("object not in inventory: %q", id)
 	}
@@ -343,12 +467,16 @@ func (tc *Manager) SetSkippedReconcile(id object.ObjMetadata) error {
 
 // SkippedReconciles returns all the objects where reconcile was skipped
 func (tc *Manager) SkippedReconciles() object.ObjMetadataSet {
-	return tc.ObjectsWithReconcileStatus(actuation.ReconcileSkipped)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithReconcileStatus(actuation.ReconcileSkipped)
 }
 
 // IsTimeoutReconcile returns true if the object reconcile was skipped
 func (tc *Manager) IsTimeoutReconcile(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -357,7 +485,9 @@ func (tc *Manager) IsTimeoutReconcile(id object.ObjMetadata) bool {
 
 // SetTimeoutReconcile registers that the object reconcile was skipped
 func (tc *Manager) SetTimeoutReconcile(id object.ObjMetadata) error {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return fmt.Errorf("object not in inventory: %q", id)
 	}
@@ -367,12 +497,16 @@ func (tc *Manager) SetTimeoutReconcile(id object.ObjMetadata) error {
 
 // TimeoutReconciles returns all the objects where reconcile was skipped
 func (tc *Manager) TimeoutReconciles() object.ObjMetadataSet {
-	return tc.ObjectsWithReconcileStatus(actuation.ReconcileTimeout)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithReconcileStatus(actuation.ReconcileTimeout)
 }
 
 // IsPendingReconcile returns true if the object reconcile is pending
 func (tc *Manager) IsPendingReconcile(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -381,7 +515,9 @@ func (tc *Manager) IsPendingReconcile(id object.ObjMetadata) bool {
 
 // SetPendingReconcile registers that the object reconcile is pending
 func (tc *Manager) SetPendingReconcile(id object.ObjMetadata) error {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return fmt.Errorf("object not in inventory: %q", id)
 	}
@@ -391,12 +527,16 @@ func (tc *Manager) SetPendingReconcile(id object.ObjMetadata) error {
 
 // PendingReconciles returns all the objects where reconcile is pending
 func (tc *Manager) PendingReconciles() object.ObjMetadataSet {
-	return tc.ObjectsWithReconcileStatus(actuation.ReconcilePending)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithReconcileStatus(actuation.ReconcilePending)
 }
 
 // IsPendingApply returns true if the object pending apply
 func (tc *Manager) IsPendingApply(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -406,7 +546,9 @@ func (tc *Manager) IsPendingApply(id object.ObjMetadata) bool {
 
 // AddPendingApply registers that the object is pending apply
 func (tc *Manager) AddPendingApply(id object.ObjMetadata) {
-	tc.SetObjectStatus(actuation.ObjectStatus{
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(actuation.ObjectStatus{
 		ObjectReference: ObjectReferenceFromObjMetadata(id),
 		Strategy:        actuation.ActuationStrategyApply,
 		Actuation:       actuation.ActuationPending,
@@ -416,13 +558,17 @@ func (tc *Manager) AddPendingApply(id object.ObjMetadata) {
 
 // PendingApplies returns all the objects that are pending apply
 func (tc *Manager) PendingApplies() object.ObjMetadataSet {
-	return tc.ObjectsWithActuationStatus(actuation.ActuationStrategyApply,
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(actuation.ActuationStrategyApply,
 		actuation.ActuationPending)
 }
 
 // IsPendingDelete returns true if the object pending delete
 func (tc *Manager) IsPendingDelete(id object.ObjMetadata) bool {
-	objStatus, found := tc.ObjectStatus(id)
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	objStatus, found := tc.objectStatus(id)
 	if !found {
 		return false
 	}
@@ -432,7 +578,9 @@ func (tc *Manager) IsPendingDelete(id object.ObjMetadata) bool {
 
 // AddPendingDelete registers that the object is pending delete
 func (tc *Manager) AddPendingDelete(id object.ObjMetadata) {
-	tc.SetObjectStatus(actuation.ObjectStatus{
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setObjectStatus(actuation.ObjectStatus{
 		ObjectReference: ObjectReferenceFromObjMetadata(id),
 		Strategy:        actuation.ActuationStrategyDelete,
 		Actuation:       actuation.ActuationPending,
@@ -442,6 +590,8 @@ func (tc *Manager) AddPendingDelete(id object.ObjMetadata) {
 
 // PendingDeletes returns all the objects that are pending delete
 func (tc *Manager) PendingDeletes() object.ObjMetadataSet {
-	return tc.ObjectsWithActuationStatus(actuation.ActuationStrategyDelete,
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.objectsWithActuationStatus(actuation.ActuationStrategyDelete,
 		actuation.ActuationPending)
 }
@@ -53,15 +53,9 @@ func TestObjectStatusGetSet(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, &inStatus2, outStatus)
 
-	// Test get after set via returned pointer
+	// Test the returned status is a copy
 	outStatus.Reconcile = actuation.ReconcileFailed
 	outStatus, found = manager.ObjectStatus(id)
 	require.True(t, found)
-	expStatus := actuation.ObjectStatus{
-		ObjectReference: ObjectReferenceFromObjMetadata(id),
-		Strategy:        actuation.ActuationStrategyApply,
-		Actuation:       actuation.ActuationSucceeded,
-		Reconcile:       actuation.ReconcileFailed,
-	}
-	require.Equal(t, &expStatus, outStatus)
+	require.Equal(t, &inStatus2, outStatus)
 }
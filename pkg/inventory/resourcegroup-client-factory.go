@@ -0,0 +1,44 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+var (
+	_ ClientFactory = ResourceGroupClientFactory{}
+)
+
+// ResourceGroupClientFactory is a factory that creates instances of inventory
+// clients which are backed by ResourceGroup custom resources. The
+// ResourceGroup CRD must be installed in the cluster.
+type ResourceGroupClientFactory struct {
+	// StatusEnabled enables persisting the object statuses to the
+	// ResourceGroup status subresource.
+	StatusEnabled bool
+	// MaxObjectsPerShard is the maximum number of object references to store
+	// in each inventory object, before splitting the inventory across
+	// multiple objects. If zero, the inventory is not sharded.
+	MaxObjectsPerShard int
+}
+
+func (rcf ResourceGroupClientFactory) NewClient(factory cmdutil.Factory) (Client, error) {
+	var toStatus ToUnstructuredFunc
+	if rcf.StatusEnabled {
+		toStatus = inventoryToResourceGroupStatus
+	}
+	client, err := NewUnstructuredClient(factory,
+		resourceGroupToInventory(rcf.StatusEnabled),
+		inventoryToResourceGroup,
+		toStatus, ResourceGroupGVK, WithMaxObjectsPerShard(rcf.MaxObjectsPerShard))
+	if meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("the ResourceGroup CRD is not installed "+
+			"(print it with `kapply init --print-crd`): %w", err)
+	}
+	return client, err
+}
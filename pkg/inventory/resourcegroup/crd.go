@@ -0,0 +1,94 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcegroup
+
+// ResourceGroupCRD is the CustomResourceDefinition manifest for the
+// ResourceGroup inventory object. It must be installed in the cluster before
+// using the ResourceGroup inventory client.
+const ResourceGroupCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: resourcegroups.cli-utils.sigs.k8s.io
+spec:
+  group: cli-utils.sigs.k8s.io
+  names:
+    kind: ResourceGroup
+    listKind: ResourceGroupList
+    plural: resourcegroups
+    singular: resourcegroup
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    subresources:
+      status: {}
+    schema:
+      openAPIV3Schema:
+        description: ResourceGroup is an inventory of applied objects.
+        type: object
+        properties:
+          apiVersion:
+            type: string
+          kind:
+            type: string
+          metadata:
+            type: object
+          spec:
+            description: The objects in the inventory.
+            type: object
+            properties:
+              objects:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    group:
+                      type: string
+                    kind:
+                      type: string
+                    namespace:
+                      type: string
+                    name:
+                      type: string
+                  required:
+                  - kind
+                  - name
+          status:
+            description: The actuation and reconcile status of the objects.
+            type: object
+            properties:
+              observedGeneration:
+                type: integer
+                format: int64
+              objects:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    group:
+                      type: string
+                    kind:
+                      type: string
+                    namespace:
+                      type: string
+                    name:
+                      type: string
+                    strategy:
+                      type: string
+                    actuation:
+                      type: string
+                    reconcile:
+                      type: string
+                    uid:
+                      type: string
+                    generation:
+                      type: integer
+                      format: int64
+                    hash:
+                      type: string
+                  required:
+                  - kind
+                  - name
+`
@@ -0,0 +1,268 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// revisionKeyPrefix is the prefix of the ConfigMap binaryData keys used to
+// store revisions. The key suffix is the revision number.
+const revisionKeyPrefix = "revision-"
+
+// secretDataHashAnnotation replaces the data of the Secrets stored in a
+// revision, which are not stored in the inventory. The value is a hash of the
+// data, which is compared to the live Secret when rolling back.
+const secretDataHashAnnotation = "config.k8s.io/secret-data-hash"
+
+var secretGK = schema.GroupKind{Kind: "Secret"}
+
+// Revision is a snapshot of the objects applied by a previous apply, which
+// can be applied again to roll back to it.
+type Revision struct {
+	// Number identifies the revision. It is incremented by every apply.
+	Number int64 `json:"number"`
+	// Time is when the revision was applied.
+	Time metav1.Time `json:"time"`
+	// Succeeded is true if all the objects were successfully applied and
+	// reconciled.
+	Succeeded bool `json:"succeeded"`
+	// Objects are the manifests of the applied objects. The data of Secrets
+	// is replaced with a hash, see RestoreSecretData.
+	Objects object.UnstructuredSet `json:"objects"`
+}
+
+// RevisionInventory is an Inventory that also stores the revisions of
+// previous applies, ordered from oldest to newest. Inventory clients decide
+// how many revisions are persisted.
+type RevisionInventory interface {
+	Inventory
+	// GetRevisions returns the list of revisions stored in the inventory.
+	GetRevisions() []Revision
+	// SetRevisions updates the local cache of revisions stored in the inventory.
+	// This will be persisted to the cluster when the Inventory is passed to CreateOrUpdate.
+	SetRevisions([]Revision)
+}
+
+// NewRevision returns the revision that follows the specified revisions,
+// with a deep copy of the specified objects. The data of Secrets is replaced
+// with a hash, so it is not stored in the inventory.
+func NewRevision(revisions []Revision, objs object.UnstructuredSet, succeeded bool) Revision {
+	var number int64 = 1
+	if len(revisions) > 0 {
+		number = revisions[len(revisions)-1].Number + 1
+	}
+	revisionObjs := make(object.UnstructuredSet, 0, len(objs))
+	for _, obj := range objs {
+		revisionObj, err := copyObject(obj)
+		if err != nil {
+			klog.Warningf("failed to add object to revision %d: %v", number, err)
+			continue
+		}
+		object.StripKyamlAnnotations(revisionObj)
+		if object.UnstructuredToObjMetadata(revisionObj).GroupKind == secretGK {
+			redactSecretData(revisionObj)
+		}
+		revisionObjs = append(revisionObjs, revisionObj)
+	}
+	return Revision{
+		Number:    number,
+		Time:      metav1.Now(),
+		Succeeded: succeeded,
+		Objects:   revisionObjs,
+	}
+}
+
+// copyObject returns a deep copy of the object. Unlike DeepCopy, it supports
+// objects with values that are not JSON types, like int, by encoding them to
+// JSON and back, which is how the revisions are stored anyway.
+func copyObject(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+	var content map[string]any
+	if err := utiljson.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// redactSecretData replaces the data and stringData of the Secret with a hash
+// of its data. If the data can't be hashed, the hash is empty, so the Secret
+// can't be rolled back.
+func redactSecretData(obj *unstructured.Unstructured) {
+	hash, err := secretDataHash(obj)
+	if err != nil {
+		klog.Warningf("failed to hash the data of %s: %v", object.UnstructuredToObjMetadata(obj), err)
+	}
+	unstructured.RemoveNestedField(obj.Object, "data")
+	unstructured.RemoveNestedField(obj.Object, "stringData")
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[secretDataHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+}
+
+// secretDataHash returns a hex encoded SHA-256 hash of the data of the
+// Secret, with the stringData merged into the data, the way the API server
+// stores it.
+func secretDataHash(obj *unstructured.Unstructured) (string, error) {
+	encoded, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		return "", err
+	}
+	data := make(map[string][]byte, len(encoded))
+	for key, value := range encoded {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid data %q: %w", key, err)
+		}
+		data[key] = decoded
+	}
+	stringData, _, err := unstructured.NestedStringMap(obj.Object, "stringData")
+	if err != nil {
+		return "", err
+	}
+	for key, value := range stringData {
+		data[key] = []byte(value)
+	}
+	// Map keys are sorted by json.Marshal, so the output is deterministic.
+	encodedData, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encodedData)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HasRedactedSecretData returns true if the object is a Secret from a
+// revision, without its data.
+func HasRedactedSecretData(obj *unstructured.Unstructured) bool {
+	_, found := obj.GetAnnotations()[secretDataHashAnnotation]
+	return found
+}
+
+// RestoreSecretData copies the data of the live Secret to the Secret from a
+// revision, if the data has not changed since the revision was applied.
+// Otherwise the data of the revision is unknown, and an error is returned.
+func RestoreSecretData(obj *unstructured.Unstructured, liveObj *unstructured.Unstructured) error {
+	hash := obj.GetAnnotations()[secretDataHashAnnotation]
+	liveHash, err := secretDataHash(liveObj)
+	if err != nil {
+		return fmt.Errorf("failed to hash the data of the live object: %w", err)
+	}
+	if hash == "" || hash != liveHash {
+		return fmt.Errorf("the Secret data is not stored in revisions, and has changed since the revision was applied")
+	}
+	if data, found, _ := unstructured.NestedFieldCopy(liveObj.Object, "data"); found {
+		if err := unstructured.SetNestedField(obj.Object, data, "data"); err != nil {
+			return err
+		}
+	}
+	annotations := obj.GetAnnotations()
+	delete(annotations, secretDataHashAnnotation)
+	if len(annotations) == 0 {
+		// Remove the annotations added by redactSecretData.
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// FindRevision returns the revision with the specified number.
+// If the number is zero, FindRevision returns the newest successful revision
+// before the latest revision, which is the one to roll back to if the latest
+// apply failed or introduced a problem.
+func FindRevision(revisions []Revision, number int64) (Revision, error) {
+	if len(revisions) == 0 {
+		return Revision{}, fmt.Errorf("inventory has no revisions")
+	}
+	if number == 0 {
+		for i := len(revisions) - 2; i >= 0; i-- {
+			if revisions[i].Succeeded {
+				return revisions[i], nil
+			}
+		}
+		return Revision{}, fmt.Errorf("inventory has no successful revision before revision %d",
+			revisions[len(revisions)-1].Number)
+	}
+	for _, revision := range revisions {
+		if revision.Number == number {
+			return revision, nil
+		}
+	}
+	return Revision{}, fmt.Errorf("inventory revision not found: %d", number)
+}
+
+// buildRevisionMap converts the newest revisions, up to the specified limit,
+// to the storage format to be used in the ConfigMap binaryData.
+func buildRevisionMap(revisions []Revision, limit int) (map[string]string, error) {
+	if len(revisions) > limit {
+		revisions = revisions[len(revisions)-limit:]
+	}
+	revisionMap := make(map[string]string, len(revisions))
+	for _, revision := range revisions {
+		data, err := json.Marshal(revision)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode revision %d: %w", revision.Number, err)
+		}
+		value, err := compress(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode revision %d: %w", revision.Number, err)
+		}
+		revisionMap[revisionKeyPrefix+strconv.FormatInt(revision.Number, 10)] = value
+	}
+	return revisionMap, nil
+}
+
+// parseRevisionMap converts the revisions from the ConfigMap binaryData
+// storage format, ignoring any other keys. The revisions are sorted by number.
+func parseRevisionMap(binaryData map[string]string) ([]Revision, error) {
+	var revisions []Revision
+	for key, value := range binaryData {
+		if !isRevisionKey(key) {
+			continue
+		}
+		data, err := decompress(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		var revision Revision
+		if err := json.Unmarshal(data, &revision); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		revisions = append(revisions, revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+	return revisions, nil
+}
+
+// isRevisionKey returns true if the ConfigMap binaryData key stores a revision.
+func isRevisionKey(key string) bool {
+	suffix, found := strings.CutPrefix(key, revisionKeyPrefix)
+	if !found {
+		return false
+	}
+	_, err := strconv.ParseInt(suffix, 10, 64)
+	return err == nil
+}
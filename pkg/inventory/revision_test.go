@@ -0,0 +1,232 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+)
+
+func newTestRevision(number int64, succeeded bool, objs ...*unstructured.Unstructured) Revision {
+	return Revision{
+		Number:    number,
+		Time:      metav1.Unix(1700000000+number, 0),
+		Succeeded: succeeded,
+		Objects:   objs,
+	}
+}
+
+func TestNewRevision(t *testing.T) {
+	obj := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: default
+  annotations:
+    internal.config.kubernetes.io/path: pod.yaml
+`)
+
+	revision := NewRevision(nil, object.UnstructuredSet{obj}, true)
+	assert.Equal(t, int64(1), revision.Number)
+	assert.True(t, revision.Succeeded)
+	require.Len(t, revision.Objects, 1)
+	// kyaml annotations are removed from the copy only
+	assert.NotContains(t, revision.Objects[0].GetAnnotations(), kioutil.PathAnnotation)
+	assert.Contains(t, obj.GetAnnotations(), kioutil.PathAnnotation)
+
+	revision = NewRevision([]Revision{newTestRevision(4, true), newTestRevision(7, false)},
+		object.UnstructuredSet{obj}, false)
+	assert.Equal(t, int64(8), revision.Number)
+	assert.False(t, revision.Succeeded)
+}
+
+func TestFindRevision(t *testing.T) {
+	tests := map[string]struct {
+		revisions []Revision
+		number    int64
+		expected  int64
+		hasError  bool
+	}{
+		"no revisions": {
+			hasError: true,
+		},
+		"previous revision": {
+			revisions: []Revision{
+				newTestRevision(1, true),
+				newTestRevision(2, true),
+				newTestRevision(3, true),
+			},
+			expected: 2,
+		},
+		"previous successful revision": {
+			revisions: []Revision{
+				newTestRevision(1, true),
+				newTestRevision(2, false),
+				newTestRevision(3, false),
+			},
+			expected: 1,
+		},
+		"no previous successful revision": {
+			revisions: []Revision{
+				newTestRevision(1, false),
+				newTestRevision(2, true),
+			},
+			hasError: true,
+		},
+		"specific revision": {
+			revisions: []Revision{
+				newTestRevision(1, true),
+				newTestRevision(2, false),
+				newTestRevision(3, true),
+			},
+			number:   2,
+			expected: 2,
+		},
+		"missing revision": {
+			revisions: []Revision{
+				newTestRevision(1, true),
+			},
+			number:   2,
+			hasError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			revision, err := FindRevision(tc.revisions, tc.number)
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, revision.Number)
+		})
+	}
+}
+
+func TestConfigMapRevisions(t *testing.T) {
+	pod := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: default
+`)
+	revisions := []Revision{
+		newTestRevision(1, true, pod),
+		newTestRevision(2, false, pod),
+		newTestRevision(3, true, pod),
+	}
+
+	tests := map[string]struct {
+		limit    int
+		expected []Revision
+	}{
+		"revisions disabled": {
+			limit: 0,
+		},
+		"all revisions kept": {
+			limit:    5,
+			expected: revisions,
+		},
+		"oldest revisions removed": {
+			limit:    2,
+			expected: revisions[1:],
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cm := emptyInventoryObject()
+			// Unrelated binaryData keys are preserved
+			require.NoError(t, unstructured.SetNestedStringMap(cm.Object,
+				map[string]string{"other": "dGVzdA=="}, "binaryData"))
+
+			inv := NewSingleObjectInventory(cm)
+			inv.SetRevisions(revisions)
+			cm, err := inventoryToConfigMap(true, tc.limit, false)(cm, inv)
+			require.NoError(t, err)
+
+			binaryData, _, err := unstructured.NestedStringMap(cm.Object, "binaryData")
+			require.NoError(t, err)
+			assert.Equal(t, "dGVzdA==", binaryData["other"])
+			assert.Len(t, binaryData, len(tc.expected)+1)
+
+			actual, err := configMapToInventory(true, tc.limit > 0)(cm)
+			require.NoError(t, err)
+			testutil.AssertEqual(t, tc.expected, actual.GetRevisions())
+		})
+	}
+}
+
+func TestSecretRevisionData(t *testing.T) {
+	secret := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: default
+data:
+  password: c2VjcmV0LXBhc3N3b3Jk
+stringData:
+  token: secret-token
+`)
+	// The API server merges stringData into data.
+	liveSecret := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: default
+  resourceVersion: "1"
+data:
+  password: c2VjcmV0LXBhc3N3b3Jk
+  token: c2VjcmV0LXRva2Vu
+`)
+
+	revision := NewRevision(nil, object.UnstructuredSet{secret}, true)
+	require.Len(t, revision.Objects, 1)
+	assert.NotContains(t, revision.Objects[0].Object, "data")
+	assert.NotContains(t, revision.Objects[0].Object, "stringData")
+	assert.True(t, HasRedactedSecretData(revision.Objects[0]))
+	// The data is removed from the copy only
+	assert.Contains(t, secret.Object, "data")
+
+	// No Secret data is stored in the ConfigMap
+	inv := NewSingleObjectInventory(emptyInventoryObject())
+	inv.SetRevisions([]Revision{revision})
+	cm, err := inventoryToConfigMap(true, 1, false)(emptyInventoryObject(), inv)
+	require.NoError(t, err)
+	binaryData, _, err := unstructured.NestedStringMap(cm.Object, "binaryData")
+	require.NoError(t, err)
+	require.Len(t, binaryData, 1)
+	for _, value := range binaryData {
+		data, err := decompress(value)
+		require.NoError(t, err)
+		for _, secretValue := range []string{"secret-password", "c2VjcmV0LXBhc3N3b3Jk", "secret-token", "c2VjcmV0LXRva2Vu"} {
+			assert.NotContains(t, string(data), secretValue)
+		}
+	}
+
+	// The data is restored from the live Secret, if unchanged
+	restored := revision.Objects[0].DeepCopy()
+	require.NoError(t, RestoreSecretData(restored, liveSecret))
+	assert.False(t, HasRedactedSecretData(restored))
+	data, _, err := unstructured.NestedStringMap(restored.Object, "data")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"password": "c2VjcmV0LXBhc3N3b3Jk",
+		"token":    "c2VjcmV0LXRva2Vu",
+	}, data)
+
+	// Changed data can't be restored
+	require.NoError(t, unstructured.SetNestedField(liveSecret.Object, "Y2hhbmdlZA==", "data", "token"))
+	require.Error(t, RestoreSecretData(revision.Objects[0].DeepCopy(), liveSecret))
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+var (
+	_ ClientFactory = SecretClientFactory{}
+)
+
+// SecretClientFactory is a factory that creates instances of inventory clients
+// which are backed by Secrets.
+type SecretClientFactory struct {
+	StatusEnabled bool
+	// RevisionHistoryLimit is the number of revisions of applied objects to
+	// keep in the inventory, which can be used to roll back. If zero,
+	// revisions are not stored.
+	RevisionHistoryLimit int
+	// MaxObjectsPerShard is the maximum number of object references to store
+	// in each inventory object, before splitting the inventory across
+	// multiple objects. If zero, the inventory is not sharded.
+	MaxObjectsPerShard int
+	// CompactEncoding stores the object references and statuses as a single
+	// compressed value, instead of one key per object. Inventories in either
+	// encoding can always be read.
+	CompactEncoding bool
+}
+
+func (scf SecretClientFactory) NewClient(factory cmdutil.Factory) (Client, error) {
+	revisionsEnabled := scf.RevisionHistoryLimit > 0
+	return NewUnstructuredClient(factory,
+		secretToInventory(scf.StatusEnabled, revisionsEnabled),
+		inventoryToSecret(scf.StatusEnabled, scf.RevisionHistoryLimit, scf.CompactEncoding),
+		nil, SecretGVK, WithMaxObjectsPerShard(scf.MaxObjectsPerShard))
+}
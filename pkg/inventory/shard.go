@@ -0,0 +1,155 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+//
+// Introduces inventory sharding, which splits the contents of an inventory
+// across multiple objects, to avoid exceeding the maximum object size when
+// the inventory tracks many objects. The first shard is the inventory object
+// itself, which links to the other shards with annotations.
+
+package inventory
+
+import (
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// ShardLabel is the label set on inventory shard objects. Its value is
+	// the inventory ID. Shard objects do not have the inventory label, so
+	// they are not mistaken for inventory objects.
+	ShardLabel = "cli-utils.sigs.k8s.io/inventory-shard-of"
+	// shardCountAnnotation is the number of shards, including the inventory
+	// object itself. It is only set on the inventory object.
+	shardCountAnnotation = "cli-utils.sigs.k8s.io/inventory-shard-count"
+	// shardEpochAnnotation is incremented every time the shards are
+	// written. Shards of each epoch are written to new objects, before the
+	// inventory object is updated to link to them, so readers always see a
+	// complete set of shards.
+	shardEpochAnnotation = "cli-utils.sigs.k8s.io/inventory-shard-epoch"
+)
+
+// shardRefs describes the shards linked from an inventory object.
+type shardRefs struct {
+	epoch int64
+	count int
+}
+
+// names returns the names of the shard objects, excluding the inventory
+// object itself.
+func (s shardRefs) names(invName string) []string {
+	var names []string
+	for i := 1; i < s.count; i++ {
+		names = append(names, fmt.Sprintf("%s-shard-%d-%d", invName, s.epoch, i))
+	}
+	return names
+}
+
+// readShardRefs returns the shards linked from the inventory object. An
+// inventory object without shard annotations has a single shard.
+func readShardRefs(obj *unstructured.Unstructured) (shardRefs, error) {
+	refs := shardRefs{count: 1}
+	annotations := obj.GetAnnotations()
+	if value, found := annotations[shardCountAnnotation]; found {
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 1 {
+			return refs, fmt.Errorf("invalid annotation %s: %q", shardCountAnnotation, value)
+		}
+		refs.count = count
+	}
+	if value, found := annotations[shardEpochAnnotation]; found {
+		epoch, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return refs, fmt.Errorf("invalid annotation %s: %q", shardEpochAnnotation, value)
+		}
+		refs.epoch = epoch
+	}
+	return refs, nil
+}
+
+// writeShardRefs sets the shard annotations on the inventory object. The
+// annotations are removed if there is only one shard.
+func writeShardRefs(obj *unstructured.Unstructured, refs shardRefs) {
+	annotations := obj.GetAnnotations()
+	if refs.count <= 1 {
+		delete(annotations, shardCountAnnotation)
+		delete(annotations, shardEpochAnnotation)
+	} else {
+		if annotations == nil {
+			annotations = make(map[string]string, 2)
+		}
+		annotations[shardCountAnnotation] = strconv.Itoa(refs.count)
+		annotations[shardEpochAnnotation] = strconv.FormatInt(refs.epoch, 10)
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// markShardObject replaces the inventory label of a shard object with the
+// shard label, and makes the inventory object its owner, so the shard is
+// garbage collected if the inventory object is deleted.
+func markShardObject(obj *unstructured.Unstructured, id ID, owner *unstructured.Unstructured) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	delete(labels, common.InventoryLabel)
+	labels[ShardLabel] = id.String()
+	obj.SetLabels(labels)
+	obj.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: owner.GetAPIVersion(),
+		Kind:       owner.GetKind(),
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+	}})
+}
+
+// splitInventory splits the inventory into shards of up to maxObjects object
+// references each, along with their object statuses. The first shard is the
+// inventory object itself, which keeps the revisions. The others are named
+// after the inventory object and the epoch.
+func splitInventory(inv *SingleObjectInventory, maxObjects int, epoch int64) []*SingleObjectInventory {
+	objRefs := inv.GetObjectRefs()
+	count := 1
+	if maxObjects > 0 && len(objRefs) > maxObjects {
+		count = (len(objRefs) + maxObjects - 1) / maxObjects
+	}
+	if count == 1 {
+		return []*SingleObjectInventory{inv}
+	}
+	statuses := make(map[object.ObjMetadata]int, len(inv.GetObjectStatuses()))
+	for i, status := range inv.GetObjectStatuses() {
+		statuses[ObjMetadataFromObjectReference(status.ObjectReference)] = i
+	}
+	names := shardRefs{epoch: epoch, count: count}.names(inv.GetName())
+	shards := make([]*SingleObjectInventory, count)
+	for i := range shards {
+		start := i * maxObjects
+		end := min(start+maxObjects, len(objRefs))
+		shard := &SingleObjectInventory{SingleObjectInfo: inv.SingleObjectInfo}
+		if i > 0 {
+			shard.SingleObjectInfo = *NewSingleObjectInfo(inv.GetID(),
+				types.NamespacedName{Namespace: inv.GetNamespace(), Name: names[i-1]})
+		} else {
+			shard.Revisions = inv.GetRevisions()
+		}
+		shard.ObjectRefs = objRefs[start:end]
+		for _, id := range shard.ObjectRefs {
+			if j, found := statuses[id]; found {
+				shard.ObjectStatuses = append(shard.ObjectStatuses, inv.GetObjectStatuses()[j])
+			}
+		}
+		shards[i] = shard
+	}
+	return shards
+}
+
+// mergeShard appends the contents of a shard to the inventory.
+func mergeShard(inv *SingleObjectInventory, shard *SingleObjectInventory) {
+	inv.ObjectRefs = append(inv.ObjectRefs, shard.GetObjectRefs()...)
+	inv.ObjectStatuses = append(inv.ObjectStatuses, shard.GetObjectStatuses()...)
+}
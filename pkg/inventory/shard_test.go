@@ -0,0 +1,206 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestSplitInventory(t *testing.T) {
+	objs := object.ObjMetadataSet{
+		toObjMeta(t, pod1Info),
+		toObjMeta(t, pod2Info),
+		toObjMeta(t, pod3Info),
+	}
+	statuses := object.ObjectStatusSet{
+		podStatus(t, pod1Info),
+		podStatus(t, pod3Info),
+	}
+	tests := map[string]struct {
+		maxObjects       int
+		expectedRefs     []object.ObjMetadataSet
+		expectedStatuses []object.ObjectStatusSet
+		expectedNames    []string
+	}{
+		"sharding disabled": {
+			maxObjects:       0,
+			expectedRefs:     []object.ObjMetadataSet{objs},
+			expectedStatuses: []object.ObjectStatusSet{statuses},
+			expectedNames:    []string{inventoryObjName},
+		},
+		"objects fit in one shard": {
+			maxObjects:       3,
+			expectedRefs:     []object.ObjMetadataSet{objs},
+			expectedStatuses: []object.ObjectStatusSet{statuses},
+			expectedNames:    []string{inventoryObjName},
+		},
+		"objects split across shards": {
+			maxObjects: 2,
+			expectedRefs: []object.ObjMetadataSet{
+				{objs[0], objs[1]},
+				{objs[2]},
+			},
+			expectedStatuses: []object.ObjectStatusSet{
+				{statuses[0]},
+				{statuses[1]},
+			},
+			expectedNames: []string{
+				inventoryObjName,
+				inventoryObjName + "-shard-7-1",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			inv := NewSingleObjectInventory(emptyInventoryObject())
+			inv.SetObjectRefs(objs)
+			inv.SetObjectStatuses(statuses)
+			inv.SetRevisions([]Revision{newTestRevision(1, true)})
+
+			shards := splitInventory(inv, tc.maxObjects, 7)
+			require.Len(t, shards, len(tc.expectedRefs))
+			for i, shard := range shards {
+				testutil.AssertEqual(t, tc.expectedRefs[i], shard.GetObjectRefs())
+				testutil.AssertEqual(t, tc.expectedStatuses[i], shard.GetObjectStatuses())
+				require.Equal(t, tc.expectedNames[i], shard.GetName())
+				require.Equal(t, inv.GetID(), shard.GetID())
+				require.Equal(t, inv.GetNamespace(), shard.GetNamespace())
+				if i == 0 {
+					require.Equal(t, inv.GetRevisions(), shard.GetRevisions())
+				} else {
+					require.Empty(t, shard.GetRevisions())
+				}
+			}
+		})
+	}
+}
+
+func TestShardedClient(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+	defer tf.Cleanup()
+
+	invClient, err := ConfigMapClientFactory{
+		StatusEnabled:      true,
+		MaxObjectsPerShard: 1,
+	}.NewClient(tf)
+	require.NoError(t, err)
+	// Assign UIDs to created objects, like the apiserver.
+	tf.FakeDynamicClient.PrependReactor("create", "configmaps",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			obj := action.(clienttesting.CreateAction).GetObject().(*unstructured.Unstructured)
+			obj.SetUID(types.UID("uid-" + obj.GetName()))
+			return false, nil, nil
+		})
+
+	configMaps := tf.FakeDynamicClient.
+		Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace(testNamespace)
+	listNames := func() []string {
+		list, err := configMaps.List(context.TODO(), metav1.ListOptions{})
+		require.NoError(t, err)
+		var names []string
+		for _, obj := range list.Items {
+			names = append(names, obj.GetName())
+		}
+		return names
+	}
+
+	inv := NewSingleObjectInventory(emptyInventoryObject())
+	inv.SetObjectRefs(object.ObjMetadataSet{
+		toObjMeta(t, pod1Info),
+		toObjMeta(t, pod2Info),
+		toObjMeta(t, pod3Info),
+	})
+	inv.SetObjectStatuses(object.ObjectStatusSet{
+		podStatus(t, pod1Info),
+		podStatus(t, pod2Info),
+		podStatus(t, pod3Info),
+	})
+
+	// Create a sharded inventory
+	err = invClient.CreateOrUpdate(context.TODO(), inv, UpdateOptions{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		inventoryObjName,
+		inventoryObjName + "-shard-1-1",
+		inventoryObjName + "-shard-1-2",
+	}, listNames())
+
+	// The shards are owned by the inventory object.
+	shardObj, err := configMaps.Get(context.TODO(), inventoryObjName+"-shard-1-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []metav1.OwnerReference{{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Name:       inventoryObjName,
+		UID:        types.UID("uid-" + inventoryObjName),
+	}}, shardObj.GetOwnerReferences())
+
+	actual, err := invClient.Get(context.TODO(), inv.Info(), GetOptions{})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, inv.GetObjectRefs(), actual.GetObjectRefs())
+	testutil.AssertEqual(t, inv.GetObjectStatuses(), actual.GetObjectStatuses())
+
+	invs, err := invClient.List(context.TODO(), ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, invs, 1)
+	testutil.AssertEqual(t, inv.GetObjectRefs(), invs[0].GetObjectRefs())
+
+	// Shrink the inventory, replacing the shards
+	inv.SetObjectRefs(object.ObjMetadataSet{
+		toObjMeta(t, pod1Info),
+		toObjMeta(t, pod3Info),
+	})
+	inv.SetObjectStatuses(object.ObjectStatusSet{
+		podStatus(t, pod1Info),
+		podStatus(t, pod3Info),
+	})
+	err = invClient.CreateOrUpdate(context.TODO(), inv, UpdateOptions{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		inventoryObjName,
+		inventoryObjName + "-shard-2-1",
+	}, listNames())
+
+	actual, err = invClient.Get(context.TODO(), inv.Info(), GetOptions{})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, inv.GetObjectRefs(), actual.GetObjectRefs())
+	testutil.AssertEqual(t, inv.GetObjectStatuses(), actual.GetObjectStatuses())
+
+	// Shrink the inventory into a single object
+	inv.SetObjectRefs(object.ObjMetadataSet{toObjMeta(t, pod1Info)})
+	inv.SetObjectStatuses(object.ObjectStatusSet{podStatus(t, pod1Info)})
+	err = invClient.CreateOrUpdate(context.TODO(), inv, UpdateOptions{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{inventoryObjName}, listNames())
+
+	// Grow it again, then delete everything
+	inv.SetObjectRefs(object.ObjMetadataSet{
+		toObjMeta(t, pod1Info),
+		toObjMeta(t, pod2Info),
+	})
+	err = invClient.CreateOrUpdate(context.TODO(), inv, UpdateOptions{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		inventoryObjName,
+		inventoryObjName + "-shard-1-1",
+	}, listNames())
+
+	err = invClient.Delete(context.TODO(), inv.Info(), DeleteOptions{})
+	require.NoError(t, err)
+	require.Empty(t, listNames())
+}
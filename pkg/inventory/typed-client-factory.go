@@ -0,0 +1,111 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// StorageType identifies the kind of object used to store the inventory.
+type StorageType string
+
+const (
+	ConfigMapStorage     StorageType = "configmap"
+	SecretStorage        StorageType = "secret"
+	ResourceGroupStorage StorageType = "resourcegroup"
+	// ApplySetStorage stores the inventory in a Secret which is also the
+	// parent object of a Kubernetes ApplySet (KEP-3659).
+	ApplySetStorage StorageType = "applyset"
+)
+
+// StorageTypes returns the supported inventory storage types.
+func StorageTypes() []StorageType {
+	return []StorageType{ConfigMapStorage, SecretStorage, ResourceGroupStorage, ApplySetStorage}
+}
+
+// GroupKind returns the kind of object used to store the inventory.
+func (t StorageType) GroupKind() schema.GroupKind {
+	switch t {
+	case SecretStorage, ApplySetStorage:
+		return SecretGVK.GroupKind()
+	case ResourceGroupStorage:
+		return ResourceGroupGVK.GroupKind()
+	default:
+		return ConfigMapGVK.GroupKind()
+	}
+}
+
+var (
+	_ ClientFactory = &TypedClientFactory{}
+)
+
+// TypedClientFactory is a factory that creates instances of inventory clients
+// backed by the configured storage type. The fields are read when the client
+// is created, so they may be bound to command line flags.
+type TypedClientFactory struct {
+	// Type is the storage type. Defaults to ConfigMapStorage.
+	Type          StorageType
+	StatusEnabled bool
+	// RevisionHistoryLimit is the number of revisions of applied objects to
+	// keep in the inventory. Not supported by ResourceGroupStorage.
+	RevisionHistoryLimit int
+	// MaxObjectsPerShard is the maximum number of object references to store
+	// in each inventory object. If zero, the inventory is not sharded.
+	MaxObjectsPerShard int
+	// CompactEncoding stores the object references and statuses as a single
+	// compressed value. Not supported by ResourceGroupStorage.
+	CompactEncoding bool
+	// ApplySetTooling is the value of the ApplySet tooling annotation.
+	// Required by ApplySetStorage. See ApplySetClientFactory.Tooling.
+	ApplySetTooling string
+}
+
+func (tcf *TypedClientFactory) NewClient(factory cmdutil.Factory) (Client, error) {
+	switch tcf.Type {
+	case ConfigMapStorage, "":
+		return ConfigMapClientFactory{
+			StatusEnabled:        tcf.StatusEnabled,
+			RevisionHistoryLimit: tcf.RevisionHistoryLimit,
+			MaxObjectsPerShard:   tcf.MaxObjectsPerShard,
+			CompactEncoding:      tcf.CompactEncoding,
+		}.NewClient(factory)
+	case SecretStorage:
+		return SecretClientFactory{
+			StatusEnabled:        tcf.StatusEnabled,
+			RevisionHistoryLimit: tcf.RevisionHistoryLimit,
+			MaxObjectsPerShard:   tcf.MaxObjectsPerShard,
+			CompactEncoding:      tcf.CompactEncoding,
+		}.NewClient(factory)
+	case ResourceGroupStorage:
+		if tcf.RevisionHistoryLimit > 0 {
+			return nil, fmt.Errorf("inventory storage type %q does not support revisions", tcf.Type)
+		}
+		if tcf.CompactEncoding {
+			return nil, fmt.Errorf("inventory storage type %q does not support compact encoding", tcf.Type)
+		}
+		return ResourceGroupClientFactory{
+			StatusEnabled:      tcf.StatusEnabled,
+			MaxObjectsPerShard: tcf.MaxObjectsPerShard,
+		}.NewClient(factory)
+	case ApplySetStorage:
+		if tcf.RevisionHistoryLimit > 0 {
+			return nil, fmt.Errorf("inventory storage type %q does not support revisions", tcf.Type)
+		}
+		if tcf.MaxObjectsPerShard > 0 {
+			return nil, fmt.Errorf("inventory storage type %q does not support sharding", tcf.Type)
+		}
+		if tcf.CompactEncoding {
+			return nil, fmt.Errorf("inventory storage type %q does not support compact encoding", tcf.Type)
+		}
+		return ApplySetClientFactory{
+			Tooling:       tcf.ApplySetTooling,
+			StatusEnabled: tcf.StatusEnabled,
+		}.NewClient(factory)
+	default:
+		return nil, fmt.Errorf("invalid inventory storage type %q, must be one of %v", tcf.Type, StorageTypes())
+	}
+}
@@ -24,6 +24,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ErrNotCached is returned by the CachingClusterReader when the requested
+// combination of GroupKind and namespace is not part of the cache.
+var ErrNotCached = errors.New("not found in cache")
+
 // This map is hard-coded knowledge that a Deployment contains and
 // ReplicaSet, and that a ReplicaSet in turn contains Pods, etc., and the
 // approach to finding status being used here requires hardcoding that
@@ -163,7 +167,7 @@ func (c *CachingClusterReader) Get(_ context.Context, key client.ObjectKey, obj
 	}
 	cacheEntry, found := c.cache[gn]
 	if !found {
-		return fmt.Errorf("GVK %s and Namespace %s not found in cache", gvk.String(), gn.Namespace)
+		return fmt.Errorf("GVK %s and Namespace %s %w", gvk.String(), gn.Namespace, ErrNotCached)
 	}
 
 	if cacheEntry.err != nil {
@@ -191,7 +195,7 @@ func (c *CachingClusterReader) ListNamespaceScoped(_ context.Context, list *unst
 
 	cacheEntry, found := c.cache[gn]
 	if !found {
-		return fmt.Errorf("GVK %s and Namespace %s not found in cache", gvk.String(), gn.Namespace)
+		return fmt.Errorf("GVK %s and Namespace %s %w", gvk.String(), gn.Namespace, ErrNotCached)
 	}
 
 	if cacheEntry.err != nil {
@@ -31,11 +31,13 @@ func NewStatusReader(mapper meta.RESTMapper, statusReaders ...engine.StatusReade
 	replicaSetStatusReader := NewReplicaSetStatusReader(mapper, defaultStatusReader)
 	deploymentStatusReader := NewDeploymentResourceReader(mapper, replicaSetStatusReader)
 	statefulSetStatusReader := NewStatefulSetResourceReader(mapper, defaultStatusReader)
+	webhookStatusReader := NewWebhookConfigurationStatusReader(mapper)
 
 	statusReaders = append(statusReaders,
 		deploymentStatusReader,
 		statefulSetStatusReader,
 		replicaSetStatusReader,
+		webhookStatusReader,
 		defaultStatusReader,
 	)
 
@@ -0,0 +1,169 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package statusreaders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// NewWebhookConfigurationStatusReader returns a StatusReader for
+// ValidatingWebhookConfigurations and MutatingWebhookConfigurations, that
+// reports them as Current once every Service backing their webhooks has
+// ready endpoints.
+func NewWebhookConfigurationStatusReader(mapper meta.RESTMapper) engine.StatusReader {
+	return &baseStatusReader{
+		mapper: mapper,
+		resourceStatusReader: &webhookConfigurationStatusReader{
+			mapper: mapper,
+		},
+	}
+}
+
+// webhookConfigurationStatusReader is a resourceTypeStatusReader that can
+// fetch webhook configurations from the cluster, knows how to find the
+// EndpointSlices of the Services backing the webhooks, and compute the
+// availability of the webhooks.
+type webhookConfigurationStatusReader struct {
+	mapper meta.RESTMapper
+}
+
+var _ resourceTypeStatusReader = &webhookConfigurationStatusReader{}
+
+func (w *webhookConfigurationStatusReader) Supports(gk schema.GroupKind) bool {
+	return gk == admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration").GroupKind() ||
+		gk == admissionregistrationv1.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration").GroupKind()
+}
+
+func (w *webhookConfigurationStatusReader) ReadStatusForObject(ctx context.Context, reader engine.ClusterReader,
+	config *unstructured.Unstructured) (*event.ResourceStatus, error) {
+	identifier := object.UnstructuredToObjMetadata(config)
+
+	// Webhook configurations don't have a status, but may be terminating.
+	res, err := status.Compute(config)
+	if err != nil {
+		return errResourceToResourceStatus(err, config)
+	}
+	if res.Status != status.CurrentStatus {
+		return &event.ResourceStatus{
+			Identifier: identifier,
+			Status:     res.Status,
+			Resource:   config,
+			Message:    res.Message,
+		}, nil
+	}
+
+	services := webhookServices(config)
+	for _, service := range services {
+		ready, err := w.hasReadyEndpoints(ctx, reader, service)
+		if err != nil {
+			if meta.IsNoMatchError(err) || apierrors.IsForbidden(err) || errors.Is(err, clusterreader.ErrNotCached) {
+				// Availability can't be checked, so don't block on it. The
+				// CachingClusterReader doesn't cache EndpointSlices, because
+				// the namespaces of the Services aren't known in advance.
+				return &event.ResourceStatus{
+					Identifier: identifier,
+					Status:     status.CurrentStatus,
+					Resource:   config,
+					Message:    fmt.Sprintf("Webhook availability unknown: %v", err),
+				}, nil
+			}
+			return errResourceToResourceStatus(err, config)
+		}
+		if !ready {
+			return &event.ResourceStatus{
+				Identifier: identifier,
+				Status:     status.InProgressStatus,
+				Resource:   config,
+				Message:    fmt.Sprintf("Service %s has no ready endpoints", service),
+			}, nil
+		}
+	}
+
+	return &event.ResourceStatus{
+		Identifier: identifier,
+		Status:     status.CurrentStatus,
+		Resource:   config,
+		Message:    fmt.Sprintf("Webhooks are available. Services: %d", len(services)),
+	}, nil
+}
+
+// hasReadyEndpoints returns true if any of the EndpointSlices of the Service
+// has a ready endpoint.
+func (w *webhookConfigurationStatusReader) hasReadyEndpoints(ctx context.Context, reader engine.ClusterReader,
+	service types.NamespacedName) (bool, error) {
+	var sliceList unstructured.UnstructuredList
+	gvk, err := gvk(discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice").GroupKind(), w.mapper)
+	if err != nil {
+		return false, err
+	}
+	sliceList.SetGroupVersionKind(gvk)
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: service.Name})
+	err = reader.ListNamespaceScoped(ctx, &sliceList, service.Namespace, selector)
+	if err != nil {
+		return false, err
+	}
+	for _, slice := range sliceList.Items {
+		endpoints, _, err := unstructured.NestedSlice(slice.Object, "endpoints")
+		if err != nil {
+			return false, err
+		}
+		for _, endpoint := range endpoints {
+			endpointMap, ok := endpoint.(map[string]any)
+			if !ok {
+				continue
+			}
+			// A nil ready condition means the endpoint is ready.
+			ready, found, err := unstructured.NestedBool(endpointMap, "conditions", "ready")
+			if err != nil {
+				return false, err
+			}
+			if !found || ready {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// webhookServices returns the unique Services referenced by the client
+// configs of the webhooks, in order. Webhooks called by URL are skipped.
+func webhookServices(config *unstructured.Unstructured) []types.NamespacedName {
+	webhooks, _, _ := unstructured.NestedSlice(config.Object, "webhooks")
+	var services []types.NamespacedName
+	seen := make(map[types.NamespacedName]struct{})
+	for _, webhook := range webhooks {
+		webhookMap, ok := webhook.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(webhookMap, "clientConfig", "service", "name")
+		namespace, _, _ := unstructured.NestedString(webhookMap, "clientConfig", "service", "namespace")
+		if name == "" {
+			continue
+		}
+		service := types.NamespacedName{Namespace: namespace, Name: name}
+		if _, found := seen[service]; found {
+			continue
+		}
+		seen[service] = struct{}{}
+		services = append(services, service)
+	}
+	return services
+}
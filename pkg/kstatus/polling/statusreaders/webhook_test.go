@@ -0,0 +1,153 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package statusreaders
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader"
+	fakecr "sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader/fake"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/testutil"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	fakemapper "sigs.k8s.io/cli-utils/pkg/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	validatingWebhookGVK = admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration")
+	endpointSliceGVK     = discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice")
+
+	validatingWebhook = strings.TrimSpace(`
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: test
+  generation: 1
+webhooks:
+- name: validate.example.com
+  clientConfig:
+    service:
+      namespace: webhook
+      name: webhook
+- name: validate-url.example.com
+  clientConfig:
+    url: https://example.com/validate
+`)
+
+	readyEndpointSlice = strings.TrimSpace(`
+apiVersion: discovery.k8s.io/v1
+kind: EndpointSlice
+metadata:
+  name: webhook-abcde
+  namespace: webhook
+  labels:
+    kubernetes.io/service-name: webhook
+endpoints:
+- addresses:
+  - 10.0.0.1
+  conditions:
+    ready: false
+- addresses:
+  - 10.0.0.2
+  conditions:
+    ready: true
+`)
+
+	notReadyEndpointSlice = strings.TrimSpace(`
+apiVersion: discovery.k8s.io/v1
+kind: EndpointSlice
+metadata:
+  name: webhook-abcde
+  namespace: webhook
+  labels:
+    kubernetes.io/service-name: webhook
+endpoints:
+- addresses:
+  - 10.0.0.1
+  conditions:
+    ready: false
+`)
+)
+
+func TestWebhookConfigurationReadStatus(t *testing.T) {
+	testCases := map[string]struct {
+		slices          []string
+		expectedStatus  status.Status
+		expectedMessage string
+	}{
+		"ready endpoints": {
+			slices:          []string{readyEndpointSlice},
+			expectedStatus:  status.CurrentStatus,
+			expectedMessage: "Webhooks are available. Services: 1",
+		},
+		"no ready endpoints": {
+			slices:          []string{notReadyEndpointSlice},
+			expectedStatus:  status.InProgressStatus,
+			expectedMessage: "Service webhook/webhook has no ready endpoints",
+		},
+		"no endpoints": {
+			expectedStatus:  status.InProgressStatus,
+			expectedMessage: "Service webhook/webhook has no ready endpoints",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			slices := &unstructured.UnstructuredList{}
+			for _, slice := range tc.slices {
+				slices.Items = append(slices.Items, *testutil.YamlToUnstructured(t, slice))
+			}
+			fakeReader := &fakecr.ClusterReader{
+				ListResources: slices,
+			}
+			fakeMapper := fakemapper.NewFakeRESTMapper(validatingWebhookGVK, endpointSliceGVK)
+			statusReader := NewWebhookConfigurationStatusReader(fakeMapper)
+
+			config := testutil.YamlToUnstructured(t, validatingWebhook)
+			require.True(t, statusReader.Supports(validatingWebhookGVK.GroupKind()))
+			rs, err := statusReader.ReadStatusForObject(context.Background(), fakeReader, config)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rs.Status)
+			assert.Equal(t, tc.expectedMessage, rs.Message)
+		})
+	}
+}
+
+func TestWebhookConfigurationReadStatus_CachingClusterReader(t *testing.T) {
+	fakeMapper := fakemapper.NewFakeRESTMapper(validatingWebhookGVK, endpointSliceGVK)
+	statusReader := NewWebhookConfigurationStatusReader(fakeMapper)
+	config := testutil.YamlToUnstructured(t, validatingWebhook)
+
+	// The EndpointSlices of the Services are not in the cache, so the
+	// availability of the webhooks is unknown.
+	reader, err := clusterreader.NewCachingClusterReader(&emptyReader{}, fakeMapper,
+		object.ObjMetadataSet{object.UnstructuredToObjMetadata(config)})
+	require.NoError(t, err)
+	require.NoError(t, reader.Sync(context.Background()))
+
+	rs, err := statusReader.ReadStatusForObject(context.Background(), reader, config)
+	require.NoError(t, err)
+	assert.Equal(t, status.CurrentStatus, rs.Status)
+	assert.Equal(t, "Webhook availability unknown: GVK discovery.k8s.io/v1, Kind=EndpointSlice "+
+		"and Namespace webhook not found in cache", rs.Message)
+}
+
+// emptyReader is a client.Reader that finds no objects.
+type emptyReader struct{}
+
+func (emptyReader) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return nil
+}
+
+func (emptyReader) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return nil
+}
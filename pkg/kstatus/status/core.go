@@ -36,6 +36,7 @@ var legacyTypes = map[string]GetConditionsFn{
 	"ConfigMap":                  alwaysReady,
 	"batch/Job":                  jobConditions,
 	"apiextensions.k8s.io/CustomResourceDefinition": CRDConditions,
+	"apiregistration.k8s.io/APIService":             apiServiceConditions,
 }
 
 const (
@@ -608,6 +609,40 @@ func serviceConditions(u *unstructured.Unstructured) (*Result, error) {
 	}, nil
 }
 
+// apiServiceConditions return standardized Conditions for APIService
+//
+// An APIService is available when the aggregator can reach the service
+// backing it. Until then, the Available condition is False with reasons like
+// MissingEndpoints or FailedDiscoveryCheck, which are expected while the
+// backing service starts, so they are reported as InProgress.
+func apiServiceConditions(u *unstructured.Unstructured) (*Result, error) {
+	obj := u.UnstructuredContent()
+
+	objc, err := GetObjectWithConditions(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range objc.Status.Conditions {
+		if c.Type != "Available" {
+			continue
+		}
+		if c.Status == corev1.ConditionTrue {
+			return &Result{
+				Status:     CurrentStatus,
+				Message:    "APIService is available",
+				Conditions: []Condition{},
+			}, nil
+		}
+		message := "APIService is not available"
+		if c.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, c.Message)
+		}
+		return newInProgressStatus(c.Reason, message), nil
+	}
+	return newInProgressStatus("Unavailable", "APIService availability not yet reported"), nil
+}
+
 func CRDConditions(u *unstructured.Unstructured) (*Result, error) {
 	obj := u.UnstructuredContent()
 
@@ -1510,3 +1510,86 @@ func TestCRDStatus(t *testing.T) {
 		})
 	}
 }
+
+var apiServiceNoConditions = `
+apiVersion: apiregistration.k8s.io/v1
+kind: APIService
+metadata:
+   name: v1beta1.metrics.k8s.io
+   generation: 1
+`
+
+var apiServiceMissingEndpoints = `
+apiVersion: apiregistration.k8s.io/v1
+kind: APIService
+metadata:
+   name: v1beta1.metrics.k8s.io
+   generation: 1
+status:
+   conditions:
+    - type: Available
+      status: "False"
+      reason: MissingEndpoints
+      message: endpoints for service/metrics-server in "kube-system" have no addresses
+`
+
+var apiServiceAvailable = `
+apiVersion: apiregistration.k8s.io/v1
+kind: APIService
+metadata:
+   name: v1beta1.metrics.k8s.io
+   generation: 1
+status:
+   conditions:
+    - type: Available
+      status: "True"
+      reason: Passed
+`
+
+func TestAPIServiceStatus(t *testing.T) {
+	testCases := map[string]testSpec{
+		"apiServiceNoConditions": {
+			spec:           apiServiceNoConditions,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{
+				{
+					Type:   ConditionReconciling,
+					Status: corev1.ConditionTrue,
+					Reason: "Unavailable",
+				},
+			},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"apiServiceMissingEndpoints": {
+			spec:           apiServiceMissingEndpoints,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{
+				{
+					Type:   ConditionReconciling,
+					Status: corev1.ConditionTrue,
+					Reason: "MissingEndpoints",
+				},
+			},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"apiServiceAvailable": {
+			spec:               apiServiceAvailable,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionReconciling,
+				ConditionStalled,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			runStatusTest(t, tc)
+		})
+	}
+}
@@ -476,6 +476,12 @@ func (w *ObjectStatusReporter) eventHandler(
 			w.taskManager.Schedule(ctx, id, status.ScheduleWindow,
 				w.newStatusCheckTaskFunc(ctx, eventCh, id))
 		}
+		if isWebhookUnavailable(rs) {
+			klog.V(5).Infof("AddFunc: webhook unavailable: %v", id)
+			// schedule delayed status update
+			w.taskManager.Schedule(ctx, id, WebhookRecheckInterval,
+				w.newStatusCheckTaskFunc(ctx, eventCh, id))
+		}
 
 		klog.V(7).Infof("AddFunc: sending update event: %v", rs)
 		eventCh <- event.Event{
@@ -525,6 +531,12 @@ func (w *ObjectStatusReporter) eventHandler(
 			w.taskManager.Schedule(ctx, id, status.ScheduleWindow,
 				w.newStatusCheckTaskFunc(ctx, eventCh, id))
 		}
+		if isWebhookUnavailable(rs) {
+			klog.V(5).Infof("UpdateFunc: webhook unavailable: %v", id)
+			// schedule delayed status update
+			w.taskManager.Schedule(ctx, id, WebhookRecheckInterval,
+				w.newStatusCheckTaskFunc(ctx, eventCh, id))
+		}
 
 		klog.V(7).Infof("UpdateFunc: sending update event: %v", rs)
 		eventCh <- event.Event{
@@ -702,6 +714,11 @@ func (w *ObjectStatusReporter) newStatusCheckTaskFunc(
 			w.handleFatalError(eventCh, err)
 			return
 		}
+		if isWebhookUnavailable(rs) {
+			// keep checking until the webhook is available
+			w.taskManager.Schedule(ctx, id, WebhookRecheckInterval,
+				w.newStatusCheckTaskFunc(ctx, eventCh, id))
+		}
 		eventCh <- event.Event{
 			Type:     event.ResourceUpdateEvent,
 			Resource: rs,
@@ -0,0 +1,29 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+// WebhookRecheckInterval is how often the status of an unavailable webhook
+// configuration is re-read from the cluster. Webhook configurations are not
+// updated when the endpoints of their backing services become ready, so
+// watching them is not enough.
+var WebhookRecheckInterval = 2 * time.Second
+
+// isWebhookUnavailable returns true if the object is a webhook configuration
+// that is waiting for the endpoints of its backing services to be ready.
+func isWebhookUnavailable(rs *event.ResourceStatus) bool {
+	if rs.Error != nil || rs.Status != status.InProgressStatus || rs.Resource == nil {
+		return false
+	}
+	gk := rs.Resource.GroupVersionKind().GroupKind()
+	return gk == schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"} ||
+		gk == schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}
+}
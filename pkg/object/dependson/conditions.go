@@ -0,0 +1,222 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dependson
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/jsonpath"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConditionsAnnotation configures the condition that each dependency in
+	// the depends-on annotation must meet before the object is applied. The
+	// value is a YAML map of object references, in the same format as the
+	// depends-on annotation, to conditions. Dependencies that are not in the
+	// map must be Current.
+	//
+	// Example:
+	//
+	//	batch/namespaces/default/Job/migrate: complete
+	//	example.com/namespaces/default/Database/db: condition=Ready
+	//	/namespaces/default/Service/lb: jsonpath=$.status.loadBalancer.ingress[0].ip
+	ConditionsAnnotation = "config.kubernetes.io/depends-on-conditions"
+)
+
+// ConditionType is the kind of a dependency Condition.
+type ConditionType string
+
+const (
+	// ConditionCurrent means the dependency must have the Current status.
+	// This is the default.
+	ConditionCurrent ConditionType = "current"
+
+	// ConditionComplete means the dependency must have run to completion:
+	// Jobs must have the Complete condition and Pods the Succeeded phase.
+	ConditionComplete ConditionType = "complete"
+
+	// ConditionTrue means the dependency must have a status condition of the
+	// type in the Condition Value, with status True.
+	ConditionTrue ConditionType = "condition"
+
+	// ConditionFieldNotEmpty means the JSONPath expression in the Condition
+	// Value must match a field with a value that is not empty.
+	ConditionFieldNotEmpty ConditionType = "jsonpath"
+)
+
+// Used to separate the condition type and value.
+const conditionSeparator = "="
+
+// neverCompleteKinds are the kinds of objects that run until they are deleted,
+// so they never complete.
+var neverCompleteKinds = map[schema.GroupKind]bool{
+	{Kind: "ReplicationController"}:      true,
+	{Group: "apps", Kind: "Deployment"}:  true,
+	{Group: "apps", Kind: "StatefulSet"}: true,
+	{Group: "apps", Kind: "DaemonSet"}:   true,
+	{Group: "apps", Kind: "ReplicaSet"}:  true,
+}
+
+// Condition is a condition that a dependency must meet before its dependents
+// are applied.
+type Condition struct {
+	Type  ConditionType
+	Value string
+}
+
+// String returns the condition in the format of the annotation.
+func (c Condition) String() string {
+	if c.Value == "" {
+		return string(c.Type)
+	}
+	return string(c.Type) + conditionSeparator + c.Value
+}
+
+// ParseCondition parses the passed string as a dependency condition.
+//
+// Examples:
+//
+//	current
+//	complete
+//	condition=<type>
+//	jsonpath=<expression>
+//
+// Returns the parsed Condition or an error if unable to parse.
+func ParseCondition(condStr string) (Condition, error) {
+	condStr = strings.TrimSpace(condStr)
+	condType, value, _ := strings.Cut(condStr, conditionSeparator)
+	switch ConditionType(condType) {
+	case ConditionCurrent:
+		if value != "" {
+			return Condition{}, fmt.Errorf("unexpected value for condition %q: %q", condType, condStr)
+		}
+		return Condition{Type: ConditionCurrent}, nil
+	case ConditionComplete:
+		if value != "" {
+			return Condition{}, fmt.Errorf("unexpected value for condition %q: %q", condType, condStr)
+		}
+		return Condition{Type: ConditionComplete}, nil
+	case ConditionTrue:
+		if value == "" {
+			return Condition{}, fmt.Errorf("missing condition type: %q", condStr)
+		}
+		return Condition{Type: ConditionTrue, Value: value}, nil
+	case ConditionFieldNotEmpty:
+		if value == "" {
+			return Condition{}, fmt.Errorf("missing jsonpath expression: %q", condStr)
+		}
+		if _, err := jsonpath.Get(map[string]any{}, value); err != nil {
+			return Condition{}, err
+		}
+		return Condition{Type: ConditionFieldNotEmpty, Value: value}, nil
+	default:
+		return Condition{}, fmt.Errorf("unknown condition: %q", condStr)
+	}
+}
+
+// HasConditionsAnnotation returns true if the
+// config.kubernetes.io/depends-on-conditions annotation is present, false if
+// not.
+func HasConditionsAnnotation(u *unstructured.Unstructured) bool {
+	if u == nil {
+		return false
+	}
+	_, found := u.GetAnnotations()[ConditionsAnnotation]
+	return found
+}
+
+// ReadConditions reads the depends-on-conditions annotation and parses the
+// conditions of the dependencies. Every object reference must also be in the
+// depends-on annotation.
+func ReadConditions(u *unstructured.Unstructured) (map[object.ObjMetadata]Condition, error) {
+	if u == nil {
+		return nil, nil
+	}
+	condsStr, found := u.GetAnnotations()[ConditionsAnnotation]
+	if !found {
+		return nil, nil
+	}
+	klog.V(5).Infof("depends-on-conditions annotation found for %s/%s: %q",
+		u.GetNamespace(), u.GetName(), condsStr)
+
+	conds, err := parseConditions(condsStr)
+	if err != nil {
+		return nil, object.InvalidAnnotationError{
+			Annotation: ConditionsAnnotation,
+			Cause:      err,
+		}
+	}
+	// Invalid depends-on annotations are reported by ReadAnnotation.
+	deps, _ := ReadAnnotation(u)
+	for id := range conds {
+		if !object.ObjMetadataSet(deps).Contains(id) {
+			return nil, object.InvalidAnnotationError{
+				Annotation: ConditionsAnnotation,
+				Cause:      fmt.Errorf("object is not a dependency: %s", id),
+			}
+		}
+	}
+	return conds, nil
+}
+
+// parseConditions parses the YAML map of object references to conditions.
+func parseConditions(condsStr string) (map[object.ObjMetadata]Condition, error) {
+	var condStrs map[string]string
+	if err := yaml.Unmarshal([]byte(condsStr), &condStrs); err != nil {
+		return nil, err
+	}
+	conds := make(map[object.ObjMetadata]Condition, len(condStrs))
+	for objStr, condStr := range condStrs {
+		id, err := ParseObjMetadata(objStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse object reference: %w", err)
+		}
+		cond, err := ParseCondition(condStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse condition of %s: %w", id, err)
+		}
+		if cond.Type == ConditionComplete && neverCompleteKinds[id.GroupKind] {
+			return nil, fmt.Errorf("%s objects never complete: %s", id.GroupKind.Kind, id)
+		}
+		conds[id] = cond
+	}
+	return conds, nil
+}
+
+// WriteConditions updates the supplied unstructured object to add the
+// depends-on-conditions annotation with a multi-line yaml value.
+func WriteConditions(obj *unstructured.Unstructured, conds map[object.ObjMetadata]Condition) error {
+	if obj == nil {
+		return errors.New("object is nil")
+	}
+	if len(conds) == 0 {
+		return errors.New("conditions are empty")
+	}
+	condStrs := make(map[string]string, len(conds))
+	for id, cond := range conds {
+		objStr, err := FormatObjMetadata(id)
+		if err != nil {
+			return fmt.Errorf("failed to format depends-on-conditions annotation: %w", err)
+		}
+		condStrs[objStr] = cond.String()
+	}
+	yamlBytes, err := yaml.Marshal(condStrs)
+	if err != nil {
+		return fmt.Errorf("failed to format depends-on-conditions annotation: %w", err)
+	}
+	a := obj.GetAnnotations()
+	if a == nil {
+		a = map[string]string{}
+	}
+	a[ConditionsAnnotation] = string(yamlBytes)
+	obj.SetAnnotations(a)
+	return nil
+}
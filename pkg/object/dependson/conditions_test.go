@@ -0,0 +1,169 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dependson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestParseCondition(t *testing.T) {
+	testCases := map[string]struct {
+		condStr       string
+		expected      Condition
+		expectedError string
+	}{
+		"current": {
+			condStr:  "current",
+			expected: Condition{Type: ConditionCurrent},
+		},
+		"complete": {
+			condStr:  "complete",
+			expected: Condition{Type: ConditionComplete},
+		},
+		"condition": {
+			condStr:  " condition=Ready ",
+			expected: Condition{Type: ConditionTrue, Value: "Ready"},
+		},
+		"jsonpath": {
+			condStr:  "jsonpath=$.status.loadBalancer.ingress[0].ip",
+			expected: Condition{Type: ConditionFieldNotEmpty, Value: "$.status.loadBalancer.ingress[0].ip"},
+		},
+		"current with value": {
+			condStr:       "current=true",
+			expectedError: `unexpected value for condition "current": "current=true"`,
+		},
+		"condition without type": {
+			condStr:       "condition=",
+			expectedError: `missing condition type: "condition="`,
+		},
+		"invalid jsonpath": {
+			condStr:       "jsonpath={.status}",
+			expectedError: "failed to evaluate jsonpath expression ({.status}): wrong symbol '{' at 0",
+		},
+		"unknown": {
+			condStr:       "ready",
+			expectedError: `unknown condition: "ready"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			cond, err := ParseCondition(tc.condStr)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cond)
+			// String formats the condition, so that it can be parsed again.
+			parsed, err := ParseCondition(cond.String())
+			require.NoError(t, err)
+			assert.Equal(t, cond, parsed)
+		})
+	}
+}
+
+func TestReadConditions(t *testing.T) {
+	jobID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "batch", Kind: "Job"},
+		Namespace: "test-namespace",
+		Name:      "migrate",
+	}
+	podID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "Pod"},
+		Namespace: "test-namespace",
+		Name:      "migrate",
+	}
+	crdID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"},
+		Name:      "crontabs.example.com",
+	}
+
+	testCases := map[string]struct {
+		annotations   map[string]string
+		expected      map[object.ObjMetadata]Condition
+		expectedError string
+	}{
+		"no annotation": {
+			annotations: map[string]string{
+				Annotation: "batch/namespaces/test-namespace/Job/migrate",
+			},
+		},
+		"conditions": {
+			annotations: map[string]string{
+				Annotation: "batch/namespaces/test-namespace/Job/migrate," +
+					"apiextensions.k8s.io/CustomResourceDefinition/crontabs.example.com",
+				ConditionsAnnotation: "batch/namespaces/test-namespace/Job/migrate: complete\n" +
+					"apiextensions.k8s.io/CustomResourceDefinition/crontabs.example.com: condition=Established\n",
+			},
+			expected: map[object.ObjMetadata]Condition{
+				jobID: {Type: ConditionComplete},
+				crdID: {Type: ConditionTrue, Value: "Established"},
+			},
+		},
+		"pod completes": {
+			annotations: map[string]string{
+				Annotation:           "/namespaces/test-namespace/Pod/migrate",
+				ConditionsAnnotation: "/namespaces/test-namespace/Pod/migrate: complete",
+			},
+			expected: map[object.ObjMetadata]Condition{
+				podID: {Type: ConditionComplete},
+			},
+		},
+		"not a dependency": {
+			annotations: map[string]string{
+				Annotation:           "batch/namespaces/test-namespace/Job/migrate",
+				ConditionsAnnotation: "apiextensions.k8s.io/CustomResourceDefinition/crontabs.example.com: current",
+			},
+			expectedError: "invalid \"config.kubernetes.io/depends-on-conditions\" annotation: " +
+				"object is not a dependency: _crontabs.example.com_apiextensions.k8s.io_CustomResourceDefinition",
+		},
+		"invalid condition": {
+			annotations: map[string]string{
+				Annotation:           "batch/namespaces/test-namespace/Job/migrate",
+				ConditionsAnnotation: "batch/namespaces/test-namespace/Job/migrate: done",
+			},
+			expectedError: "invalid \"config.kubernetes.io/depends-on-conditions\" annotation: " +
+				"failed to parse condition of test-namespace_migrate_batch_Job: unknown condition: \"done\"",
+		},
+		"never completes": {
+			annotations: map[string]string{
+				Annotation: "apps/namespaces/test-namespace/Deployment/app,/namespaces/test-namespace/Pod/migrate",
+				ConditionsAnnotation: "apps/namespaces/test-namespace/Deployment/app: complete\n" +
+					"/namespaces/test-namespace/Pod/migrate: complete\n",
+			},
+			expectedError: "invalid \"config.kubernetes.io/depends-on-conditions\" annotation: " +
+				"Deployment objects never complete: test-namespace_app_apps_Deployment",
+		},
+		"invalid object reference": {
+			annotations: map[string]string{
+				Annotation:           "batch/namespaces/test-namespace/Job/migrate",
+				ConditionsAnnotation: "Job/migrate: complete",
+			},
+			expectedError: "invalid \"config.kubernetes.io/depends-on-conditions\" annotation: " +
+				"failed to parse object reference: expected 3 or 5 fields, found 2: \"Job/migrate\"",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			u.SetAnnotations(tc.annotations)
+			assert.Equal(t, tc.expected != nil || tc.expectedError != "", HasConditionsAnnotation(u))
+			conds, err := ReadConditions(u)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, conds)
+		})
+	}
+}
@@ -2,26 +2,61 @@
 // SPDX-License-Identifier: Apache-2.0
 
 // This package provides a object sorting functionality
-// based on the explicit "depends-on" annotation, and
-// implicit object dependencies like namespaces and CRD's.
+// based on the explicit "depends-on" and "apply-wave" annotations,
+// and implicit object dependencies like namespaces and CRD's.
 package graph
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"sort"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/multierror"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/dependson"
 	"sigs.k8s.io/cli-utils/pkg/object/mutation"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/object/wave"
 	"sigs.k8s.io/cli-utils/pkg/ordering"
 )
 
+// Options configures how dependencies are inferred by
+// DependencyGraphWithOptions.
+type Options struct {
+	// ActuationGroups partitions the objects into groups that are actuated
+	// separately, like the objects to apply and the objects to prune.
+	// Apply-wave and reference dependencies are only added between objects
+	// in the same group. Objects that are not in any group are in the same
+	// group as each other. If empty, all objects are in the same group.
+	ActuationGroups []object.ObjMetadataSet
+
+	// InferReferences enables the dependencies inferred from object
+	// references, like from a Deployment to the ConfigMaps, Secrets,
+	// ServiceAccount and PriorityClass used by its pod template, from a
+	// RoleBinding to its Role and ServiceAccounts, and from an Ingress to its
+	// Services. The inferred dependencies are returned by Graph.References.
+	InferReferences bool
+
+	// AllowExternalDependencies allows depends-on annotations to reference
+	// objects that are not in the object set. Instead of returning an
+	// ExternalDependencyError, the dependencies are added as external edges,
+	// returned by Graph.ExternalDependencies.
+	AllowExternalDependencies bool
+}
+
 // DependencyGraph returns a new graph, populated with the supplied objects as
 // vetices and edges built from their dependencies.
 func DependencyGraph(objs object.UnstructuredSet) (*Graph, error) {
+	return DependencyGraphWithOptions(objs, Options{})
+}
+
+// DependencyGraphWithOptions is the same as DependencyGraph, but with options
+// that configure how dependencies are inferred.
+func DependencyGraphWithOptions(objs object.UnstructuredSet, opts Options) (*Graph, error) {
 	g := New()
 	if len(objs) == 0 {
 		return g, nil
@@ -32,17 +67,28 @@ func DependencyGraph(objs object.UnstructuredSet) (*Graph, error) {
 	// This is simply an optimiation to avoid repeating obj -> id conversion.
 	ids := object.UnstructuredSetToObjMetadataSet(objs)
 
+	// Index of the actuation group of each object (same length & order as objs)
+	groupIndexes := actuationGroupIndexes(ids, opts.ActuationGroups)
+
 	// Add objects as graph vertices
 	addVertices(g, ids)
 	// Add dependencies as graph edges
 	addCRDEdges(g, objs, ids)
 	addNamespaceEdges(g, objs, ids)
-	if err := addDependsOnEdges(g, objs, ids); err != nil {
+	if err := addDependsOnEdges(g, objs, ids, opts.AllowExternalDependencies); err != nil {
 		errors = append(errors, err)
 	}
 	if err := addApplyTimeMutationEdges(g, objs, ids); err != nil {
 		errors = append(errors, err)
 	}
+	if err := addApplyWaveEdges(g, objs, ids, groupIndexes); err != nil {
+		errors = append(errors, err)
+	}
+	// Reference edges are added last, so that they can be skipped if they
+	// conflict with the other dependencies.
+	if opts.InferReferences {
+		addReferenceEdges(g, objs, ids, groupIndexes)
+	}
 	if len(errors) > 0 {
 		return g, multierror.Wrap(errors...)
 	}
@@ -203,12 +249,14 @@ func addApplyTimeMutationEdges(g *Graph, objs object.UnstructuredSet, ids object
 }
 
 // addDependsOnEdges updates the graph with edges from objects
-// with an explicit "depends-on" annotation.
+// with an explicit "depends-on" annotation, and the conditions of the edges
+// from the "depends-on-conditions" annotation. Dependencies that are not in
+// the object set are added as external edges, if allowExternal is true.
 // The objs and ids must match in order and length (optimization).
-func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMetadataSet) error {
+func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMetadataSet, allowExternal bool) error {
 	var errors []error
 	for i, obj := range objs {
-		if !dependson.HasAnnotation(obj) {
+		if !dependson.HasAnnotation(obj) && !dependson.HasConditionsAnnotation(obj) {
 			continue
 		}
 		id := ids[i]
@@ -218,6 +266,12 @@ func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMeta
 			errors = append(errors, validation.NewError(err, id))
 			continue
 		}
+		conds, err := dependson.ReadConditions(obj)
+		if err != nil {
+			klog.V(3).Infof("failed to add edges from: %s: %v", id, err)
+			errors = append(errors, validation.NewError(err, id))
+			continue
+		}
 		seen := make(map[object.ObjMetadata]struct{})
 		var objErrors []error
 		for _, dep := range deps {
@@ -239,8 +293,14 @@ func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMeta
 			}
 			// Mark as seen
 			seen[dep] = struct{}{}
-			// Require dependencies to be in the same resource group.
-			// Waiting for external dependencies isn't implemented (yet).
+			// Require dependencies to be in the same resource group,
+			// unless external dependencies are allowed.
+			if !ids.Contains(dep) && allowExternal {
+				klog.V(3).Infof("adding external edge from: %s, to: %s", id, dep)
+				g.AddExternalEdge(id, dep)
+				g.SetEdgeCondition(id, dep, conds[dep])
+				continue
+			}
 			if !ids.Contains(dep) {
 				err := object.InvalidAnnotationError{
 					Annotation: dependson.Annotation,
@@ -257,6 +317,7 @@ func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMeta
 			}
 			klog.V(3).Infof("adding edge from: %s, to: %s", id, dep)
 			g.AddEdge(id, dep)
+			g.SetEdgeCondition(id, dep, conds[dep])
 		}
 		if len(objErrors) > 0 {
 			errors = append(errors,
@@ -323,3 +384,75 @@ func addNamespaceEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMeta
 		}
 	}
 }
+
+// addApplyWaveEdges adds edges to the dependency graph from objects in each
+// apply wave to the objects in the previous wave of the same actuation group,
+// so that all objects in a wave are applied and reconciled before the next
+// wave, and pruned in reverse. Objects without the annotation are in wave 0.
+// No edges are added to an actuation group with no annotated objects.
+// The edges go through a barrier vertex between each pair of waves, so the
+// number of edges grows linearly with the number of objects.
+// The objs and ids must match in order and length (optimization).
+func addApplyWaveEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMetadataSet, groupIndexes []int) error {
+	var errors []error
+	// map of group index -> wave -> ids
+	waves := make(map[int]map[int]object.ObjMetadataSet)
+	annotated := make(map[int]bool)
+	for i, obj := range objs {
+		id := ids[i]
+		groupIndex := groupIndexes[i]
+		w, err := wave.ReadAnnotation(obj)
+		if err != nil {
+			klog.V(3).Infof("failed to add edges from: %s: %v", id, err)
+			errors = append(errors, validation.NewError(err, id))
+			continue
+		}
+		if wave.HasAnnotation(obj) {
+			annotated[groupIndex] = true
+		}
+		if waves[groupIndex] == nil {
+			waves[groupIndex] = make(map[int]object.ObjMetadataSet)
+		}
+		waves[groupIndex][w] = append(waves[groupIndex][w], id)
+	}
+	for groupIndex, groupWaves := range waves {
+		if !annotated[groupIndex] {
+			continue
+		}
+		order := slices.Sorted(maps.Keys(groupWaves))
+		for j := 1; j < len(order); j++ {
+			barrier := waveBarrier(groupIndex, order[j])
+			klog.V(3).Infof("adding edges from: wave %d, to: wave %d", order[j], order[j-1])
+			g.addBarrier(barrier, groupWaves[order[j]], groupWaves[order[j-1]])
+		}
+	}
+	if len(errors) > 0 {
+		return multierror.Wrap(errors...)
+	}
+	return nil
+}
+
+// waveBarrier returns the id of the barrier vertex between an apply wave and
+// the previous wave of an actuation group. It is not a valid object id, so it
+// can't collide with the objects in the graph.
+func waveBarrier(groupIndex int, w int) object.ObjMetadata {
+	return object.ObjMetadata{
+		GroupKind: schema.GroupKind{
+			Group: "apply-wave.cli-utils.sigs.k8s.io",
+			Kind:  "Barrier",
+		},
+		Name: fmt.Sprintf("group %d wave %d", groupIndex, w),
+	}
+}
+
+// actuationGroupIndexes returns the index of the actuation group of each
+// object, or -1 if the object is not in any group.
+func actuationGroupIndexes(ids object.ObjMetadataSet, groups []object.ObjMetadataSet) []int {
+	indexes := make([]int, len(ids))
+	for i, id := range ids {
+		indexes[i] = slices.IndexFunc(groups, func(group object.ObjMetadataSet) bool {
+			return group.Contains(id)
+		})
+	}
+	return indexes
+}
@@ -5,6 +5,7 @@ package graph
 
 import (
 	"errors"
+	"fmt"
 	"slices"
 	"testing"
 
@@ -20,6 +21,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/object/mutation"
 	mutationutil "sigs.k8s.io/cli-utils/pkg/object/mutation/testutil"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/object/wave"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
@@ -269,6 +271,60 @@ func TestSortObjs(t *testing.T) {
 			expected: []object.UnstructuredSet{},
 			isError:  true,
 		},
+		"apply waves sort objects in ascending order": {
+			objs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["deployment"],
+					testutil.AddApplyWave(2)),
+				testutil.Unstructured(t, resources["secret"]),
+				testutil.Unstructured(t, resources["pod"],
+					testutil.AddApplyWave(-1)),
+			},
+			expected: []object.UnstructuredSet{
+				{
+					testutil.Unstructured(t, resources["pod"],
+						testutil.AddApplyWave(-1)),
+				},
+				{
+					testutil.Unstructured(t, resources["secret"]),
+				},
+				{
+					testutil.Unstructured(t, resources["deployment"],
+						testutil.AddApplyWave(2)),
+				},
+			},
+			isError: false,
+		},
+		"objects in the same apply wave are in the same set": {
+			objs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["deployment"],
+					testutil.AddApplyWave(1)),
+				testutil.Unstructured(t, resources["secret"],
+					testutil.AddApplyWave(1)),
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			expected: []object.UnstructuredSet{
+				{
+					testutil.Unstructured(t, resources["pod"]),
+				},
+				{
+					testutil.Unstructured(t, resources["deployment"],
+						testutil.AddApplyWave(1)),
+					testutil.Unstructured(t, resources["secret"],
+						testutil.AddApplyWave(1)),
+				},
+			},
+			isError: false,
+		},
+		"depends-on an object in a later apply wave is cyclic": {
+			objs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["deployment"],
+					testutil.AddApplyWave(1)),
+				testutil.Unstructured(t, resources["secret"],
+					testutil.AddDependsOn(t, testutil.ToIdentifier(t, resources["deployment"]))),
+			},
+			expected: []object.UnstructuredSet{},
+			isError:  true,
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -662,6 +718,425 @@ func TestDependencyGraph(t *testing.T) {
 	}
 }
 
+func TestDependencyGraphWithOptions(t *testing.T) {
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+	podID := testutil.ToIdentifier(t, resources["pod"])
+	objs := object.UnstructuredSet{
+		testutil.Unstructured(t, resources["deployment"],
+			testutil.AddApplyWave(1)),
+		testutil.Unstructured(t, resources["secret"]),
+		testutil.Unstructured(t, resources["pod"],
+			testutil.AddApplyWave(1)),
+	}
+
+	// All objects in the same actuation group
+	g, err := DependencyGraphWithOptions(objs, Options{})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, object.ObjMetadataSet{secretID}, g.Dependencies(deploymentID))
+	testutil.AssertEqual(t, object.ObjMetadataSet{secretID}, g.Dependencies(podID))
+
+	// Waves don't order objects in different actuation groups
+	g, err = DependencyGraphWithOptions(objs, Options{
+		ActuationGroups: []object.ObjMetadataSet{
+			{deploymentID, secretID},
+			{podID},
+		},
+	})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, object.ObjMetadataSet{secretID}, g.Dependencies(deploymentID))
+	testutil.AssertEqual(t, object.ObjMetadataSet{}, g.Dependencies(podID))
+
+	// Invalid waves are validation errors
+	invalid := testutil.Unstructured(t, resources["pod"])
+	invalid.SetAnnotations(map[string]string{wave.Annotation: "first"})
+	_, err = DependencyGraphWithOptions(object.UnstructuredSet{invalid}, Options{})
+	var validationErr *validation.Error
+	require.ErrorAs(t, err, &validationErr)
+	testutil.AssertEqual(t, object.ObjMetadataSet{podID}, validationErr.Identifiers())
+}
+
+func TestDependencyGraphLargeApplyWaves(t *testing.T) {
+	const waves = 3
+	const objsPerWave = 1000
+	var objs object.UnstructuredSet
+	ids := make([]object.ObjMetadataSet, waves)
+	for w := range waves {
+		for i := range objsPerWave {
+			obj := testutil.Unstructured(t, resources["secret"], testutil.AddApplyWave(w))
+			obj.SetName(fmt.Sprintf("secret-%d-%d", w, i))
+			objs = append(objs, obj)
+			ids[w] = append(ids[w], object.UnstructuredToObjMetadata(obj))
+		}
+	}
+
+	g, err := DependencyGraph(objs)
+	require.NoError(t, err)
+	assert.Equal(t, len(objs), g.Size())
+
+	// Each pair of waves adds one edge per object, through a barrier.
+	edges := 0
+	for _, adj := range g.edges {
+		edges += len(adj)
+	}
+	assert.Equal(t, (waves-1)*2*objsPerWave, edges)
+
+	// The barriers are hidden from the callers of the graph.
+	testutil.AssertEqual(t, ids[0], g.Dependencies(ids[1][0]))
+	testutil.AssertEqual(t, ids[2], g.Dependents(ids[1][0]))
+	assert.Len(t, g.Components(), 1)
+	assert.Len(t, g.Components()[0], len(objs))
+	sorted, err := g.Sort()
+	require.NoError(t, err)
+	require.Len(t, sorted, waves)
+	for w := range waves {
+		assert.ElementsMatch(t, ids[w], sorted[w])
+	}
+
+}
+
+func TestDependencyGraphApplyWaveCycle(t *testing.T) {
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+	g, err := DependencyGraph(object.UnstructuredSet{
+		testutil.Unstructured(t, resources["deployment"],
+			testutil.AddApplyWave(1)),
+		testutil.Unstructured(t, resources["secret"],
+			testutil.AddDependsOn(t, deploymentID)),
+	})
+	require.NoError(t, err)
+
+	// Cycles through a barrier are reported without it.
+	_, err = g.Sort()
+	require.EqualError(t, err, validation.NewError(CyclicDependencyError{
+		Edges: []Edge{
+			{From: secretID, To: deploymentID},
+			{From: deploymentID, To: secretID},
+		},
+	}, secretID, deploymentID).Error())
+}
+
+func TestDependencyGraphExternalDependencies(t *testing.T) {
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+	podID := testutil.ToIdentifier(t, resources["pod"])
+	objs := object.UnstructuredSet{
+		testutil.Unstructured(t, resources["deployment"],
+			testutil.AddDependsOn(t, secretID, podID)),
+		testutil.Unstructured(t, resources["secret"]),
+	}
+
+	// External dependencies are invalid by default
+	_, err := DependencyGraphWithOptions(objs, Options{})
+	var validationErr *validation.Error
+	require.ErrorAs(t, err, &validationErr)
+	testutil.AssertEqual(t, object.ObjMetadataSet{deploymentID}, validationErr.Identifiers())
+
+	// External dependencies are added as external edges, if allowed
+	g, err := DependencyGraphWithOptions(objs, Options{AllowExternalDependencies: true})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, object.ObjMetadataSet{secretID}, g.Dependencies(deploymentID))
+	testutil.AssertEqual(t, object.ObjMetadataSet{podID}, g.ExternalDependencies(deploymentID))
+	testutil.AssertEqual(t, object.ObjMetadataSet{}, g.ExternalDependencies(secretID))
+	testutil.AssertEqual(t, []Edge{{From: deploymentID, To: podID}}, g.ExternalEdges())
+	// External objects are not sorted
+	sorted, err := g.Sort()
+	require.NoError(t, err)
+	testutil.AssertEqual(t, []object.ObjMetadataSet{{secretID}, {deploymentID}}, sorted)
+}
+
+func TestDependencyGraphDependsOnConditions(t *testing.T) {
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+	podID := testutil.ToIdentifier(t, resources["pod"])
+	namespaceID := testutil.ToIdentifier(t, resources["namespace"])
+	readyCond := dependson.Condition{Type: dependson.ConditionTrue, Value: "Ready"}
+	fieldCond := dependson.Condition{Type: dependson.ConditionFieldNotEmpty, Value: "$.data.password"}
+	objs := object.UnstructuredSet{
+		testutil.Unstructured(t, resources["deployment"],
+			testutil.AddDependsOn(t, secretID, podID),
+			testutil.AddDependsOnConditions(t, map[object.ObjMetadata]dependson.Condition{
+				secretID: fieldCond,
+				podID:    readyCond,
+			})),
+		testutil.Unstructured(t, resources["secret"],
+			testutil.AddDependsOn(t, podID),
+			testutil.AddDependsOnConditions(t, map[object.ObjMetadata]dependson.Condition{
+				podID: {Type: dependson.ConditionCurrent},
+			})),
+		testutil.Unstructured(t, resources["pod"]),
+		testutil.Unstructured(t, resources["namespace"]),
+	}
+
+	g, err := DependencyGraph(objs)
+	require.NoError(t, err)
+	testutil.AssertEqual(t, []dependson.Condition{fieldCond}, g.DependentConditions(secretID))
+	testutil.AssertEqual(t, []dependson.Condition{readyCond}, g.DependentConditions(podID))
+	testutil.AssertEqual(t, []dependson.Condition(nil), g.DependentConditions(deploymentID))
+	testutil.AssertEqual(t, []dependson.Condition(nil), g.DependentConditions(namespaceID))
+
+	// Conditions of external dependencies are also added
+	objs = object.UnstructuredSet{
+		testutil.Unstructured(t, resources["deployment"],
+			testutil.AddDependsOn(t, podID),
+			testutil.AddDependsOnConditions(t, map[object.ObjMetadata]dependson.Condition{
+				podID: readyCond,
+			})),
+	}
+	g, err = DependencyGraphWithOptions(objs, Options{AllowExternalDependencies: true})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, []dependson.Condition{readyCond}, g.DependentConditions(podID))
+
+	// Conditions of objects that are not dependencies are invalid
+	objs = object.UnstructuredSet{
+		testutil.Unstructured(t, resources["deployment"],
+			testutil.AddDependsOnConditions(t, map[object.ObjMetadata]dependson.Condition{
+				secretID: readyCond,
+			})),
+		testutil.Unstructured(t, resources["secret"]),
+	}
+	_, err = DependencyGraph(objs)
+	var validationErr *validation.Error
+	require.ErrorAs(t, err, &validationErr)
+	testutil.AssertEqual(t, object.ObjMetadataSet{deploymentID}, validationErr.Identifiers())
+}
+
+func TestDependencyGraphReferences(t *testing.T) {
+	deployment := testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: test-namespace
+spec:
+  template:
+    spec:
+      serviceAccountName: app
+      priorityClassName: high
+      volumes:
+      - name: config
+        configMap:
+          name: app-config
+      - name: data
+        persistentVolumeClaim:
+          claimName: app-data
+      containers:
+      - name: app
+        envFrom:
+        - secretRef:
+            name: app-secret
+        env:
+        - name: MISSING
+          valueFrom:
+            configMapKeyRef:
+              name: not-in-package
+`)
+	configMap := testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: test-namespace
+`)
+	secret := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+  namespace: test-namespace
+`)
+	pvc := testutil.Unstructured(t, `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: app-data
+  namespace: test-namespace
+`)
+	serviceAccount := testutil.Unstructured(t, `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: app
+  namespace: test-namespace
+`)
+	priorityClass := testutil.Unstructured(t, `
+apiVersion: scheduling.k8s.io/v1
+kind: PriorityClass
+metadata:
+  name: high
+`)
+	role := testutil.Unstructured(t, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: app
+  namespace: test-namespace
+`)
+	roleBinding := testutil.Unstructured(t, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: app
+  namespace: test-namespace
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: app
+subjects:
+- kind: ServiceAccount
+  name: app
+`)
+	service := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  namespace: test-namespace
+`)
+	ingress := testutil.Unstructured(t, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: app
+  namespace: test-namespace
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /
+        backend:
+          service:
+            name: app
+`)
+	objs := object.UnstructuredSet{deployment, configMap, secret, pvc, serviceAccount,
+		priorityClass, role, roleBinding, service, ingress}
+	id := object.UnstructuredToObjMetadata
+
+	g, err := DependencyGraphWithOptions(objs, Options{InferReferences: true})
+	require.NoError(t, err)
+	// The PersistentVolumeClaim is not a dependency, because it may not be
+	// bound until the pods using it are scheduled.
+	testutil.AssertEqual(t, object.ObjMetadataSet{id(serviceAccount), id(priorityClass),
+		id(configMap), id(secret)}, g.Dependencies(id(deployment)))
+	testutil.AssertEqual(t, object.ObjMetadataSet{id(role), id(serviceAccount)},
+		g.Dependencies(id(roleBinding)))
+	testutil.AssertEqual(t, object.ObjMetadataSet{id(service)}, g.Dependencies(id(ingress)))
+	assert.Equal(t, []ReferenceEdge{
+		{Edge: Edge{From: id(deployment), To: id(serviceAccount)}, Field: "spec.template.spec.serviceAccountName"},
+		{Edge: Edge{From: id(deployment), To: id(priorityClass)}, Field: "spec.template.spec.priorityClassName"},
+		{Edge: Edge{From: id(deployment), To: id(configMap)}, Field: "spec.template.spec.volumes[0].configMap.name"},
+		{Edge: Edge{From: id(deployment), To: id(secret)}, Field: "spec.template.spec.containers[0].envFrom[0].secretRef.name"},
+		{Edge: Edge{From: id(roleBinding), To: id(role)}, Field: "roleRef"},
+		{Edge: Edge{From: id(roleBinding), To: id(serviceAccount)}, Field: "subjects[0]"},
+		{Edge: Edge{From: id(ingress), To: id(service)}, Field: "spec.rules[0].http.paths[0].backend.service.name"},
+	}, g.References())
+
+	// References that contradict explicit dependencies are skipped
+	explicit := object.UnstructuredSet{
+		deployment,
+		testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: test-namespace
+`, testutil.AddDependsOn(t, id(deployment))),
+	}
+	g, err = DependencyGraphWithOptions(explicit, Options{InferReferences: true})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, object.ObjMetadataSet{}, g.Dependencies(id(deployment)))
+	assert.Empty(t, g.References())
+
+	// References to objects in different actuation groups are skipped
+	g, err = DependencyGraphWithOptions(object.UnstructuredSet{ingress, service}, Options{
+		InferReferences: true,
+		ActuationGroups: []object.ObjMetadataSet{{id(ingress)}, {id(service)}},
+	})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, object.ObjMetadataSet{}, g.Dependencies(id(ingress)))
+
+	// Inference is disabled by default
+	g, err = DependencyGraphWithOptions(objs, Options{})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, object.ObjMetadataSet{}, g.Dependencies(id(deployment)))
+	assert.Empty(t, g.References())
+}
+
+func TestDependencyGraphBackendReferences(t *testing.T) {
+	deployment := testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webhook
+  namespace: webhook
+spec:
+  template:
+    metadata:
+      labels:
+        app: webhook
+        tier: backend
+`)
+	otherDeployment := testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: other
+  namespace: webhook
+spec:
+  template:
+    metadata:
+      labels:
+        app: other
+`)
+	service := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: webhook
+  namespace: webhook
+spec:
+  selector:
+    app: webhook
+`)
+	webhook := testutil.Unstructured(t, `
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: webhook
+webhooks:
+- name: validate.example.com
+  clientConfig:
+    service:
+      namespace: webhook
+      name: webhook
+`)
+	apiService := testutil.Unstructured(t, `
+apiVersion: apiregistration.k8s.io/v1
+kind: APIService
+metadata:
+  name: v1.example.com
+spec:
+  group: example.com
+  version: v1
+  service:
+    namespace: webhook
+    name: webhook
+`)
+	objs := object.UnstructuredSet{webhook, apiService, service, deployment, otherDeployment}
+	id := object.UnstructuredToObjMetadata
+
+	g, err := DependencyGraphWithOptions(objs, Options{InferReferences: true})
+	require.NoError(t, err)
+	testutil.AssertEqual(t, object.ObjMetadataSet{id(service), id(deployment)}, g.Dependencies(id(webhook)))
+	testutil.AssertEqual(t, object.ObjMetadataSet{id(service), id(deployment)}, g.Dependencies(id(apiService)))
+	assert.Equal(t, []ReferenceEdge{
+		{Edge: Edge{From: id(webhook), To: id(service)}, Field: "webhooks[0].clientConfig.service"},
+		{Edge: Edge{From: id(webhook), To: id(deployment)}, Field: "webhooks[0].clientConfig.service"},
+		{Edge: Edge{From: id(apiService), To: id(service)}, Field: "spec.service"},
+		{Edge: Edge{From: id(apiService), To: id(deployment)}, Field: "spec.service"},
+	}, g.References())
+}
+
 func TestHydrateSetList(t *testing.T) {
 	testCases := map[string]struct {
 		idSetList []object.ObjMetadataSet
@@ -1426,7 +1901,7 @@ func TestAddDependsOnEdges(t *testing.T) {
 		t.Run(tn, func(t *testing.T) {
 			g := New()
 			ids := object.UnstructuredSetToObjMetadataSet(tc.objs)
-			err := addDependsOnEdges(g, tc.objs, ids)
+			err := addDependsOnEdges(g, tc.objs, ids, false)
 			if tc.expectedError != nil {
 				assert.EqualError(t, err, tc.expectedError.Error())
 			} else {
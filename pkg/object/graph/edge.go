@@ -16,6 +16,14 @@ type Edge struct {
 	To   object.ObjMetadata
 }
 
+// ReferenceEdge is an edge inferred from a field of the "from" object that
+// references the "to" object.
+type ReferenceEdge struct {
+	Edge
+	// Field is the path of the field that holds the reference.
+	Field string
+}
+
 // SortableEdges sorts a list of edges alphanumerically by From and then To.
 type SortableEdges []Edge
 
@@ -11,6 +11,7 @@ import (
 	"sort"
 
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/dependson"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 	"sigs.k8s.io/cli-utils/pkg/ordering"
 )
@@ -23,6 +24,15 @@ type Graph struct {
 	edges map[object.ObjMetadata]object.ObjMetadataSet
 	// map "to" vertex -> list of "from" vertices
 	reverseEdges map[object.ObjMetadata]object.ObjMetadataSet
+	// edges inferred from object references, in the order they were added
+	references []ReferenceEdge
+	// map "from" vertex -> list of "to" objects outside the graph
+	externalEdges map[object.ObjMetadata]object.ObjMetadataSet
+	// map edge -> condition the "to" object must meet, if not Current
+	edgeConditions map[Edge]dependson.Condition
+	// synthetic vertices that order the vertices on either side of them,
+	// hidden from the callers of the graph
+	barriers map[object.ObjMetadata]struct{}
 }
 
 // New returns a pointer to an empty Graph data structure.
@@ -30,6 +40,9 @@ func New() *Graph {
 	g := &Graph{}
 	g.edges = make(map[object.ObjMetadata]object.ObjMetadataSet)
 	g.reverseEdges = make(map[object.ObjMetadata]object.ObjMetadataSet)
+	g.externalEdges = make(map[object.ObjMetadata]object.ObjMetadataSet)
+	g.edgeConditions = make(map[Edge]dependson.Condition)
+	g.barriers = make(map[object.ObjMetadata]struct{})
 	return g
 }
 
@@ -81,6 +94,87 @@ func (g *Graph) AddEdge(from object.ObjMetadata, to object.ObjMetadata) {
 	}
 }
 
+// addBarrier adds a synthetic vertex with edges from each of the "from"
+// vertices to the barrier, and from the barrier to each of the "to" vertices.
+// This makes every "from" vertex depend on every "to" vertex with a linear
+// number of edges. Barriers are not returned by Dependencies, Dependents,
+// Sort or Components, which follow the edges through them.
+func (g *Graph) addBarrier(barrier object.ObjMetadata, from object.ObjMetadataSet, to object.ObjMetadataSet) {
+	g.barriers[barrier] = struct{}{}
+	g.AddVertex(barrier)
+	for _, v := range from {
+		g.AddEdge(v, barrier)
+	}
+	for _, v := range to {
+		g.AddEdge(barrier, v)
+	}
+}
+
+// isBarrier returns true if the vertex was added by addBarrier.
+func (g *Graph) isBarrier(v object.ObjMetadata) bool {
+	_, found := g.barriers[v]
+	return found
+}
+
+// skipBarriers returns the vertices, replacing each barrier with the vertices
+// adjacent to it in the passed edge map, without duplicates.
+func (g *Graph) skipBarriers(edgeMap map[object.ObjMetadata]object.ObjMetadataSet, vertices object.ObjMetadataSet) object.ObjMetadataSet {
+	result := make(object.ObjMetadataSet, 0, len(vertices))
+	seen := make(map[object.ObjMetadata]struct{}, len(vertices))
+	stack := slices.Clone(vertices)
+	slices.Reverse(stack)
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, found := seen[v]; found {
+			continue
+		}
+		seen[v] = struct{}{}
+		if !g.isBarrier(v) {
+			result = append(result, v)
+			continue
+		}
+		adj := slices.Clone(edgeMap[v])
+		slices.Reverse(adj)
+		stack = append(stack, adj...)
+	}
+	return result
+}
+
+// withoutBarriers returns a copy of the edge map without barrier vertices,
+// where the edges to a barrier are replaced with edges through it.
+func (g *Graph) withoutBarriers(edgeMap map[object.ObjMetadata]object.ObjMetadataSet) map[object.ObjMetadata]object.ObjMetadataSet {
+	result := make(map[object.ObjMetadata]object.ObjMetadataSet, len(edgeMap))
+	for v, adj := range edgeMap {
+		if !g.isBarrier(v) {
+			result[v] = g.skipBarriers(edgeMap, adj)
+		}
+	}
+	return result
+}
+
+// AddExternalEdge adds an edge from an ObjMetadata vertex to an object that
+// is not a vertex of the graph. External edges are not sorted, but are
+// returned by ExternalDependencies.
+func (g *Graph) AddExternalEdge(from object.ObjMetadata, to object.ObjMetadata) {
+	g.AddVertex(from)
+	if !slices.Contains(g.externalEdges[from], to) {
+		g.externalEdges[from] = append(g.externalEdges[from], to)
+	}
+}
+
+// SetEdgeCondition sets the condition that the "to" object of an edge must
+// meet before the "from" object is actuated. The default, and the zero
+// Condition, is Current.
+func (g *Graph) SetEdgeCondition(from object.ObjMetadata, to object.ObjMetadata, c dependson.Condition) {
+	edge := Edge{From: from, To: to}
+	if c.Type == "" || c.Type == dependson.ConditionCurrent {
+		delete(g.edgeConditions, edge)
+		return
+	}
+	g.edgeConditions[edge] = c
+}
+
 // edgeMapToList returns a sorted slice of directed graph edges (vertex pairs).
 func edgeMapToList(edgeMap map[object.ObjMetadata]object.ObjMetadataSet) []Edge {
 	edges := []Edge{}
@@ -107,7 +201,7 @@ func (g *Graph) isAdjacent(from object.ObjMetadata, to object.ObjMetadata) bool
 
 // Size returns the number of vertices in the graph.
 func (g *Graph) Size() int {
-	return len(g.edges)
+	return len(g.edges) - len(g.barriers)
 }
 
 // removeVertex removes the passed vertex as well as any edges into the vertex.
@@ -126,9 +220,7 @@ func (g *Graph) Dependencies(from object.ObjMetadata) object.ObjMetadataSet {
 	if !exists {
 		return nil
 	}
-	c := make(object.ObjMetadataSet, len(edgesFrom))
-	copy(c, edgesFrom)
-	return c
+	return g.skipBarriers(g.edges, edgesFrom)
 }
 
 // Dependents returns the objects that depend on this object.
@@ -137,9 +229,65 @@ func (g *Graph) Dependents(to object.ObjMetadata) object.ObjMetadataSet {
 	if !exists {
 		return nil
 	}
-	c := make(object.ObjMetadataSet, len(edgesTo))
-	copy(c, edgesTo)
-	return c
+	return g.skipBarriers(g.reverseEdges, edgesTo)
+}
+
+// ExternalDependencies returns the objects outside the graph that this object
+// depends on.
+func (g *Graph) ExternalDependencies(from object.ObjMetadata) object.ObjMetadataSet {
+	return slices.Clone(g.externalEdges[from])
+}
+
+// ExternalEdges returns a sorted slice of the edges to objects outside the
+// graph.
+func (g *Graph) ExternalEdges() []Edge {
+	return edgeMapToList(g.externalEdges)
+}
+
+// DependentConditions returns the unique conditions, other than Current, that
+// the dependents of this object require it to meet, ordered by dependent.
+func (g *Graph) DependentConditions(to object.ObjMetadata) []dependson.Condition {
+	var edges []Edge
+	for edge := range g.edgeConditions {
+		if edge.To == to {
+			edges = append(edges, edge)
+		}
+	}
+	sort.Sort(SortableEdges(edges))
+	var conds []dependson.Condition
+	for _, edge := range edges {
+		if c := g.edgeConditions[edge]; !slices.Contains(conds, c) {
+			conds = append(conds, c)
+		}
+	}
+	return conds
+}
+
+// References returns the edges that were inferred from object references.
+func (g *Graph) References() []ReferenceEdge {
+	return slices.Clone(g.references)
+}
+
+// reachable returns true if the "to" vertex can be reached from the "from"
+// vertex by following edges, i.e. if "from" depends on "to", directly or
+// indirectly.
+func (g *Graph) reachable(from object.ObjMetadata, to object.ObjMetadata) bool {
+	visited := map[object.ObjMetadata]struct{}{from: {}}
+	stack := object.ObjMetadataSet{from}
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if next == to {
+			return true
+		}
+		for _, w := range g.edges[next] {
+			if _, found := visited[w]; !found {
+				visited[w] = struct{}{}
+				stack = append(stack, w)
+			}
+		}
+	}
+	return false
 }
 
 // Sort returns the ordered set of vertices after a topological sort.
@@ -153,6 +301,7 @@ func (g *Graph) Sort() ([]object.ObjMetadataSet, error) {
 	}
 
 	sorted := []object.ObjMetadataSet{}
+	g.removeBarrierLeaves(edges)
 	for len(edges) > 0 {
 		// Identify all the leaf vertices.
 		leafVertices := object.ObjMetadataSet{}
@@ -165,6 +314,7 @@ func (g *Graph) Sort() ([]object.ObjMetadataSet, error) {
 		// where remaining edges define the cycle.
 		if len(leafVertices) == 0 {
 			// Error can be ignored, so return the full set list
+			edges = g.withoutBarriers(edges)
 			return sorted, validation.NewError(CyclicDependencyError{
 				Edges: edgeMapToList(edges),
 			}, edgeMapKeys(edges)...)
@@ -174,6 +324,69 @@ func (g *Graph) Sort() ([]object.ObjMetadataSet, error) {
 			removeVertex(edges, v)
 		}
 		sorted = append(sorted, leafVertices)
+		// Remove barriers as soon as they become leaves, so that they don't
+		// delay the vertices that depend on them by a set.
+		g.removeBarrierLeaves(edges)
 	}
 	return sorted, nil
 }
+
+// removeBarrierLeaves removes the barrier vertices that have no edges, until
+// there are none left.
+func (g *Graph) removeBarrierLeaves(edges map[object.ObjMetadata]object.ObjMetadataSet) {
+	if len(g.barriers) == 0 {
+		return
+	}
+	for {
+		var leaves object.ObjMetadataSet
+		for v, adj := range edges {
+			if len(adj) == 0 && g.isBarrier(v) {
+				leaves = append(leaves, v)
+			}
+		}
+		if len(leaves) == 0 {
+			return
+		}
+		for _, v := range leaves {
+			removeVertex(edges, v)
+		}
+	}
+}
+
+// Components returns the weakly connected components of the graph, i.e. the
+// sets of vertices that are connected by edges, ignoring edge direction.
+// Vertices in different components do not depend on each other, directly or
+// indirectly. Each component is sorted, and the components are ordered by
+// their first vertex.
+func (g *Graph) Components() []object.ObjMetadataSet {
+	visited := make(map[object.ObjMetadata]struct{}, len(g.edges))
+	components := []object.ObjMetadataSet{}
+	// Iterate over sorted vertices for deterministic output.
+	for _, v := range edgeMapKeys(g.edges) {
+		if _, found := visited[v]; found || g.isBarrier(v) {
+			continue
+		}
+		// Depth-first search in both edge directions.
+		component := object.ObjMetadataSet{}
+		stack := object.ObjMetadataSet{v}
+		visited[v] = struct{}{}
+		for len(stack) > 0 {
+			next := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !g.isBarrier(next) {
+				component = append(component, next)
+			}
+			for _, adj := range []object.ObjMetadataSet{g.edges[next], g.reverseEdges[next]} {
+				for _, w := range adj {
+					if _, found := visited[w]; !found {
+						visited[w] = struct{}{}
+						stack = append(stack, w)
+					}
+				}
+			}
+		}
+		sort.Sort(ordering.SortableMetas(component))
+		components = append(components, component)
+	}
+	return components
+}
@@ -254,3 +254,58 @@ func TestGraphDependents(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphComponents(t *testing.T) {
+	testCases := map[string]struct {
+		vertices object.ObjMetadataSet
+		edges    []Edge
+		expected []object.ObjMetadataSet
+	}{
+		"empty graph": {
+			vertices: object.ObjMetadataSet{},
+			edges:    []Edge{},
+			expected: []object.ObjMetadataSet{},
+		},
+		"no edges": {
+			vertices: object.ObjMetadataSet{o3, o1, o2},
+			edges:    []Edge{},
+			expected: []object.ObjMetadataSet{{o1}, {o2}, {o3}},
+		},
+		"one component": {
+			vertices: object.ObjMetadataSet{o1, o2, o3},
+			edges:    []Edge{e1, e2},
+			expected: []object.ObjMetadataSet{{o1, o2, o3}},
+		},
+		"edge direction ignored": {
+			vertices: object.ObjMetadataSet{o1, o2, o3},
+			edges: []Edge{
+				{From: o1, To: o3},
+				{From: o2, To: o3},
+			},
+			expected: []object.ObjMetadataSet{{o1, o2, o3}},
+		},
+		"two components": {
+			vertices: object.ObjMetadataSet{o1, o2, o3, o4, o5},
+			edges: []Edge{
+				{From: o1, To: o3},
+				{From: o2, To: o4},
+				e8,
+			},
+			expected: []object.ObjMetadataSet{{o1, o3}, {o2, o4, o5}},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			g := New()
+			for _, vertex := range tc.vertices {
+				g.AddVertex(vertex)
+			}
+			for _, edge := range tc.edges {
+				g.AddEdge(edge.From, edge.To)
+			}
+
+			testutil.AssertEqual(t, tc.expected, g.Components())
+		})
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+var (
+	configMapGK      = schema.GroupKind{Kind: "ConfigMap"}
+	secretGK         = schema.GroupKind{Kind: "Secret"}
+	serviceAccountGK = schema.GroupKind{Kind: "ServiceAccount"}
+	serviceGK        = schema.GroupKind{Kind: "Service"}
+	priorityClassGK  = schema.GroupKind{Group: "scheduling.k8s.io", Kind: "PriorityClass"}
+	roleGK           = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "Role"}
+	clusterRoleGK    = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}
+)
+
+// podSpecFields maps the kinds of workloads to the path of their pod spec.
+var podSpecFields = map[schema.GroupKind][]string{
+	{Kind: "Pod"}:                        {"spec"},
+	{Kind: "ReplicationController"}:      {"spec", "template", "spec"},
+	{Group: "apps", Kind: "Deployment"}:  {"spec", "template", "spec"},
+	{Group: "apps", Kind: "ReplicaSet"}:  {"spec", "template", "spec"},
+	{Group: "apps", Kind: "StatefulSet"}: {"spec", "template", "spec"},
+	{Group: "apps", Kind: "DaemonSet"}:   {"spec", "template", "spec"},
+	{Group: "batch", Kind: "Job"}:        {"spec", "template", "spec"},
+	{Group: "batch", Kind: "CronJob"}:    {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// reference is an object referenced by a field of another object.
+type reference struct {
+	id    object.ObjMetadata
+	field string
+	// backend is true if the referenced object is a Service that must have
+	// ready endpoints, like the Service backing a webhook or an APIService.
+	backend bool
+}
+
+// addReferenceEdges adds edges to the dependency graph from objects to the
+// objects they reference in the same actuation group, like from a Deployment
+// to the ConfigMaps used by its pod template. Objects that reference a
+// backend Service also get edges to the workloads selected by the Service,
+// so that they are applied after the Service has ready endpoints. Edges that
+// would create a cycle are skipped, so that explicit dependencies take
+// precedence.
+// The objs and ids must match in order and length (optimization).
+func addReferenceEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMetadataSet, groupIndexes []int) {
+	groups := make(map[object.ObjMetadata]int, len(ids))
+	objsByID := make(map[object.ObjMetadata]*unstructured.Unstructured, len(ids))
+	for i, id := range ids {
+		groups[id] = groupIndexes[i]
+		objsByID[id] = objs[i]
+	}
+	for i, obj := range objs {
+		from := ids[i]
+		seen := make(map[object.ObjMetadata]struct{})
+		refs := objectReferences(obj)
+		refs = withBackendWorkloads(refs, objsByID, objs, ids)
+		for _, ref := range refs {
+			to := ref.id
+			if to == from {
+				continue
+			}
+			if groupIndex, found := groups[to]; !found || groupIndex != groupIndexes[i] {
+				continue
+			}
+			if _, found := seen[to]; found {
+				continue
+			}
+			seen[to] = struct{}{}
+			// Explicit dependencies don't need to be inferred.
+			if g.isAdjacent(from, to) {
+				continue
+			}
+			if g.reachable(to, from) {
+				klog.V(3).Infof("skipping inferred edge from: %s, to: %s: would create a cycle", from, to)
+				continue
+			}
+			klog.V(3).Infof("adding inferred edge from: %s, to: %s (%s)", from, to, ref.field)
+			g.AddEdge(from, to)
+			g.references = append(g.references, ReferenceEdge{
+				Edge:  Edge{From: from, To: to},
+				Field: ref.field,
+			})
+		}
+	}
+}
+
+// objectReferences returns the objects referenced by the supported fields of
+// the object, in field order.
+func objectReferences(obj *unstructured.Unstructured) []reference {
+	gk := obj.GroupVersionKind().GroupKind()
+	if fields, found := podSpecFields[gk]; found {
+		podSpec, found, err := unstructured.NestedMap(obj.Object, fields...)
+		if err != nil || !found {
+			return nil
+		}
+		return podSpecReferences(podSpec, strings.Join(fields, "."), obj.GetNamespace())
+	}
+	switch gk {
+	case schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"},
+		schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:
+		return roleBindingReferences(obj)
+	case schema.GroupKind{Group: "networking.k8s.io", Kind: "Ingress"}:
+		return ingressReferences(obj)
+	case schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"},
+		schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:
+		return webhookReferences(obj)
+	case schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"}:
+		return apiServiceReferences(obj)
+	}
+	return nil
+}
+
+// withBackendWorkloads returns the references, with the workloads selected
+// by each backend Service added after the Service. Services that are not in
+// the object set are ignored.
+// The objs and ids must match in order and length (optimization).
+func withBackendWorkloads(refs []reference, objsByID map[object.ObjMetadata]*unstructured.Unstructured,
+	objs object.UnstructuredSet, ids object.ObjMetadataSet) []reference {
+	var result []reference
+	for _, ref := range refs {
+		result = append(result, ref)
+		if !ref.backend {
+			continue
+		}
+		service, found := objsByID[ref.id]
+		if !found {
+			continue
+		}
+		selector, found, err := unstructured.NestedStringMap(service.Object, "spec", "selector")
+		if err != nil || !found || len(selector) == 0 {
+			continue
+		}
+		for i, obj := range objs {
+			if obj.GetNamespace() != service.GetNamespace() {
+				continue
+			}
+			if podLabels, found := podTemplateLabels(obj); found &&
+				labels.SelectorFromSet(selector).Matches(labels.Set(podLabels)) {
+				result = append(result, reference{
+					id:    ids[i],
+					field: ref.field,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// podTemplateLabels returns the labels of the pods created by a workload,
+// or false if the object is not a workload.
+func podTemplateLabels(obj *unstructured.Unstructured) (map[string]string, bool) {
+	fields, found := podSpecFields[obj.GroupVersionKind().GroupKind()]
+	if !found {
+		return nil, false
+	}
+	// The labels are in the metadata next to the pod spec.
+	path := append(append([]string{}, fields[:len(fields)-1]...), "metadata", "labels")
+	podLabels, _, err := unstructured.NestedStringMap(obj.Object, path...)
+	if err != nil {
+		return nil, false
+	}
+	return podLabels, true
+}
+
+// podSpecReferences returns the ConfigMaps, Secrets, ServiceAccount and
+// PriorityClass referenced by a pod spec. PersistentVolumeClaims are skipped,
+// because claims of a StorageClass with the WaitForFirstConsumer binding mode
+// are not bound, so not Current, until a pod uses them.
+func podSpecReferences(podSpec map[string]any, path, namespace string) []reference {
+	var refs []reference
+	add := func(gk schema.GroupKind, namespace, name, field string) {
+		if name == "" {
+			return
+		}
+		refs = append(refs, reference{
+			id: object.ObjMetadata{
+				GroupKind: gk,
+				Namespace: namespace,
+				Name:      name,
+			},
+			field: path + "." + field,
+		})
+	}
+
+	if name := nestedString(podSpec, "serviceAccountName"); name != "" {
+		add(serviceAccountGK, namespace, name, "serviceAccountName")
+	} else {
+		add(serviceAccountGK, namespace, nestedString(podSpec, "serviceAccount"), "serviceAccount")
+	}
+	add(priorityClassGK, "", nestedString(podSpec, "priorityClassName"), "priorityClassName")
+	for i, secret := range nestedMaps(podSpec, "imagePullSecrets") {
+		add(secretGK, namespace, nestedString(secret, "name"),
+			fmt.Sprintf("imagePullSecrets[%d].name", i))
+	}
+	for i, volume := range nestedMaps(podSpec, "volumes") {
+		field := fmt.Sprintf("volumes[%d]", i)
+		add(configMapGK, namespace, nestedString(volume, "configMap", "name"),
+			field+".configMap.name")
+		add(secretGK, namespace, nestedString(volume, "secret", "secretName"),
+			field+".secret.secretName")
+		for j, source := range nestedMaps(volume, "projected", "sources") {
+			sourceField := fmt.Sprintf("%s.projected.sources[%d]", field, j)
+			add(configMapGK, namespace, nestedString(source, "configMap", "name"),
+				sourceField+".configMap.name")
+			add(secretGK, namespace, nestedString(source, "secret", "name"),
+				sourceField+".secret.name")
+		}
+	}
+	for _, containersField := range []string{"initContainers", "containers"} {
+		for i, container := range nestedMaps(podSpec, containersField) {
+			field := fmt.Sprintf("%s[%d]", containersField, i)
+			for j, env := range nestedMaps(container, "env") {
+				envField := fmt.Sprintf("%s.env[%d].valueFrom", field, j)
+				add(configMapGK, namespace, nestedString(env, "valueFrom", "configMapKeyRef", "name"),
+					envField+".configMapKeyRef.name")
+				add(secretGK, namespace, nestedString(env, "valueFrom", "secretKeyRef", "name"),
+					envField+".secretKeyRef.name")
+			}
+			for j, envFrom := range nestedMaps(container, "envFrom") {
+				envFromField := fmt.Sprintf("%s.envFrom[%d]", field, j)
+				add(configMapGK, namespace, nestedString(envFrom, "configMapRef", "name"),
+					envFromField+".configMapRef.name")
+				add(secretGK, namespace, nestedString(envFrom, "secretRef", "name"),
+					envFromField+".secretRef.name")
+			}
+		}
+	}
+	return refs
+}
+
+// roleBindingReferences returns the Role or ClusterRole and the
+// ServiceAccounts referenced by a RoleBinding or ClusterRoleBinding.
+func roleBindingReferences(obj *unstructured.Unstructured) []reference {
+	var refs []reference
+	namespace := obj.GetNamespace()
+	roleRef, _, _ := unstructured.NestedMap(obj.Object, "roleRef")
+	if name := nestedString(roleRef, "name"); name != "" &&
+		nestedString(roleRef, "apiGroup") == roleGK.Group {
+		switch nestedString(roleRef, "kind") {
+		case roleGK.Kind:
+			refs = append(refs, reference{
+				id:    object.ObjMetadata{GroupKind: roleGK, Namespace: namespace, Name: name},
+				field: "roleRef",
+			})
+		case clusterRoleGK.Kind:
+			refs = append(refs, reference{
+				id:    object.ObjMetadata{GroupKind: clusterRoleGK, Name: name},
+				field: "roleRef",
+			})
+		}
+	}
+	for i, subject := range nestedMaps(obj.Object, "subjects") {
+		name := nestedString(subject, "name")
+		if nestedString(subject, "kind") != serviceAccountGK.Kind || name == "" {
+			continue
+		}
+		subjectNamespace := nestedString(subject, "namespace")
+		if subjectNamespace == "" {
+			subjectNamespace = namespace
+		}
+		refs = append(refs, reference{
+			id: object.ObjMetadata{
+				GroupKind: serviceAccountGK,
+				Namespace: subjectNamespace,
+				Name:      name,
+			},
+			field: fmt.Sprintf("subjects[%d]", i),
+		})
+	}
+	return refs
+}
+
+// ingressReferences returns the Services referenced by the backends of an
+// Ingress.
+func ingressReferences(obj *unstructured.Unstructured) []reference {
+	var refs []reference
+	add := func(name, field string) {
+		if name == "" {
+			return
+		}
+		refs = append(refs, reference{
+			id: object.ObjMetadata{
+				GroupKind: serviceGK,
+				Namespace: obj.GetNamespace(),
+				Name:      name,
+			},
+			field: field,
+		})
+	}
+	add(nestedString(obj.Object, "spec", "defaultBackend", "service", "name"),
+		"spec.defaultBackend.service.name")
+	for i, rule := range nestedMaps(obj.Object, "spec", "rules") {
+		for j, path := range nestedMaps(rule, "http", "paths") {
+			add(nestedString(path, "backend", "service", "name"),
+				fmt.Sprintf("spec.rules[%d].http.paths[%d].backend.service.name", i, j))
+		}
+	}
+	return refs
+}
+
+// webhookReferences returns the Services backing the webhooks of a
+// ValidatingWebhookConfiguration or MutatingWebhookConfiguration.
+func webhookReferences(obj *unstructured.Unstructured) []reference {
+	var refs []reference
+	for i, webhook := range nestedMaps(obj.Object, "webhooks") {
+		name := nestedString(webhook, "clientConfig", "service", "name")
+		if name == "" {
+			continue
+		}
+		refs = append(refs, reference{
+			id: object.ObjMetadata{
+				GroupKind: serviceGK,
+				Namespace: nestedString(webhook, "clientConfig", "service", "namespace"),
+				Name:      name,
+			},
+			field:   fmt.Sprintf("webhooks[%d].clientConfig.service", i),
+			backend: true,
+		})
+	}
+	return refs
+}
+
+// apiServiceReferences returns the Service backing an APIService.
+func apiServiceReferences(obj *unstructured.Unstructured) []reference {
+	name := nestedString(obj.Object, "spec", "service", "name")
+	if name == "" {
+		return nil
+	}
+	return []reference{{
+		id: object.ObjMetadata{
+			GroupKind: serviceGK,
+			Namespace: nestedString(obj.Object, "spec", "service", "namespace"),
+			Name:      name,
+		},
+		field:   "spec.service",
+		backend: true,
+	}}
+}
+
+// nestedString returns the string at the path, or an empty string if the
+// field is not found or is not a string.
+func nestedString(obj map[string]any, fields ...string) string {
+	value, _, _ := unstructured.NestedString(obj, fields...)
+	return value
+}
+
+// nestedMaps returns the maps in the list at the path, skipping any item
+// that is not a map.
+func nestedMaps(obj map[string]any, fields ...string) []map[string]any {
+	items, _, _ := unstructured.NestedSlice(obj, fields...)
+	result := make([]map[string]any, len(items))
+	for i, item := range items {
+		// Keep the indexes aligned with the field paths
+		m, _ := item.(map[string]any)
+		result[i] = m
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Hash returns a hex encoded SHA-256 hash of the object configuration.
+// The kyaml annotations and the status are ignored, because they are not
+// applied to the cluster. The passed object is not modified.
+func Hash(obj *unstructured.Unstructured) (string, error) {
+	u := obj.DeepCopy()
+	StripKyamlAnnotations(u)
+	if len(u.GetAnnotations()) == 0 {
+		// Don't distinguish between missing and empty annotations.
+		unstructured.RemoveNestedField(u.Object, "metadata", "annotations")
+	}
+	unstructured.RemoveNestedField(u.Object, "status")
+	// Map keys are sorted by json.Marshal, so the output is deterministic.
+	data, err := json.Marshal(u.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash object %s: %w", UnstructuredToObjMetadata(obj), err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
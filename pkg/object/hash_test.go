@@ -0,0 +1,44 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+)
+
+func TestHash(t *testing.T) {
+	crd := testutil.Unstructured(t, testCRD)
+	hash, err := object.Hash(crd)
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	// Hashing is deterministic
+	hash2, err := object.Hash(testutil.Unstructured(t, testCRD))
+	require.NoError(t, err)
+	assert.Equal(t, hash, hash2)
+
+	// Kyaml annotations and status are ignored
+	annotated := crd.DeepCopy()
+	annotated.SetAnnotations(map[string]string{
+		kioutil.PathAnnotation: "crd.yaml",
+	})
+	require.NoError(t, unstructured.SetNestedField(annotated.Object, "True", "status", "ready"))
+	hash2, err = object.Hash(annotated)
+	require.NoError(t, err)
+	assert.Equal(t, hash, hash2)
+	// The object is not modified
+	assert.Equal(t, "crd.yaml", annotated.GetAnnotations()[kioutil.PathAnnotation])
+
+	// Configuration changes are detected
+	hash2, err = object.Hash(testutil.Unstructured(t, testCRDv2))
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, hash2)
+}
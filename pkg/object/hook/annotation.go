@@ -0,0 +1,140 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hook reads the annotations that mark Jobs and Pods as lifecycle
+// hooks, which run to completion at defined points of an apply or destroy,
+// and are not tracked in the inventory.
+package hook
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// Annotation marks a Job or Pod as a lifecycle hook. The value is the
+	// Type of the hook, which defines when it is run.
+	Annotation = "config.kubernetes.io/hook"
+
+	// DeletePolicyAnnotation defines whether a hook is deleted after it
+	// completes. The value is a DeletePolicy. Hooks without the annotation
+	// are not deleted.
+	DeletePolicyAnnotation = "config.kubernetes.io/hook-delete-policy"
+)
+
+// Type defines when a hook is run.
+type Type string
+
+const (
+	// PreApply hooks are run before any object is applied.
+	PreApply Type = "pre-apply"
+	// PostApply hooks are run after all objects are applied and reconciled.
+	PostApply Type = "post-apply"
+	// PrePrune hooks are run before any object is pruned. They are only run
+	// if there are objects to prune.
+	PrePrune Type = "pre-prune"
+	// PostDestroy hooks are run after all objects are deleted by the
+	// Destroyer.
+	PostDestroy Type = "post-destroy"
+)
+
+// DeletePolicy defines whether a hook is deleted after it completes.
+type DeletePolicy string
+
+const (
+	// DeleteNever keeps the hook after it completes. This is the default.
+	DeleteNever DeletePolicy = "never"
+	// DeleteSucceeded deletes the hook after it completes successfully.
+	// Hooks that fail or time out are kept, to help debugging.
+	DeleteSucceeded DeletePolicy = "succeeded"
+	// DeleteAlways deletes the hook after it completes or fails.
+	DeleteAlways DeletePolicy = "always"
+)
+
+var (
+	jobGK = schema.GroupKind{Group: "batch", Kind: "Job"}
+	podGK = schema.GroupKind{Kind: "Pod"}
+)
+
+// HasAnnotation returns true if the config.kubernetes.io/hook annotation is
+// present, false if not.
+func HasAnnotation(u *unstructured.Unstructured) bool {
+	if u == nil {
+		return false
+	}
+	_, found := u.GetAnnotations()[Annotation]
+	return found
+}
+
+// ReadAnnotation reads and parses the hook annotation. Returns an error if
+// the value is not a known Type, or if the object is not a Job or Pod.
+// Returns an empty Type if the annotation is not present.
+func ReadAnnotation(u *unstructured.Unstructured) (Type, error) {
+	if u == nil {
+		return "", nil
+	}
+	typeStr, found := u.GetAnnotations()[Annotation]
+	if !found {
+		return "", nil
+	}
+	klog.V(5).Infof("hook annotation found for %s/%s: %q",
+		u.GetNamespace(), u.GetName(), typeStr)
+
+	var err error
+	switch t := Type(typeStr); t {
+	case PreApply, PostApply, PrePrune, PostDestroy:
+		if gk := u.GroupVersionKind().GroupKind(); gk != jobGK && gk != podGK {
+			err = fmt.Errorf("hooks must be Jobs or Pods, found %s", gk)
+			break
+		}
+		return t, nil
+	default:
+		err = fmt.Errorf("unknown hook type: %q", typeStr)
+	}
+	return "", object.InvalidAnnotationError{
+		Annotation: Annotation,
+		Cause:      err,
+	}
+}
+
+// ReadDeletePolicy reads and parses the hook-delete-policy annotation.
+// Returns DeleteNever if the annotation is not present.
+func ReadDeletePolicy(u *unstructured.Unstructured) (DeletePolicy, error) {
+	if u == nil {
+		return DeleteNever, nil
+	}
+	policyStr, found := u.GetAnnotations()[DeletePolicyAnnotation]
+	if !found {
+		return DeleteNever, nil
+	}
+	klog.V(5).Infof("hook-delete-policy annotation found for %s/%s: %q",
+		u.GetNamespace(), u.GetName(), policyStr)
+
+	switch p := DeletePolicy(policyStr); p {
+	case DeleteNever, DeleteSucceeded, DeleteAlways:
+		return p, nil
+	default:
+		return DeleteNever, object.InvalidAnnotationError{
+			Annotation: DeletePolicyAnnotation,
+			Cause:      fmt.Errorf("unknown hook delete policy: %q", policyStr),
+		}
+	}
+}
+
+// SplitHooks splits the objects into the hooks, which have the hook
+// annotation, and the other objects, preserving order.
+func SplitHooks(objs object.UnstructuredSet) (object.UnstructuredSet, object.UnstructuredSet) {
+	var hooks, others object.UnstructuredSet
+	for _, obj := range objs {
+		if HasAnnotation(obj) {
+			hooks = append(hooks, obj)
+		} else {
+			others = append(others, obj)
+		}
+	}
+	return hooks, others
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func newObject(gvk schema.GroupVersionKind, annotations map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetName("test")
+	u.SetAnnotations(annotations)
+	return u
+}
+
+func TestReadAnnotation(t *testing.T) {
+	jobGVK := schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	podGVK := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	tests := map[string]struct {
+		gvk           schema.GroupVersionKind
+		annotations   map[string]string
+		expected      Type
+		expectedFound bool
+		expectedError bool
+	}{
+		"no annotation": {
+			gvk: jobGVK,
+		},
+		"pre-apply job": {
+			gvk:           jobGVK,
+			annotations:   map[string]string{Annotation: "pre-apply"},
+			expected:      PreApply,
+			expectedFound: true,
+		},
+		"post-destroy pod": {
+			gvk:           podGVK,
+			annotations:   map[string]string{Annotation: "post-destroy"},
+			expected:      PostDestroy,
+			expectedFound: true,
+		},
+		"unknown type": {
+			gvk:           jobGVK,
+			annotations:   map[string]string{Annotation: "pre-install"},
+			expectedFound: true,
+			expectedError: true,
+		},
+		"not a job or pod": {
+			gvk:           deploymentGVK,
+			annotations:   map[string]string{Annotation: "post-apply"},
+			expectedFound: true,
+			expectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			u := newObject(tc.gvk, tc.annotations)
+			assert.Equal(t, tc.expectedFound, HasAnnotation(u))
+			hookType, err := ReadAnnotation(u)
+			if tc.expectedError {
+				require.Error(t, err)
+				assert.ErrorAs(t, err, &object.InvalidAnnotationError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, hookType)
+		})
+	}
+}
+
+func TestReadDeletePolicy(t *testing.T) {
+	jobGVK := schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+
+	u := newObject(jobGVK, nil)
+	policy, err := ReadDeletePolicy(u)
+	require.NoError(t, err)
+	assert.Equal(t, DeleteNever, policy)
+
+	u.SetAnnotations(map[string]string{DeletePolicyAnnotation: "succeeded"})
+	policy, err = ReadDeletePolicy(u)
+	require.NoError(t, err)
+	assert.Equal(t, DeleteSucceeded, policy)
+
+	u.SetAnnotations(map[string]string{DeletePolicyAnnotation: "on-success"})
+	_, err = ReadDeletePolicy(u)
+	assert.ErrorAs(t, err, &object.InvalidAnnotationError{})
+}
+
+func TestSplitHooks(t *testing.T) {
+	jobGVK := schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	hook1 := newObject(jobGVK, map[string]string{Annotation: "pre-apply"})
+	hook2 := newObject(jobGVK, map[string]string{Annotation: "invalid"})
+	obj := newObject(jobGVK, nil)
+
+	hooks, others := SplitHooks(object.UnstructuredSet{hook1, obj, hook2})
+	assert.Equal(t, object.UnstructuredSet{hook1, hook2}, hooks)
+	assert.Equal(t, object.UnstructuredSet{obj}, others)
+}
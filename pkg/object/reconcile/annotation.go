@@ -0,0 +1,66 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reconcile reads the annotations that configure how long the
+// applier waits for an object to reconcile.
+package reconcile
+
+import (
+	"errors"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// StabilityWindowAnnotation is the minimum duration an applied object
+	// must remain Current before it is considered reconciled. The value is
+	// a Go duration, like "30s" or "2m".
+	StabilityWindowAnnotation = "config.kubernetes.io/stability-window"
+
+	// TimeoutAnnotation is the maximum duration to wait for an object to
+	// reconcile after it is applied, or to be deleted after it is pruned.
+	// The value is a Go duration, like "30s" or "20m". Zero waits
+	// indefinitely.
+	TimeoutAnnotation = "config.kubernetes.io/reconcile-timeout"
+)
+
+// ReadStabilityWindow reads and parses the stability-window annotation.
+// Returns false if the annotation is not present.
+func ReadStabilityWindow(u *unstructured.Unstructured) (time.Duration, bool, error) {
+	return readDuration(u, StabilityWindowAnnotation)
+}
+
+// ReadTimeout reads and parses the reconcile-timeout annotation.
+// Returns false if the annotation is not present.
+func ReadTimeout(u *unstructured.Unstructured) (time.Duration, bool, error) {
+	return readDuration(u, TimeoutAnnotation)
+}
+
+// readDuration reads and parses an annotation with a non-negative duration
+// value.
+func readDuration(u *unstructured.Unstructured, annotation string) (time.Duration, bool, error) {
+	if u == nil {
+		return 0, false, nil
+	}
+	str, found := u.GetAnnotations()[annotation]
+	if !found {
+		return 0, false, nil
+	}
+	klog.V(5).Infof("%s annotation found for %s/%s: %q",
+		annotation, u.GetNamespace(), u.GetName(), str)
+
+	d, err := time.ParseDuration(str)
+	if err == nil && d < 0 {
+		err = errors.New("duration must not be negative")
+	}
+	if err != nil {
+		return 0, false, object.InvalidAnnotationError{
+			Annotation: annotation,
+			Cause:      err,
+		}
+	}
+	return d, true, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package reconcile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestReadStabilityWindow(t *testing.T) {
+	tests := map[string]struct {
+		annotations   map[string]string
+		expected      time.Duration
+		expectedFound bool
+		expectedError bool
+	}{
+		"no annotation": {},
+		"seconds": {
+			annotations:   map[string]string{StabilityWindowAnnotation: "30s"},
+			expected:      30 * time.Second,
+			expectedFound: true,
+		},
+		"zero": {
+			annotations:   map[string]string{StabilityWindowAnnotation: "0s"},
+			expectedFound: true,
+		},
+		"negative": {
+			annotations:   map[string]string{StabilityWindowAnnotation: "-1m"},
+			expectedError: true,
+		},
+		"invalid": {
+			annotations:   map[string]string{StabilityWindowAnnotation: "thirty"},
+			expectedError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			u.SetName("test")
+			u.SetAnnotations(tc.annotations)
+			d, found, err := ReadStabilityWindow(u)
+			if tc.expectedError {
+				require.Error(t, err)
+				assert.ErrorAs(t, err, &object.InvalidAnnotationError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, d)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
+
+func TestReadTimeout(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetName("test")
+	_, found, err := ReadTimeout(u)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	u.SetAnnotations(map[string]string{TimeoutAnnotation: "20m"})
+	d, found, err := ReadTimeout(u)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 20*time.Minute, d)
+
+	u.SetAnnotations(map[string]string{TimeoutAnnotation: "20"})
+	_, _, err = ReadTimeout(u)
+	assert.ErrorAs(t, err, &object.InvalidAnnotationError{})
+}
@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/cli-utils/pkg/multierror"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/reconcile"
 )
 
 // Validator contains functionality for validating a set of resources prior
@@ -34,6 +35,9 @@ func (v *Validator) Validate(objs []*unstructured.Unstructured) {
 		if err := v.validateNamespace(obj, crds); err != nil {
 			objErrors = append(objErrors, err)
 		}
+		if err := v.validateReconcileAnnotations(obj); err != nil {
+			objErrors = append(objErrors, err)
+		}
 		if len(objErrors) > 0 {
 			// one error per object
 			v.Collector.Collect(NewError(
@@ -92,3 +96,16 @@ func (v *Validator) validateNamespace(u *unstructured.Unstructured, crds []*unst
 	}
 	return nil
 }
+
+// validateReconcileAnnotations validates the values of the annotations that
+// configure how long to wait for the resource to reconcile.
+func (v *Validator) validateReconcileAnnotations(u *unstructured.Unstructured) error {
+	var errs []error
+	if _, _, err := reconcile.ReadStabilityWindow(u); err != nil {
+		errs = append(errs, err)
+	}
+	if _, _, err := reconcile.ReadTimeout(u); err != nil {
+		errs = append(errs, err)
+	}
+	return multierror.Wrap(errs...)
+}
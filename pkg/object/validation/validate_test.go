@@ -4,6 +4,7 @@
 package validation_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,6 +16,7 @@ import (
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
 	"sigs.k8s.io/cli-utils/pkg/multierror"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/reconcile"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
@@ -244,6 +246,37 @@ metadata:
 				},
 			),
 		},
+		"invalid stability window": {
+			resources: []*unstructured.Unstructured{
+				{
+					Object: map[string]any{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"metadata": map[string]any{
+							"name":      "foo",
+							"namespace": "default",
+							"annotations": map[string]any{
+								reconcile.StabilityWindowAnnotation: "-30s",
+							},
+						},
+					},
+				},
+			},
+			expectedError: validation.NewError(
+				object.InvalidAnnotationError{
+					Annotation: reconcile.StabilityWindowAnnotation,
+					Cause:      errors.New("duration must not be negative"),
+				},
+				object.ObjMetadata{
+					GroupKind: schema.GroupKind{
+						Group: "",
+						Kind:  "ConfigMap",
+					},
+					Name:      "foo",
+					Namespace: "default",
+				},
+			),
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -0,0 +1,53 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wave reads the apply-wave annotation, which groups objects into
+// numbered waves that are applied in ascending order.
+package wave
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// Annotation is the apply wave of an object. The value is an integer,
+	// which may be negative. Objects without the annotation are in wave 0.
+	Annotation = "config.kubernetes.io/apply-wave"
+)
+
+// HasAnnotation returns true if the config.kubernetes.io/apply-wave
+// annotation is present, false if not.
+func HasAnnotation(u *unstructured.Unstructured) bool {
+	if u == nil {
+		return false
+	}
+	_, found := u.GetAnnotations()[Annotation]
+	return found
+}
+
+// ReadAnnotation reads and parses the apply-wave annotation. Returns 0 if the
+// annotation is not present.
+func ReadAnnotation(u *unstructured.Unstructured) (int, error) {
+	if u == nil {
+		return 0, nil
+	}
+	waveStr, found := u.GetAnnotations()[Annotation]
+	if !found {
+		return 0, nil
+	}
+	klog.V(5).Infof("apply-wave annotation found for %s/%s: %q",
+		u.GetNamespace(), u.GetName(), waveStr)
+
+	wave, err := strconv.Atoi(waveStr)
+	if err != nil {
+		return 0, object.InvalidAnnotationError{
+			Annotation: Annotation,
+			Cause:      err,
+		}
+	}
+	return wave, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package wave
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestReadAnnotation(t *testing.T) {
+	testCases := map[string]struct {
+		annotations   map[string]string
+		expected      int
+		expectedFound bool
+		expectedError bool
+	}{
+		"no annotation": {},
+		"positive": {
+			annotations:   map[string]string{Annotation: "3"},
+			expected:      3,
+			expectedFound: true,
+		},
+		"negative": {
+			annotations:   map[string]string{Annotation: "-1"},
+			expected:      -1,
+			expectedFound: true,
+		},
+		"not a number": {
+			annotations:   map[string]string{Annotation: "first"},
+			expectedFound: true,
+			expectedError: true,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			u.SetName("test")
+			u.SetAnnotations(tc.annotations)
+			assert.Equal(t, tc.expectedFound, HasAnnotation(u))
+			wave, err := ReadAnnotation(u)
+			if tc.expectedError {
+				assert.ErrorAs(t, err, &object.InvalidAnnotationError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, wave)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ownership
+
+// Action is the change to the owner of an object.
+//
+//go:generate stringer -type=Action -linecomment
+type Action int
+
+const (
+	// ActionUnchanged means the object is already owned by the target.
+	ActionUnchanged Action = iota // Unchanged
+	// ActionUpdate means the owning-inventory annotation is updated.
+	ActionUpdate // Update
+	// ActionSkipped means the object is owned by another inventory, so it is
+	// not changed.
+	ActionSkipped // Skipped
+	// ActionNotFound means the object does not exist in the cluster.
+	ActionNotFound // NotFound
+	// ActionFailed means the object could not be read or updated.
+	ActionFailed // Failed
+)
@@ -0,0 +1,240 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ownership
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// AdoptOptions configures Adopt.
+type AdoptOptions struct {
+	// From is the inventory currently owning the objects, if any. Objects
+	// owned by From are removed from it. Objects owned by any other
+	// inventory are skipped.
+	From inventory.Info
+	// DryRun reports the changes without making them.
+	DryRun bool
+}
+
+// AbandonOptions configures Abandon.
+type AbandonOptions struct {
+	// DryRun reports the changes without making them.
+	DryRun bool
+}
+
+// Adopt transfers ownership of the objects to the inventory, from no
+// inventory or from the opts.From inventory. The returned channel receives an
+// OwnershipEvent for each object, and is closed when done.
+//
+// The objects are added to the inventory before their owning-inventory
+// annotation is updated, and only removed from the previous inventory after.
+// So each object is always listed by its owning inventory, even if Adopt is
+// interrupted.
+func (c *Client) Adopt(ctx context.Context, invClient inventory.Client, to inventory.Info,
+	ids object.ObjMetadataSet, opts AdoptOptions) <-chan event.Event {
+	eventChannel := make(chan event.Event)
+	go func() {
+		defer close(eventChannel)
+		var from string
+		if opts.From != nil {
+			from = opts.From.GetID().String()
+		}
+		toID := to.GetID().String()
+
+		results := make([]ObjectResult, 0, len(ids))
+		var adopted object.ObjMetadataSet
+		for _, id := range ids {
+			result := c.planOwner(ctx, id, from, toID)
+			// Objects without an owner can always be adopted.
+			if result.Action == ActionSkipped && result.Owner == "" {
+				result.Action = ActionUpdate
+			}
+			if result.Action == ActionUpdate || result.Action == ActionUnchanged {
+				adopted = append(adopted, id)
+			}
+			results = append(results, result)
+		}
+		if opts.DryRun {
+			sendOwnershipEvents(eventChannel, event.OwnershipAdopt, results)
+			return
+		}
+
+		labels, err := memberLabels(invClient, to)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		// Add the objects to the new inventory first.
+		if err := updateInventory(ctx, invClient, to, adopted, nil); err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		var removed object.ObjMetadataSet
+		for i := range results {
+			result := &results[i]
+			switch result.Action {
+			case ActionUpdate:
+				if err := c.setOwner(ctx, result.Identifier, result.Owner, toID, labels); err != nil {
+					result.Action = ActionFailed
+					result.Error = err
+					continue
+				}
+				if opts.From != nil && result.Owner == from {
+					removed = append(removed, result.Identifier)
+				}
+			case ActionUnchanged:
+				// Objects already owned by the inventory may still need its
+				// member labels, like when it is an ApplySet.
+				if len(labels) == 0 {
+					continue
+				}
+				if err := c.setOwner(ctx, result.Identifier, toID, toID, labels); err != nil {
+					result.Action = ActionFailed
+					result.Error = err
+				}
+			}
+		}
+		sendOwnershipEvents(eventChannel, event.OwnershipAdopt, results)
+
+		// Then remove them from the previous inventory.
+		if len(removed) > 0 {
+			if err := updateInventory(ctx, invClient, opts.From, nil, removed); err != nil {
+				handleError(eventChannel, err)
+			}
+		}
+	}()
+	return eventChannel
+}
+
+// Abandon detaches the objects from the inventory, removing their
+// owning-inventory annotation, so they are no longer pruned or deleted by it.
+// Objects owned by other inventories are skipped. The returned channel
+// receives an OwnershipEvent for each object, and is closed when done.
+//
+// The owning-inventory annotation is removed before the objects are removed
+// from the inventory, so the inventory never prunes an abandoned object.
+func (c *Client) Abandon(ctx context.Context, invClient inventory.Client, from inventory.Info,
+	ids object.ObjMetadataSet, opts AbandonOptions) <-chan event.Event {
+	eventChannel := make(chan event.Event)
+	go func() {
+		defer close(eventChannel)
+		fromID := from.GetID().String()
+
+		results := make([]ObjectResult, 0, len(ids))
+		for _, id := range ids {
+			results = append(results, c.planOwner(ctx, id, fromID, ""))
+		}
+		if opts.DryRun {
+			sendOwnershipEvents(eventChannel, event.OwnershipAbandon, results)
+			return
+		}
+
+		var removed object.ObjMetadataSet
+		for i := range results {
+			result := &results[i]
+			switch result.Action {
+			case ActionUpdate:
+				if err := c.setOwner(ctx, result.Identifier, fromID, "", nil); err != nil {
+					result.Action = ActionFailed
+					result.Error = err
+					continue
+				}
+				removed = append(removed, result.Identifier)
+			case ActionUnchanged, ActionNotFound:
+				// Not owned by any inventory, or deleted, so it is safe to
+				// remove from the inventory.
+				removed = append(removed, result.Identifier)
+			}
+		}
+		sendOwnershipEvents(eventChannel, event.OwnershipAbandon, results)
+
+		if len(removed) > 0 {
+			if err := updateInventory(ctx, invClient, from, nil, removed); err != nil {
+				handleError(eventChannel, err)
+			}
+		}
+	}()
+	return eventChannel
+}
+
+// updateInventory adds and removes object references from the inventory.
+// The inventory is created if it does not exist and objects are added.
+func updateInventory(ctx context.Context, invClient inventory.Client, info inventory.Info,
+	add, remove object.ObjMetadataSet) error {
+	inv, err := invClient.Get(ctx, info, inventory.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if len(add) == 0 {
+			return nil
+		}
+		inv, err = invClient.NewInventory(info)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get inventory: %w", err)
+	}
+	objs := inv.GetObjectRefs().Union(add).Diff(remove)
+	if objs.Equal(inv.GetObjectRefs()) {
+		return nil
+	}
+	inv.SetObjectRefs(objs)
+	var statuses object.ObjectStatusSet
+	removed := remove.ToMap()
+	for _, status := range inv.GetObjectStatuses() {
+		if _, found := removed[inventory.ObjMetadataFromObjectReference(status.ObjectReference)]; !found {
+			statuses = append(statuses, status)
+		}
+	}
+	inv.SetObjectStatuses(statuses)
+	klog.V(4).Infof("updating inventory (id: %q, added: %d, removed: %d)", info.GetID(), len(add), len(remove))
+	if err := invClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update inventory: %w", err)
+	}
+	return nil
+}
+
+func sendOwnershipEvents(eventChannel chan<- event.Event, op event.OwnershipOperation, results []ObjectResult) {
+	for _, result := range results {
+		e := event.OwnershipEvent{
+			Identifier: result.Identifier,
+			Operation:  op,
+			Owner:      result.Owner,
+		}
+		switch result.Action {
+		case ActionUpdate, ActionUnchanged:
+			e.Status = event.OwnershipSuccessful
+		case ActionSkipped:
+			e.Status = event.OwnershipSkipped
+			e.Error = fmt.Errorf("owned by another inventory: %q", result.Owner)
+		case ActionNotFound:
+			if op == event.OwnershipAbandon {
+				e.Status = event.OwnershipSuccessful
+			} else {
+				e.Status = event.OwnershipSkipped
+				e.Error = fmt.Errorf("object not found")
+			}
+		case ActionFailed:
+			e.Status = event.OwnershipFailed
+			e.Error = result.Error
+		}
+		eventChannel <- event.Event{
+			Type:           event.OwnershipType,
+			OwnershipEvent: e,
+		}
+	}
+}
+
+func handleError(eventChannel chan<- event.Event, err error) {
+	eventChannel <- event.Event{
+		Type: event.ErrorType,
+		ErrorEvent: event.ErrorEvent{
+			Err: err,
+		},
+	}
+}
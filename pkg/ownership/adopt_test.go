@@ -0,0 +1,170 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ownership
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestAdoptAndAbandon(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("default")
+	defer tf.Cleanup()
+
+	invClient, err := inventory.ConfigMapClientFactory{}.NewClient(tf)
+	require.NoError(t, err)
+	c := &Client{
+		Client: tf.FakeDynamicClient,
+		Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+			scheme.Scheme.PrioritizedVersionsAllGroups()...),
+	}
+
+	deployment := testutil.Unstructured(t, deploymentManifest)
+	secret := testutil.Unstructured(t, secretManifest)
+	pod := testutil.Unstructured(t, podManifest)
+	for _, obj := range []*unstructured.Unstructured{deployment, secret, pod} {
+		client, err := c.namespacedClient(object.UnstructuredToObjMetadata(obj))
+		require.NoError(t, err)
+		_, err = client.Create(context.TODO(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	secretID := object.UnstructuredToObjMetadata(secret)
+	podID := object.UnstructuredToObjMetadata(pod)
+
+	oldInfo := inventory.NewSingleObjectInfo("old-id",
+		types.NamespacedName{Namespace: "default", Name: "old-inventory"})
+	newInfo := inventory.NewSingleObjectInfo("new-id",
+		types.NamespacedName{Namespace: "default", Name: "new-inventory"})
+	oldInv, err := invClient.NewInventory(oldInfo)
+	require.NoError(t, err)
+	oldInv.SetObjectRefs(object.ObjMetadataSet{deploymentID})
+	require.NoError(t, invClient.CreateOrUpdate(context.TODO(), oldInv, inventory.UpdateOptions{}))
+
+	assertState := func(info inventory.Info, expectedObjs object.ObjMetadataSet, owners map[object.ObjMetadata]string) {
+		inv, err := invClient.Get(context.TODO(), info, inventory.GetOptions{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, expectedObjs, inv.GetObjectRefs())
+		for id, owner := range owners {
+			obj, err := c.getObject(context.TODO(), id)
+			require.NoError(t, err)
+			assert.Equal(t, owner, obj.GetAnnotations()[inventory.OwningInventoryKey], id)
+		}
+	}
+	collect := func(ch <-chan event.Event) []event.Event {
+		var events []event.Event
+		for e := range ch {
+			events = append(events, e)
+		}
+		return events
+	}
+
+	// Adopt all three objects from the old inventory
+	ids := object.ObjMetadataSet{deploymentID, secretID, podID}
+	events := collect(c.Adopt(context.TODO(), invClient, newInfo, ids, AdoptOptions{From: oldInfo}))
+	require.Len(t, events, 3)
+	require.NoError(t, testutil.VerifyEvents([]testutil.ExpEvent{
+		{
+			EventType: event.OwnershipType,
+			OwnershipEvent: &testutil.ExpOwnershipEvent{
+				Identifier: deploymentID,
+				Operation:  event.OwnershipAdopt,
+				Status:     event.OwnershipSuccessful,
+				Owner:      "old-id",
+			},
+		},
+		{
+			EventType: event.OwnershipType,
+			OwnershipEvent: &testutil.ExpOwnershipEvent{
+				Identifier: secretID,
+				Operation:  event.OwnershipAdopt,
+				Status:     event.OwnershipSkipped,
+				Owner:      "other-id",
+				Error:      testutil.EqualErrorString(`owned by another inventory: "other-id"`),
+			},
+		},
+		{
+			EventType: event.OwnershipType,
+			OwnershipEvent: &testutil.ExpOwnershipEvent{
+				Identifier: podID,
+				Operation:  event.OwnershipAdopt,
+				Status:     event.OwnershipSuccessful,
+			},
+		},
+	}, events))
+	assertState(oldInfo, nil, nil)
+	assertState(newInfo, object.ObjMetadataSet{deploymentID, podID}, map[object.ObjMetadata]string{
+		deploymentID: "new-id",
+		secretID:     "other-id",
+		podID:        "new-id",
+	})
+
+	// Abandon the deployment in dry-run, which changes nothing
+	events = collect(c.Abandon(context.TODO(), invClient, newInfo,
+		object.ObjMetadataSet{deploymentID}, AbandonOptions{DryRun: true}))
+	require.Len(t, events, 1)
+	assert.Equal(t, event.OwnershipSuccessful, events[0].OwnershipEvent.Status)
+	assertState(newInfo, object.ObjMetadataSet{deploymentID, podID}, map[object.ObjMetadata]string{
+		deploymentID: "new-id",
+	})
+
+	// Abandon the deployment
+	events = collect(c.Abandon(context.TODO(), invClient, newInfo,
+		object.ObjMetadataSet{deploymentID}, AbandonOptions{}))
+	require.Len(t, events, 1)
+	assert.Equal(t, event.OwnershipSuccessful, events[0].OwnershipEvent.Status)
+	assertState(newInfo, object.ObjMetadataSet{podID}, map[object.ObjMetadata]string{
+		deploymentID: "",
+	})
+}
+
+func TestAdoptAddsMemberLabels(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("default")
+	defer tf.Cleanup()
+
+	invClient, err := inventory.ApplySetClientFactory{Tooling: "kapply/v0.1.0"}.NewClient(tf)
+	require.NoError(t, err)
+	c := &Client{
+		Client: tf.FakeDynamicClient,
+		Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+			scheme.Scheme.PrioritizedVersionsAllGroups()...),
+	}
+
+	nn := types.NamespacedName{Namespace: "default", Name: "my-set"}
+	applySetID := inventory.ApplySetID(nn, inventory.SecretGVK.GroupKind())
+	info := inventory.NewSingleObjectInfo(inventory.ID(applySetID), nn)
+
+	// The pod is already owned by the ApplySet, but has no member label.
+	pod := testutil.Unstructured(t, podManifest)
+	pod.SetAnnotations(map[string]string{inventory.OwningInventoryKey: applySetID})
+	podID := object.UnstructuredToObjMetadata(pod)
+	client, err := c.namespacedClient(podID)
+	require.NoError(t, err)
+	_, err = client.Create(context.TODO(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	var events []event.Event
+	for e := range c.Adopt(context.TODO(), invClient, info, object.ObjMetadataSet{podID}, AdoptOptions{}) {
+		events = append(events, e)
+	}
+	require.Len(t, events, 1)
+	assert.Equal(t, event.OwnershipSuccessful, events[0].OwnershipEvent.Status)
+
+	obj, err := c.getObject(context.TODO(), podID)
+	require.NoError(t, err)
+	assert.Equal(t, applySetID, obj.GetLabels()[inventory.ApplySetPartOfLabel])
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ownership
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// MigrateOptions configures Migrate.
+type MigrateOptions struct {
+	// DryRun computes the changes without making them.
+	DryRun bool
+}
+
+// MigrateResult is the result of migrating an inventory.
+type MigrateResult struct {
+	// Objects are the results for each object in the source inventory.
+	Objects []ObjectResult
+	// SourceDeleted is true if the source inventory object was deleted.
+	SourceDeleted bool
+}
+
+// Migrate moves the contents of an inventory to another inventory, which may
+// use a different inventory client, namespace, name, or ID. Objects owned by
+// the source inventory are updated to be owned by the destination inventory.
+// Objects owned by other inventories are skipped, but kept in the destination
+// inventory, like in the source inventory.
+//
+// If the destination inventory already exists, the source inventory is merged
+// into it. The destination inventory is written before any objects are
+// updated, and the source inventory is only deleted after all objects have
+// been updated, so an interrupted migration can be safely run again.
+// The source and destination must not be the same inventory object.
+//
+// If the destination inventory client requires member labels, like the
+// ApplySet client, they are added to the objects owned by the destination.
+// This converts an existing inventory to an ApplySet.
+func (c *Client) Migrate(ctx context.Context,
+	from inventory.Client, fromInfo inventory.Info,
+	to inventory.Client, toInfo inventory.Info,
+	opts MigrateOptions,
+) (*MigrateResult, error) {
+	src, err := from.Get(ctx, fromInfo, inventory.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source inventory: %w", err)
+	}
+	dst, err := to.Get(ctx, toInfo, inventory.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		dst, err = to.NewInventory(toInfo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination inventory: %w", err)
+	}
+
+	fromID := fromInfo.GetID().String()
+	toID := toInfo.GetID().String()
+	result := &MigrateResult{}
+	for _, id := range src.GetObjectRefs() {
+		result.Objects = append(result.Objects, c.planOwner(ctx, id, fromID, toID))
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	labels, err := memberLabels(to, toInfo)
+	if err != nil {
+		return result, err
+	}
+	mergeInventory(dst, src)
+	klog.V(4).Infof("writing destination inventory (id: %q)", toID)
+	if err := to.CreateOrUpdate(ctx, dst, inventory.UpdateOptions{}); err != nil {
+		return result, fmt.Errorf("failed to write destination inventory: %w", err)
+	}
+	failed := 0
+	for i := range result.Objects {
+		objResult := &result.Objects[i]
+		switch objResult.Action {
+		case ActionUpdate:
+			if err := c.setOwner(ctx, objResult.Identifier, fromID, toID, labels); err != nil {
+				objResult.Action = ActionFailed
+				objResult.Error = err
+				failed++
+			}
+		case ActionUnchanged:
+			// Objects already owned by the destination may still need its
+			// member labels, like when converting to an ApplySet.
+			if len(labels) == 0 {
+				continue
+			}
+			if err := c.setOwner(ctx, objResult.Identifier, toID, toID, labels); err != nil {
+				objResult.Action = ActionFailed
+				objResult.Error = err
+				failed++
+			}
+		case ActionFailed:
+			failed++
+		}
+	}
+	if failed > 0 {
+		return result, fmt.Errorf("failed to update %d objects: the source inventory was not deleted", failed)
+	}
+	klog.V(4).Infof("deleting source inventory (id: %q)", fromID)
+	if err := from.Delete(ctx, fromInfo, inventory.DeleteOptions{}); err != nil {
+		return result, fmt.Errorf("failed to delete source inventory: %w", err)
+	}
+	result.SourceDeleted = true
+	return result, nil
+}
+
+// mergeInventory adds the object references and statuses of the source
+// inventory to the destination inventory. The revisions are copied if the
+// destination has none and both inventories store revisions.
+func mergeInventory(dst, src inventory.Inventory) {
+	dst.SetObjectRefs(dst.GetObjectRefs().Union(src.GetObjectRefs()))
+
+	statuses := dst.GetObjectStatuses()
+	found := make(map[object.ObjMetadata]struct{}, len(statuses))
+	for _, status := range statuses {
+		found[inventory.ObjMetadataFromObjectReference(status.ObjectReference)] = struct{}{}
+	}
+	for _, status := range src.GetObjectStatuses() {
+		if _, ok := found[inventory.ObjMetadataFromObjectReference(status.ObjectReference)]; !ok {
+			statuses = append(statuses, status)
+		}
+	}
+	dst.SetObjectStatuses(statuses)
+
+	srcRevs, srcOK := src.(inventory.RevisionInventory)
+	dstRevs, dstOK := dst.(inventory.RevisionInventory)
+	if srcOK && dstOK && len(dstRevs.GetRevisions()) == 0 {
+		dstRevs.SetRevisions(srcRevs.GetRevisions())
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ownership
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var deploymentManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: foo
+  namespace: default
+  annotations:
+    config.k8s.io/owning-inventory: old-id
+`
+
+var secretManifest = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: bar
+  namespace: default
+  annotations:
+    config.k8s.io/owning-inventory: other-id
+`
+
+var podManifest = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: baz
+  namespace: default
+`
+
+func newTestClient(objs ...*unstructured.Unstructured) *Client {
+	var runtimeObjs, metadataObjs []runtime.Object
+	for _, obj := range objs {
+		runtimeObjs = append(runtimeObjs, obj)
+		objMeta := &metav1.PartialObjectMetadata{}
+		objMeta.APIVersion = obj.GetAPIVersion()
+		objMeta.Kind = obj.GetKind()
+		objMeta.Name = obj.GetName()
+		objMeta.Namespace = obj.GetNamespace()
+		objMeta.Annotations = obj.GetAnnotations()
+		metadataObjs = append(metadataObjs, objMeta)
+	}
+	return &Client{
+		Client: dynamicfake.NewSimpleDynamicClient(scheme.Scheme, runtimeObjs...),
+		Mapper: testutil.NewFakeRESTMapper(
+			appsv1.SchemeGroupVersion.WithKind("Deployment"),
+			v1.SchemeGroupVersion.WithKind("Secret"),
+			v1.SchemeGroupVersion.WithKind("Pod"),
+		),
+		Metadata: metadatafake.NewSimpleMetadataClient(scheme.Scheme, metadataObjs...),
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	deployment := testutil.Unstructured(t, deploymentManifest)
+	secret := testutil.Unstructured(t, secretManifest)
+	pod := testutil.Unstructured(t, podManifest)
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	secretID := object.UnstructuredToObjMetadata(secret)
+	podID := object.UnstructuredToObjMetadata(pod)
+	objIDs := object.ObjMetadataSet{deploymentID, secretID, podID}
+
+	tests := map[string]struct {
+		dryRun          bool
+		expectedOwner   string
+		expectedDeleted bool
+	}{
+		"dry-run": {
+			dryRun:        true,
+			expectedOwner: "old-id",
+		},
+		"migrate": {
+			expectedOwner:   "new-id",
+			expectedDeleted: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// The pod is in the inventory, but not in the cluster.
+			c := newTestClient(deployment.DeepCopy(), secret.DeepCopy())
+			from := inventory.NewFakeClient(objIDs)
+			to := inventory.NewFakeClient(nil)
+			fromInfo := inventory.NewSimpleInfo("old-id", "default")
+			toInfo := inventory.NewSimpleInfo("new-id", "other")
+
+			result, err := c.Migrate(context.TODO(), from, fromInfo, to, toInfo,
+				MigrateOptions{DryRun: tc.dryRun})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDeleted, result.SourceDeleted)
+			assert.Equal(t, []ObjectResult{
+				{Identifier: deploymentID, Action: ActionUpdate, Owner: "old-id"},
+				{Identifier: secretID, Action: ActionSkipped, Owner: "other-id"},
+				{Identifier: podID, Action: ActionNotFound},
+			}, result.Objects)
+
+			if tc.dryRun {
+				assert.Empty(t, to.Inv.GetObjectRefs())
+			} else {
+				testutil.AssertEqual(t, objIDs, to.Inv.GetObjectRefs())
+			}
+
+			obj, err := c.getObject(context.TODO(), deploymentID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOwner, obj.GetAnnotations()[inventory.OwningInventoryKey])
+			obj, err = c.getObject(context.TODO(), secretID)
+			require.NoError(t, err)
+			assert.Equal(t, "other-id", obj.GetAnnotations()[inventory.OwningInventoryKey])
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
+)
+
+// listPageSize is the maximum number of objects to list in each request.
+const listPageSize = 500
+
+// Orphan is an object annotated as owned by an inventory that does not list
+// it.
+type Orphan struct {
+	Identifier object.ObjMetadata
+	// Owner is the owning-inventory annotation value of the object.
+	Owner string
+	// InventoryFound is true if the owning inventory exists, but does not
+	// list the object. Otherwise the owning inventory was not found.
+	InventoryFound bool
+}
+
+// FindOrphans lists the metadata of the objects of the specified resources in
+// all namespaces, a page at a time, and returns the objects with an
+// owning-inventory annotation that are not in the object references of the
+// owning inventory. Objects owned by inventories missing from invs are also
+// returned, so invs should include all the inventories in the cluster, of
+// every storage type. Lifecycle hooks are never orphans, because they
+// are owned by an inventory without being listed in it.
+func (c *Client) FindOrphans(ctx context.Context, invs []inventory.Inventory,
+	resources []schema.GroupVersionResource) ([]Orphan, error) {
+	invObjs := make(map[string]map[object.ObjMetadata]struct{}, len(invs))
+	for _, inv := range invs {
+		id := inv.Info().GetID().String()
+		objs, found := invObjs[id]
+		if !found {
+			objs = make(map[object.ObjMetadata]struct{}, len(inv.GetObjectRefs()))
+			invObjs[id] = objs
+		}
+		for _, ref := range inv.GetObjectRefs() {
+			objs[ref] = struct{}{}
+		}
+	}
+	var orphans []Orphan
+	for _, gvr := range resources {
+		// Listed metadata has no object kind, so map it from the resource.
+		gvk, err := c.Mapper.KindFor(gvr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map %s to a kind: %w", gvr, err)
+		}
+		opts := metav1.ListOptions{Limit: listPageSize}
+		for {
+			list, err := c.Metadata.Resource(gvr).List(ctx, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", gvr, err)
+			}
+			for i := range list.Items {
+				obj := &list.Items[i]
+				owner, found := obj.GetAnnotations()[inventory.OwningInventoryKey]
+				if _, isHook := obj.GetAnnotations()[hook.Annotation]; !found || owner == "" || isHook {
+					continue
+				}
+				id := object.ObjMetadata{
+					GroupKind: gvk.GroupKind(),
+					Namespace: obj.GetNamespace(),
+					Name:      obj.GetName(),
+				}
+				objs, invFound := invObjs[owner]
+				if _, listed := objs[id]; listed {
+					continue
+				}
+				orphans = append(orphans, Orphan{
+					Identifier:     id,
+					Owner:          owner,
+					InventoryFound: invFound,
+				})
+			}
+			if list.Continue == "" {
+				break
+			}
+			opts.Continue = list.Continue
+		}
+	}
+	return orphans, nil
+}
+
+// ListableResources returns the preferred version of each resource in the
+// cluster that supports listing, excluding subresources.
+func ListableResources(dc discovery.DiscoveryInterface) ([]schema.GroupVersionResource, error) {
+	lists, err := discovery.ServerPreferredResources(dc)
+	if err != nil {
+		if len(lists) == 0 {
+			return nil, err
+		}
+		// Partial discovery failures still return the other resources.
+		klog.Warningf("failed to discover some resources: %v", err)
+	}
+	var resources []schema.GroupVersionResource
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			resources = append(resources, gv.WithResource(resource.Name))
+		}
+	}
+	return resources, nil
+}
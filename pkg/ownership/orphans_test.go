@@ -0,0 +1,126 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ownership
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestFindOrphans(t *testing.T) {
+	// Owned by old-id, which lists it
+	deployment := testutil.Unstructured(t, deploymentManifest)
+	// Owned by other-id, which does not exist
+	secret := testutil.Unstructured(t, secretManifest)
+	// Owned by old-id, which does not list it
+	ownedPod := testutil.Unstructured(t, podManifest, testutil.AddOwningInv(t, "old-id"))
+	// Not owned
+	pod := testutil.Unstructured(t, podManifest)
+	pod.SetName("unowned")
+	// Lifecycle hook owned by old-id, which does not list it
+	hookPod := testutil.Unstructured(t, podManifest, testutil.AddOwningInv(t, "old-id"),
+		testutil.AddHook(hook.PreApply, hook.DeleteNever))
+	hookPod.SetName("hook")
+
+	c := newTestClient(deployment, secret, ownedPod, pod, hookPod)
+	invs := []inventory.Inventory{
+		&inventory.FakeInventory{
+			InventoryID: "old-id",
+			InventoryContents: inventory.InventoryContents{
+				ObjectRefs: object.ObjMetadataSet{object.UnstructuredToObjMetadata(deployment)},
+			},
+		},
+	}
+	resources := []schema.GroupVersionResource{
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+		{Version: "v1", Resource: "secrets"},
+		{Version: "v1", Resource: "pods"},
+	}
+
+	orphans, err := c.FindOrphans(context.TODO(), invs, resources)
+	require.NoError(t, err)
+	assert.Equal(t, []Orphan{
+		{
+			Identifier: object.UnstructuredToObjMetadata(secret),
+			Owner:      "other-id",
+		},
+		{
+			Identifier:     object.UnstructuredToObjMetadata(ownedPod),
+			Owner:          "old-id",
+			InventoryFound: true,
+		},
+	}, orphans)
+}
+
+func TestFindOrphansPaginated(t *testing.T) {
+	first := testutil.Unstructured(t, podManifest, testutil.AddOwningInv(t, "old-id"))
+	first.SetName("first")
+	second := testutil.Unstructured(t, podManifest, testutil.AddOwningInv(t, "old-id"))
+	second.SetName("second")
+	c := newTestClient(first, second)
+	metadataClient := &pagedMetadataClient{Interface: c.Metadata, pageSize: 1}
+	c.Metadata = metadataClient
+
+	orphans, err := c.FindOrphans(context.TODO(), nil, []schema.GroupVersionResource{
+		{Version: "v1", Resource: "pods"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Orphan{
+		{Identifier: object.UnstructuredToObjMetadata(first), Owner: "old-id"},
+		{Identifier: object.UnstructuredToObjMetadata(second), Owner: "old-id"},
+	}, orphans)
+	assert.Equal(t, []metav1.ListOptions{
+		{Limit: listPageSize},
+		{Limit: listPageSize, Continue: "1"},
+	}, metadataClient.requests)
+}
+
+// pagedMetadataClient is a metadata client that returns at most pageSize
+// objects from each List, and records the options of each List. The fake
+// client ignores the Limit and Continue options.
+type pagedMetadataClient struct {
+	metadata.Interface
+	pageSize int
+	requests []metav1.ListOptions
+}
+
+func (c *pagedMetadataClient) Resource(gvr schema.GroupVersionResource) metadata.Getter {
+	return &pagedMetadataGetter{Getter: c.Interface.Resource(gvr), client: c}
+}
+
+type pagedMetadataGetter struct {
+	metadata.Getter
+	client *pagedMetadataClient
+}
+
+func (g *pagedMetadataGetter) List(ctx context.Context, opts metav1.ListOptions) (*metav1.PartialObjectMetadataList, error) {
+	g.client.requests = append(g.client.requests, opts)
+	list, err := g.Getter.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	start := 0
+	if opts.Continue != "" {
+		if start, err = strconv.Atoi(opts.Continue); err != nil {
+			return nil, err
+		}
+	}
+	end := min(start+g.client.pageSize, len(list.Items))
+	if end < len(list.Items) {
+		list.Continue = strconv.Itoa(end)
+	}
+	list.Items = list.Items[start:end]
+	return list, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ownership changes which inventory owns applied objects, by updating
+// both the inventory objects and the owning-inventory annotation of the
+// objects themselves.
+package ownership
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Client changes the owning-inventory annotation of objects in the cluster.
+type Client struct {
+	Client dynamic.Interface
+	Mapper meta.RESTMapper
+	// Metadata is used to list objects without their spec and status.
+	Metadata metadata.Interface
+}
+
+// NewClient returns a new Client, using the dynamic client, REST mapper and
+// REST config from the factory.
+func NewClient(factory cmdutil.Factory) (*Client, error) {
+	client, err := factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	restConfig, err := factory.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	metadataClient, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Client:   client,
+		Mapper:   mapper,
+		Metadata: metadataClient,
+	}, nil
+}
+
+// ObjectResult is the result of changing the owner of one object.
+type ObjectResult struct {
+	Identifier object.ObjMetadata
+	Action     Action
+	// Owner is the owning-inventory annotation value of the live object,
+	// before any change. Empty if the object has no owner or was not found.
+	Owner string
+	// Error is set if the change failed.
+	Error error
+}
+
+// planOwner returns the action needed to change the owner of the object from
+// one inventory ID to another. If from is empty, objects without an owner are
+// also changed.
+func (c *Client) planOwner(ctx context.Context, id object.ObjMetadata, from, to string) ObjectResult {
+	result := ObjectResult{Identifier: id}
+	obj, err := c.getObject(ctx, id)
+	if err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			result.Action = ActionNotFound
+			return result
+		}
+		result.Action = ActionFailed
+		result.Error = err
+		return result
+	}
+	owner := obj.GetAnnotations()[inventory.OwningInventoryKey]
+	result.Owner = owner
+	switch {
+	case owner == to:
+		result.Action = ActionUnchanged
+	case owner == from:
+		result.Action = ActionUpdate
+	default:
+		result.Action = ActionSkipped
+	}
+	return result
+}
+
+// setOwner sets the owning-inventory annotation of the object to the
+// inventory ID, if it is still owned by the from inventory ID. An empty to
+// removes the annotation. An empty from matches objects without an owner.
+// The labels, if any, are added to the object.
+func (c *Client) setOwner(ctx context.Context, id object.ObjMetadata, from, to string,
+	labels map[string]string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := c.getObject(ctx, id)
+		if err != nil {
+			return err
+		}
+		annotations := obj.GetAnnotations()
+		if owner := annotations[inventory.OwningInventoryKey]; owner != from {
+			return fmt.Errorf("object owner changed: expected %q but got %q", from, owner)
+		}
+		if to == "" {
+			delete(annotations, inventory.OwningInventoryKey)
+		} else {
+			if annotations == nil {
+				annotations = make(map[string]string, 1)
+			}
+			annotations[inventory.OwningInventoryKey] = to
+		}
+		obj.SetAnnotations(annotations)
+		if len(labels) > 0 {
+			objLabels := obj.GetLabels()
+			if objLabels == nil {
+				objLabels = make(map[string]string, len(labels))
+			}
+			for k, v := range labels {
+				objLabels[k] = v
+			}
+			obj.SetLabels(objLabels)
+		}
+		klog.V(4).Infof("updating owner (object: %q, owner: %q)", id, to)
+		client, err := c.namespacedClient(id)
+		if err != nil {
+			return err
+		}
+		_, err = client.Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// memberLabels returns the labels required on the objects in the inventory,
+// if the inventory client requires any.
+func memberLabels(invClient inventory.Client, inv inventory.Info) (map[string]string, error) {
+	if labeler, ok := invClient.(inventory.MemberLabeler); ok {
+		return labeler.MemberLabels(inv)
+	}
+	return nil, nil
+}
+
+func (c *Client) getObject(ctx context.Context, id object.ObjMetadata) (*unstructured.Unstructured, error) {
+	client, err := c.namespacedClient(id)
+	if err != nil {
+		return nil, err
+	}
+	return client.Get(ctx, id.Name, metav1.GetOptions{})
+}
+
+func (c *Client) namespacedClient(id object.ObjMetadata) (dynamic.ResourceInterface, error) {
+	mapping, err := c.Mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Resource(mapping.Resource).Namespace(id.Namespace), nil
+}
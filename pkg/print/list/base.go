@@ -12,12 +12,17 @@ import (
 )
 
 type Formatter interface {
+	FormatInferredDependency(id event.InferredDependency) error
 	FormatValidationEvent(ve event.ValidationEvent) error
 	FormatApplyEvent(ae event.ApplyEvent) error
 	FormatStatusEvent(se event.StatusEvent) error
 	FormatPruneEvent(pe event.PruneEvent) error
 	FormatDeleteEvent(de event.DeleteEvent) error
 	FormatWaitEvent(we event.WaitEvent) error
+	FormatRollbackEvent(re event.RollbackEvent) error
+	FormatOwnershipEvent(oe event.OwnershipEvent) error
+	FormatRetryEvent(re event.RetryEvent) error
+	FormatHookDeleteEvent(he event.HookDeleteEvent) error
 	FormatErrorEvent(ee event.ErrorEvent) error
 	FormatActionGroupEvent(
 		age event.ActionGroupEvent,
@@ -68,6 +73,11 @@ func (b *BaseListPrinter) Print(ch <-chan event.Event, previewStrategy common.Dr
 		switch e.Type {
 		case event.InitType:
 			actionGroups = e.InitEvent.ActionGroups
+			for _, dep := range e.InitEvent.InferredDependencies {
+				if err := formatter.FormatInferredDependency(dep); err != nil {
+					return err
+				}
+			}
 		case event.ErrorType:
 			_ = formatter.FormatErrorEvent(e.ErrorEvent)
 			return e.ErrorEvent.Err
@@ -98,6 +108,22 @@ func (b *BaseListPrinter) Print(ch <-chan event.Event, previewStrategy common.Dr
 			if err := formatter.FormatWaitEvent(e.WaitEvent); err != nil {
 				return err
 			}
+		case event.RollbackType:
+			if err := formatter.FormatRollbackEvent(e.RollbackEvent); err != nil {
+				return err
+			}
+		case event.OwnershipType:
+			if err := formatter.FormatOwnershipEvent(e.OwnershipEvent); err != nil {
+				return err
+			}
+		case event.RetryType:
+			if err := formatter.FormatRetryEvent(e.RetryEvent); err != nil {
+				return err
+			}
+		case event.HookDeleteType:
+			if err := formatter.FormatHookDeleteEvent(e.HookDeleteEvent); err != nil {
+				return err
+			}
 		case event.ActionGroupType:
 			if err := formatter.FormatActionGroupEvent(
 				e.ActionGroupEvent,
@@ -34,6 +34,11 @@ type countingFormatter struct {
 	pruneEvents      []event.PruneEvent
 	deleteEvents     []event.DeleteEvent
 	waitEvents       []event.WaitEvent
+	rollbackEvents   []event.RollbackEvent
+	ownershipEvents  []event.OwnershipEvent
+	retryEvents      []event.RetryEvent
+	hookDeleteEvents []event.HookDeleteEvent
+	inferredDeps     []event.InferredDependency
 	errorEvent       event.ErrorEvent
 	actionGroupEvent []event.ActionGroupEvent
 }
@@ -68,6 +73,31 @@ func (c *countingFormatter) FormatWaitEvent(e event.WaitEvent) error {
 	return nil
 }
 
+func (c *countingFormatter) FormatRollbackEvent(e event.RollbackEvent) error {
+	c.rollbackEvents = append(c.rollbackEvents, e)
+	return nil
+}
+
+func (c *countingFormatter) FormatOwnershipEvent(e event.OwnershipEvent) error {
+	c.ownershipEvents = append(c.ownershipEvents, e)
+	return nil
+}
+
+func (c *countingFormatter) FormatRetryEvent(e event.RetryEvent) error {
+	c.retryEvents = append(c.retryEvents, e)
+	return nil
+}
+
+func (c *countingFormatter) FormatHookDeleteEvent(e event.HookDeleteEvent) error {
+	c.hookDeleteEvents = append(c.hookDeleteEvents, e)
+	return nil
+}
+
+func (c *countingFormatter) FormatInferredDependency(id event.InferredDependency) error {
+	c.inferredDeps = append(c.inferredDeps, id)
+	return nil
+}
+
 func (c *countingFormatter) FormatErrorEvent(e event.ErrorEvent) error {
 	c.errorEvent = e
 	return nil
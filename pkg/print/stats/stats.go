@@ -13,15 +13,21 @@ import (
 // reconciliation of resources. Each item in a stats list represents the stats
 // from all the events in a single action group.
 type Stats struct {
-	ApplyStats  ApplyStats
-	PruneStats  PruneStats
-	DeleteStats DeleteStats
-	WaitStats   WaitStats
+	ApplyStats      ApplyStats
+	PruneStats      PruneStats
+	DeleteStats     DeleteStats
+	WaitStats       WaitStats
+	RollbackStats   RollbackStats
+	OwnershipStats  OwnershipStats
+	HookDeleteStats HookDeleteStats
 }
 
 // FailedActuationSum returns the number of resources that failed actuation.
+// Lifecycle hooks that failed to be deleted after they completed are not
+// included, because the hooks themselves succeeded.
 func (s *Stats) FailedActuationSum() int {
-	return s.ApplyStats.Failed + s.PruneStats.Failed + s.DeleteStats.Failed
+	return s.ApplyStats.Failed + s.PruneStats.Failed + s.DeleteStats.Failed +
+		s.RollbackStats.Failed + s.OwnershipStats.Failed
 }
 
 // FailedReconciliationSum returns the number of resources that failed reconciliation.
@@ -40,6 +46,12 @@ func (s *Stats) Handle(e event.Event) {
 		s.DeleteStats.Inc(e.DeleteEvent.Status)
 	case event.WaitType:
 		s.WaitStats.Inc(e.WaitEvent.Status)
+	case event.RollbackType:
+		s.RollbackStats.Inc(e.RollbackEvent.Status)
+	case event.OwnershipType:
+		s.OwnershipStats.Inc(e.OwnershipEvent.Status)
+	case event.HookDeleteType:
+		s.HookDeleteStats.Inc(e.HookDeleteEvent.Status)
 	}
 }
 
@@ -47,6 +59,7 @@ type ApplyStats struct {
 	Successful int
 	Skipped    int
 	Failed     int
+	Unchanged  int
 }
 
 func (a *ApplyStats) Inc(op event.ApplyEventStatus) {
@@ -57,6 +70,8 @@ func (a *ApplyStats) Inc(op event.ApplyEventStatus) {
 		a.Skipped++
 	case event.ApplyFailed:
 		a.Failed++
+	case event.ApplyUnchanged:
+		a.Unchanged++
 	default:
 		panic(fmt.Errorf("invalid apply status %s", op.String()))
 	}
@@ -67,7 +82,7 @@ func (a *ApplyStats) IncFailed() {
 }
 
 func (a *ApplyStats) Sum() int {
-	return a.Successful + a.Skipped + a.Failed
+	return a.Successful + a.Skipped + a.Failed + a.Unchanged
 }
 
 type PruneStats struct {
@@ -133,7 +148,7 @@ type WaitStats struct {
 
 func (w *WaitStats) Inc(status event.WaitEventStatus) {
 	switch status {
-	case event.ReconcilePending:
+	case event.ReconcilePending, event.ReconcileStabilizing:
 		// ignore - should be replaced by one of the others before the WaitTask exits
 	case event.ReconcileSuccessful:
 		w.Successful++
@@ -151,3 +166,74 @@ func (w *WaitStats) Inc(status event.WaitEventStatus) {
 func (w *WaitStats) Sum() int {
 	return w.Successful + w.Skipped + w.Failed + w.Timeout
 }
+
+type RollbackStats struct {
+	Successful int
+	Skipped    int
+	Failed     int
+}
+
+func (r *RollbackStats) Inc(op event.RollbackEventStatus) {
+	switch op {
+	case event.RollbackPending:
+		// ignore - should be replaced by one of the others
+	case event.RollbackSuccessful:
+		r.Successful++
+	case event.RollbackSkipped:
+		r.Skipped++
+	case event.RollbackFailed:
+		r.Failed++
+	default:
+		panic(fmt.Errorf("invalid rollback status %s", op.String()))
+	}
+}
+
+func (r *RollbackStats) Sum() int {
+	return r.Successful + r.Skipped + r.Failed
+}
+
+type OwnershipStats struct {
+	Successful int
+	Skipped    int
+	Failed     int
+}
+
+func (o *OwnershipStats) Inc(op event.OwnershipEventStatus) {
+	switch op {
+	case event.OwnershipSuccessful:
+		o.Successful++
+	case event.OwnershipSkipped:
+		o.Skipped++
+	case event.OwnershipFailed:
+		o.Failed++
+	default:
+		panic(fmt.Errorf("invalid ownership status %s", op.String()))
+	}
+}
+
+func (o *OwnershipStats) Sum() int {
+	return o.Successful + o.Skipped + o.Failed
+}
+
+type HookDeleteStats struct {
+	Successful int
+	Skipped    int
+	Failed     int
+}
+
+func (h *HookDeleteStats) Inc(op event.HookDeleteEventStatus) {
+	switch op {
+	case event.HookDeleteSuccessful:
+		h.Successful++
+	case event.HookDeleteSkipped:
+		h.Skipped++
+	case event.HookDeleteFailed:
+		h.Failed++
+	default:
+		panic(fmt.Errorf("invalid hook delete status %s", op.String()))
+	}
+}
+
+func (h *HookDeleteStats) Sum() int {
+	return h.Successful + h.Skipped + h.Failed
+}
@@ -106,8 +106,67 @@ func (ef *formatter) FormatDeleteEvent(e event.DeleteEvent) error {
 func (ef *formatter) FormatWaitEvent(e event.WaitEvent) error {
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
-	ef.print("%s reconcile %s", resourceIDToString(gk, name),
-		strings.ToLower(e.Status.String()))
+	if e.Status == event.ReconcileStabilizing {
+		ef.print("%s reconcile %s (%s remaining)", resourceIDToString(gk, name),
+			strings.ToLower(e.Status.String()), e.Remaining)
+	} else {
+		ef.print("%s reconcile %s", resourceIDToString(gk, name),
+			strings.ToLower(e.Status.String()))
+	}
+	return nil
+}
+
+func (ef *formatter) FormatRollbackEvent(e event.RollbackEvent) error {
+	gk := e.Identifier.GroupKind
+	name := e.Identifier.Name
+	if e.Error != nil {
+		ef.print("%s rollback %s: %s", resourceIDToString(gk, name),
+			strings.ToLower(e.Status.String()), e.Error.Error())
+	} else {
+		ef.print("%s rollback %s", resourceIDToString(gk, name),
+			strings.ToLower(e.Status.String()))
+	}
+	return nil
+}
+
+func (ef *formatter) FormatOwnershipEvent(e event.OwnershipEvent) error {
+	gk := e.Identifier.GroupKind
+	name := e.Identifier.Name
+	if e.Error != nil {
+		ef.print("%s %s %s: %s", resourceIDToString(gk, name),
+			strings.ToLower(e.Operation.String()), strings.ToLower(e.Status.String()), e.Error.Error())
+	} else {
+		ef.print("%s %s %s", resourceIDToString(gk, name),
+			strings.ToLower(e.Operation.String()), strings.ToLower(e.Status.String()))
+	}
+	return nil
+}
+
+func (ef *formatter) FormatRetryEvent(e event.RetryEvent) error {
+	gk := e.Identifier.GroupKind
+	name := e.Identifier.Name
+	ef.print("%s %s failed (attempt %d/%d), retrying in %s: %s", resourceIDToString(gk, name),
+		strings.ToLower(e.Action.String()), e.Attempt, e.MaxAttempts, e.Delay, e.Error.Error())
+	return nil
+}
+
+func (ef *formatter) FormatHookDeleteEvent(e event.HookDeleteEvent) error {
+	gk := e.Identifier.GroupKind
+	name := e.Identifier.Name
+	if e.Error != nil {
+		ef.print("%s hook delete %s: %s", resourceIDToString(gk, name),
+			strings.ToLower(e.Status.String()), e.Error.Error())
+	} else {
+		ef.print("%s hook delete %s", resourceIDToString(gk, name),
+			strings.ToLower(e.Status.String()))
+	}
+	return nil
+}
+
+func (ef *formatter) FormatInferredDependency(id event.InferredDependency) error {
+	ef.print("%s depends on %s (inferred from %s)",
+		resourceIDToString(id.Object.GroupKind, id.Object.Name),
+		resourceIDToString(id.Dependency.GroupKind, id.Dependency.Name), id.Field)
 	return nil
 }
 
@@ -132,6 +191,10 @@ func (ef *formatter) FormatActionGroupEvent(
 		ef.print("reconcile phase %s", strings.ToLower(age.Status.String()))
 	case event.InventoryAction:
 		ef.print("inventory update %s", strings.ToLower(age.Status.String()))
+	case event.HookDeleteAction:
+		ef.print("hook delete phase %s", strings.ToLower(age.Status.String()))
+	case event.RollbackAction:
+		ef.print("rollback phase %s", strings.ToLower(age.Status.String()))
 	default:
 		return fmt.Errorf("invalid action group action: %+v", age)
 	}
@@ -141,8 +204,13 @@ func (ef *formatter) FormatActionGroupEvent(
 func (ef *formatter) FormatSummary(s stats.Stats) error {
 	if s.ApplyStats != (stats.ApplyStats{}) {
 		as := s.ApplyStats
-		ef.print("apply result: %d attempted, %d successful, %d skipped, %d failed",
-			as.Sum(), as.Successful, as.Skipped, as.Failed)
+		if as.Unchanged > 0 {
+			ef.print("apply result: %d attempted, %d successful, %d skipped, %d failed, %d unchanged",
+				as.Sum(), as.Successful, as.Skipped, as.Failed, as.Unchanged)
+		} else {
+			ef.print("apply result: %d attempted, %d successful, %d skipped, %d failed",
+				as.Sum(), as.Successful, as.Skipped, as.Failed)
+		}
 	}
 	if s.PruneStats != (stats.PruneStats{}) {
 		ps := s.PruneStats
@@ -159,6 +227,21 @@ func (ef *formatter) FormatSummary(s stats.Stats) error {
 		ef.print("reconcile result: %d attempted, %d successful, %d skipped, %d failed, %d timed out",
 			ws.Sum(), ws.Successful, ws.Skipped, ws.Failed, ws.Timeout)
 	}
+	if s.RollbackStats != (stats.RollbackStats{}) {
+		rs := s.RollbackStats
+		ef.print("rollback result: %d attempted, %d successful, %d skipped, %d failed",
+			rs.Sum(), rs.Successful, rs.Skipped, rs.Failed)
+	}
+	if s.OwnershipStats != (stats.OwnershipStats{}) {
+		ows := s.OwnershipStats
+		ef.print("ownership result: %d attempted, %d successful, %d skipped, %d failed",
+			ows.Sum(), ows.Successful, ows.Skipped, ows.Failed)
+	}
+	if s.HookDeleteStats != (stats.HookDeleteStats{}) {
+		hs := s.HookDeleteStats
+		ef.print("hook delete result: %d attempted, %d successful, %d skipped, %d failed",
+			hs.Sum(), hs.Successful, hs.Skipped, hs.Failed)
+	}
 	return nil
 }
 
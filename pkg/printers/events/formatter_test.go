@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -252,6 +253,50 @@ func TestFormatter_FormatDeleteEvent(t *testing.T) {
 	}
 }
 
+func TestFormatter_FormatHookDeleteEvent(t *testing.T) {
+	testCases := map[string]struct {
+		event    event.HookDeleteEvent
+		expected string
+	}{
+		"hook deleted": {
+			event: event.HookDeleteEvent{
+				Status:     event.HookDeleteSuccessful,
+				Identifier: createIdentifier("batch", "Job", "default", "migrate"),
+				Object:     createObject("batch", "Job", "default", "migrate"),
+			},
+			expected: "job.batch/migrate hook delete successful",
+		},
+		"hook delete skipped": {
+			event: event.HookDeleteEvent{
+				Status:     event.HookDeleteSkipped,
+				Identifier: createIdentifier("batch", "Job", "default", "migrate"),
+				Object:     createObject("batch", "Job", "default", "migrate"),
+				Error:      fmt.Errorf("hook did not complete successfully"),
+			},
+			expected: "job.batch/migrate hook delete skipped: hook did not complete successfully",
+		},
+		"hook delete failed": {
+			event: event.HookDeleteEvent{
+				Status:     event.HookDeleteFailed,
+				Identifier: createIdentifier("batch", "Job", "default", "migrate"),
+				Error:      fmt.Errorf("this is a test"),
+			},
+			expected: "job.batch/migrate hook delete failed: this is a test",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericiooptions.NewTestIOStreams()
+			formatter := NewFormatter(ioStreams, common.DryRunNone)
+			err := formatter.FormatHookDeleteEvent(tc.event)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expected, strings.TrimSpace(out.String()))
+		})
+	}
+}
+
 func TestFormatter_FormatWaitEvent(t *testing.T) {
 	testCases := map[string]struct {
 		previewStrategy common.DryRunStrategy
@@ -313,6 +358,16 @@ func TestFormatter_FormatWaitEvent(t *testing.T) {
 			},
 			expected: "deployment.apps/my-dep reconcile timeout",
 		},
+		"resource reconcile stabilizing": {
+			previewStrategy: common.DryRunNone,
+			event: event.WaitEvent{
+				GroupName:  "wait-1",
+				Status:     event.ReconcileStabilizing,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Remaining:  25 * time.Second,
+			},
+			expected: "deployment.apps/my-dep reconcile stabilizing (25s remaining)",
+		},
 		"resource reconcile skipped": {
 			previewStrategy: common.DryRunNone,
 			event: event.WaitEvent{
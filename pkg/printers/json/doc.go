@@ -60,11 +60,28 @@
 //   - kind (string) - The object's kind.
 //   - name (string) - The object's name.
 //   - namespace (string, optional) - The object's namespace.
-//   - status (string) - One of: "Pending", "Successful", "Skipped", "Failed", or
-//     "Timeout".
+//   - status (string) - One of: "Pending", "Successful", "Skipped", "Failed",
+//     "Timeout", or "Stabilizing".
 //   - timestamp (string) - ISO-8601 format
 //   - type (string) - "apply", "prune", "delete", or "wait"
 //   - error (string, optional) - A non-fatal error message specific to this object
+//   - remaining (string, optional) - Time left in the stability window of a
+//     "Stabilizing" wait event.
+//
+// Dependency events correspond to a dependency of an object on another object
+// that it references, inferred before any object is applied. The group,
+// kind, name, and namespace fields identify the dependent object.
+//
+// Dependency events have the following fields:
+//   - group (string, optional) - The object's API group.
+//   - kind (string) - The object's kind.
+//   - name (string) - The object's name.
+//   - namespace (string, optional) - The object's namespace.
+//   - dependency (object) - The identifier of the object it depends on, with
+//     the same group, kind, name, and namespace fields.
+//   - field (string) - The path of the field that references the dependency.
+//   - timestamp (string) - ISO-8601 format
+//   - type (string) - "dependency"
 //
 // Status types are asynchronous events that correspond to status updates for
 // a specific object.
@@ -92,9 +92,60 @@ func (jf *formatter) FormatDeleteEvent(e event.DeleteEvent) error {
 func (jf *formatter) FormatWaitEvent(e event.WaitEvent) error {
 	eventInfo := jf.baseResourceEvent(e.Identifier)
 	eventInfo["status"] = e.Status.String()
+	if e.Status == event.ReconcileStabilizing {
+		eventInfo["remaining"] = e.Remaining.String()
+	}
 	return jf.printEvent("wait", eventInfo)
 }
 
+func (jf *formatter) FormatRollbackEvent(e event.RollbackEvent) error {
+	eventInfo := jf.baseResourceEvent(e.Identifier)
+	if e.Error != nil {
+		eventInfo["error"] = e.Error.Error()
+	}
+	eventInfo["status"] = e.Status.String()
+	return jf.printEvent("rollback", eventInfo)
+}
+
+func (jf *formatter) FormatOwnershipEvent(e event.OwnershipEvent) error {
+	eventInfo := jf.baseResourceEvent(e.Identifier)
+	if e.Error != nil {
+		eventInfo["error"] = e.Error.Error()
+	}
+	eventInfo["operation"] = e.Operation.String()
+	eventInfo["status"] = e.Status.String()
+	if e.Owner != "" {
+		eventInfo["owner"] = e.Owner
+	}
+	return jf.printEvent("ownership", eventInfo)
+}
+
+func (jf *formatter) FormatRetryEvent(e event.RetryEvent) error {
+	eventInfo := jf.baseResourceEvent(e.Identifier)
+	eventInfo["action"] = e.Action.String()
+	eventInfo["attempt"] = e.Attempt
+	eventInfo["maxAttempts"] = e.MaxAttempts
+	eventInfo["delay"] = e.Delay.String()
+	eventInfo["error"] = e.Error.Error()
+	return jf.printEvent("retry", eventInfo)
+}
+
+func (jf *formatter) FormatHookDeleteEvent(e event.HookDeleteEvent) error {
+	eventInfo := jf.baseResourceEvent(e.Identifier)
+	if e.Error != nil {
+		eventInfo["error"] = e.Error.Error()
+	}
+	eventInfo["status"] = e.Status.String()
+	return jf.printEvent("hookDelete", eventInfo)
+}
+
+func (jf *formatter) FormatInferredDependency(id event.InferredDependency) error {
+	eventInfo := jf.baseResourceEvent(id.Object)
+	eventInfo["dependency"] = jf.baseResourceEvent(id.Dependency)
+	eventInfo["field"] = id.Field
+	return jf.printEvent("dependency", eventInfo)
+}
+
 func (jf *formatter) FormatErrorEvent(e event.ErrorEvent) error {
 	return jf.printEvent("error", map[string]any{
 		"error": e.Err.Error(),
@@ -120,6 +171,9 @@ func (jf *formatter) FormatActionGroupEvent(
 			content["successful"] = as.Successful
 			content["skipped"] = as.Skipped
 			content["failed"] = as.Failed
+			if as.Unchanged > 0 {
+				content["unchanged"] = as.Unchanged
+			}
 		}
 	case event.PruneAction:
 		if age.Status == event.Finished {
@@ -146,6 +200,22 @@ func (jf *formatter) FormatActionGroupEvent(
 			content["failed"] = ws.Failed
 			content["timeout"] = ws.Timeout
 		}
+	case event.HookDeleteAction:
+		if age.Status == event.Finished {
+			hs := s.HookDeleteStats
+			content["count"] = hs.Sum()
+			content["successful"] = hs.Successful
+			content["skipped"] = hs.Skipped
+			content["failed"] = hs.Failed
+		}
+	case event.RollbackAction:
+		if age.Status == event.Finished {
+			rs := s.RollbackStats
+			content["count"] = rs.Sum()
+			content["successful"] = rs.Successful
+			content["skipped"] = rs.Skipped
+			content["failed"] = rs.Failed
+		}
 	case event.InventoryAction:
 		// no extra content
 	default:
@@ -158,13 +228,17 @@ func (jf *formatter) FormatActionGroupEvent(
 func (jf *formatter) FormatSummary(s stats.Stats) error {
 	if s.ApplyStats != (stats.ApplyStats{}) {
 		as := s.ApplyStats
-		err := jf.printEvent("summary", map[string]any{
+		content := map[string]any{
 			"action":     event.ApplyAction.String(),
 			"count":      as.Sum(),
 			"successful": as.Successful,
 			"skipped":    as.Skipped,
 			"failed":     as.Failed,
-		})
+		}
+		if as.Unchanged > 0 {
+			content["unchanged"] = as.Unchanged
+		}
+		err := jf.printEvent("summary", content)
 		if err != nil {
 			return err
 		}
@@ -209,6 +283,45 @@ func (jf *formatter) FormatSummary(s stats.Stats) error {
 			return err
 		}
 	}
+	if s.RollbackStats != (stats.RollbackStats{}) {
+		rs := s.RollbackStats
+		err := jf.printEvent("summary", map[string]any{
+			"action":     event.RollbackAction.String(),
+			"count":      rs.Sum(),
+			"successful": rs.Successful,
+			"skipped":    rs.Skipped,
+			"failed":     rs.Failed,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if s.OwnershipStats != (stats.OwnershipStats{}) {
+		ows := s.OwnershipStats
+		err := jf.printEvent("summary", map[string]any{
+			"action":     "Ownership",
+			"count":      ows.Sum(),
+			"successful": ows.Successful,
+			"skipped":    ows.Skipped,
+			"failed":     ows.Failed,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if s.HookDeleteStats != (stats.HookDeleteStats{}) {
+		hs := s.HookDeleteStats
+		err := jf.printEvent("summary", map[string]any{
+			"action":     event.HookDeleteAction.String(),
+			"count":      hs.Sum(),
+			"successful": hs.Successful,
+			"skipped":    hs.Skipped,
+			"failed":     hs.Failed,
+		})
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -862,6 +862,37 @@ func TestFormatter_FormatSummary(t *testing.T) {
 				},
 			},
 		},
+		"prune hook delete": {
+			statsCollector: stats.Stats{
+				PruneStats: stats.PruneStats{
+					Successful: 1,
+				},
+				HookDeleteStats: stats.HookDeleteStats{
+					Successful: 2,
+					Skipped:    1,
+				},
+			},
+			expected: []map[string]any{
+				{
+					"action":     "Prune",
+					"count":      float64(1),
+					"successful": float64(1),
+					"skipped":    float64(0),
+					"failed":     float64(0),
+					"timestamp":  nowStr,
+					"type":       "summary",
+				},
+				{
+					"action":     "HookDelete",
+					"count":      float64(3),
+					"successful": float64(2),
+					"skipped":    float64(1),
+					"failed":     float64(0),
+					"timestamp":  nowStr,
+					"type":       "summary",
+				},
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -25,8 +25,9 @@ func newResourceStateCollector(resourceGroups []event.ActionGroup) *resourceStat
 	for _, group := range resourceGroups {
 		action := group.Action
 		// Keep the action that describes the operation for the resource
-		// rather than that we will wait for it.
-		if action == event.WaitAction {
+		// rather than that we will wait for it, delete it after it
+		// completed, for lifecycle hooks, or roll it back.
+		if action == event.WaitAction || action == event.HookDeleteAction || action == event.RollbackAction {
 			continue
 		}
 		for _, identifier := range group.Identifiers {
@@ -200,6 +201,12 @@ func (r *resourceStateCollector) processEvent(ev event.Event) error {
 		r.processDeleteEvent(ev.DeleteEvent)
 	case event.WaitType:
 		r.processWaitEvent(ev.WaitEvent)
+	case event.RollbackType:
+		r.processRollbackEvent(ev.RollbackEvent)
+	case event.OwnershipType:
+		r.processOwnershipEvent(ev.OwnershipEvent)
+	case event.HookDeleteType:
+		r.processHookDeleteEvent(ev.HookDeleteEvent)
 	case event.ErrorType:
 		return ev.ErrorEvent.Err
 	}
@@ -307,6 +314,52 @@ func (r *resourceStateCollector) processWaitEvent(e event.WaitEvent) {
 	r.stats.WaitStats.Inc(e.Status)
 }
 
+// processRollbackEvent handles events related to rollback operations.
+func (r *resourceStateCollector) processRollbackEvent(e event.RollbackEvent) {
+	identifier := e.Identifier
+	klog.V(7).Infof("processing rollback event for %s", identifier)
+	previous, found := r.resourceInfos[identifier]
+	if !found {
+		klog.V(4).Infof("%s rollback event not found in ResourceInfos; no processing", identifier)
+		return
+	}
+	if e.Error != nil {
+		previous.Error = e.Error
+	}
+	r.stats.RollbackStats.Inc(e.Status)
+}
+
+// processOwnershipEvent handles events related to adopt and abandon
+// operations.
+func (r *resourceStateCollector) processOwnershipEvent(e event.OwnershipEvent) {
+	identifier := e.Identifier
+	klog.V(7).Infof("processing ownership event for %s", identifier)
+	r.stats.OwnershipStats.Inc(e.Status)
+	previous, found := r.resourceInfos[identifier]
+	if !found {
+		klog.V(4).Infof("%s ownership event not found in ResourceInfos; no processing", identifier)
+		return
+	}
+	if e.Error != nil {
+		previous.Error = e.Error
+	}
+}
+
+// processHookDeleteEvent handles events related to deleting lifecycle hooks.
+func (r *resourceStateCollector) processHookDeleteEvent(e event.HookDeleteEvent) {
+	identifier := e.Identifier
+	klog.V(7).Infof("processing hook delete event for %s", identifier)
+	previous, found := r.resourceInfos[identifier]
+	if !found {
+		klog.V(4).Infof("%s hook delete event not found in ResourceInfos; no processing", identifier)
+		return
+	}
+	if e.Error != nil {
+		previous.Error = e.Error
+	}
+	r.stats.HookDeleteStats.Inc(e.Status)
+}
+
 // ResourceState contains the latest state for all the resources.
 type ResourceState struct {
 	resourceInfos ResourceInfos
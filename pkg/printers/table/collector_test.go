@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/graph"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
 )
 
 var (
@@ -96,6 +97,33 @@ func TestResourceStateCollector_New(t *testing.T) {
 				},
 			},
 		},
+		"hook resources": {
+			resourceGroups: []event.ActionGroup{
+				{
+					Action: event.ApplyAction,
+					Identifiers: object.ObjMetadataSet{
+						customID,
+					},
+				},
+				{
+					Action: event.WaitAction,
+					Identifiers: object.ObjMetadataSet{
+						customID,
+					},
+				},
+				{
+					Action: event.HookDeleteAction,
+					Identifiers: object.ObjMetadataSet{
+						customID,
+					},
+				},
+			},
+			resourceInfos: map[object.ObjMetadata]*resourceInfo{
+				customID: {
+					ResourceAction: event.ApplyAction,
+				},
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -269,6 +297,56 @@ func TestResourceStateCollector_ProcessValidationEvent(t *testing.T) {
 	}
 }
 
+func TestResourceStateCollector_ProcessHookDeleteEvent(t *testing.T) {
+	testCases := map[string]struct {
+		event         event.HookDeleteEvent
+		expectedStats stats.HookDeleteStats
+		expectedError error
+	}{
+		"hook deleted": {
+			event: event.HookDeleteEvent{
+				Identifier: customID,
+				Status:     event.HookDeleteSuccessful,
+			},
+			expectedStats: stats.HookDeleteStats{Successful: 1},
+		},
+		"hook delete failed": {
+			event: event.HookDeleteEvent{
+				Identifier: customID,
+				Status:     event.HookDeleteFailed,
+				Error:      errors.New("unexpected"),
+			},
+			expectedStats: stats.HookDeleteStats{Failed: 1},
+			expectedError: errors.New("unexpected"),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			rsc := newResourceStateCollector([]event.ActionGroup{
+				{
+					Action:      event.ApplyAction,
+					Identifiers: object.ObjMetadataSet{customID},
+				},
+				{
+					Action:      event.HookDeleteAction,
+					Identifiers: object.ObjMetadataSet{customID},
+				},
+			})
+			err := rsc.processEvent(event.Event{
+				Type:            event.HookDeleteType,
+				HookDeleteEvent: tc.event,
+			})
+			assert.NoError(t, err)
+
+			// Hook deletions are not counted as pruned resources
+			assert.Equal(t, stats.PruneStats{}, rsc.stats.PruneStats)
+			assert.Equal(t, tc.expectedStats, rsc.stats.HookDeleteStats)
+			assert.Equal(t, tc.expectedError, rsc.resourceInfos[customID].Error)
+		})
+	}
+}
+
 func getID(e event.StatusEvent) (object.ObjMetadata, bool) {
 	if e.Resource == nil {
 		return object.ObjMetadata{}, false
@@ -26,6 +26,10 @@ type ExpEvent struct {
 	DeleteEvent      *ExpDeleteEvent
 	WaitEvent        *ExpWaitEvent
 	ValidationEvent  *ExpValidationEvent
+	RollbackEvent    *ExpRollbackEvent
+	OwnershipEvent   *ExpOwnershipEvent
+	RetryEvent       *ExpRetryEvent
+	HookDeleteEvent  *ExpHookDeleteEvent
 }
 
 type ExpInitEvent struct {
@@ -81,6 +85,35 @@ type ExpValidationEvent struct {
 	Error       error
 }
 
+type ExpRollbackEvent struct {
+	Status     event.RollbackEventStatus
+	Identifier object.ObjMetadata
+	Error      error
+}
+
+type ExpOwnershipEvent struct {
+	Operation  event.OwnershipOperation
+	Status     event.OwnershipEventStatus
+	Identifier object.ObjMetadata
+	Owner      string
+	Error      error
+}
+
+type ExpRetryEvent struct {
+	GroupName  string
+	Action     event.ResourceAction
+	Identifier object.ObjMetadata
+	Attempt    int
+	Error      error
+}
+
+type ExpHookDeleteEvent struct {
+	GroupName  string
+	Status     event.HookDeleteEventStatus
+	Identifier object.ObjMetadata
+	Error      error
+}
+
 func VerifyEvents(expEvents []ExpEvent, events []event.Event) error {
 	if len(expEvents) == 0 && len(events) == 0 {
 		return nil
@@ -295,6 +328,106 @@ func isMatch(ee ExpEvent, e event.Event) bool {
 		}
 		return ve.Error == nil
 
+	case event.RollbackType:
+		ree := ee.RollbackEvent
+		if ree == nil {
+			return true
+		}
+		re := e.RollbackEvent
+
+		if ree.Identifier != object.NilObjMetadata {
+			if ree.Identifier != re.Identifier {
+				return false
+			}
+		}
+
+		if ree.Status != re.Status {
+			return false
+		}
+
+		if ree.Error != nil {
+			return re.Error != nil
+		}
+		return re.Error == nil
+
+	case event.HookDeleteType:
+		hee := ee.HookDeleteEvent
+		if hee == nil {
+			return true
+		}
+		he := e.HookDeleteEvent
+
+		if hee.Identifier != object.NilObjMetadata {
+			if hee.Identifier != he.Identifier {
+				return false
+			}
+		}
+
+		if hee.GroupName != "" {
+			if hee.GroupName != he.GroupName {
+				return false
+			}
+		}
+
+		if hee.Status != he.Status {
+			return false
+		}
+
+		if hee.Error != nil {
+			return he.Error != nil
+		}
+		return he.Error == nil
+
+	case event.OwnershipType:
+		oee := ee.OwnershipEvent
+		if oee == nil {
+			return true
+		}
+		oe := e.OwnershipEvent
+
+		if oee.Identifier != object.NilObjMetadata {
+			if oee.Identifier != oe.Identifier {
+				return false
+			}
+		}
+
+		if oee.Operation != oe.Operation || oee.Status != oe.Status || oee.Owner != oe.Owner {
+			return false
+		}
+
+		if oee.Error != nil {
+			return oe.Error != nil
+		}
+		return oe.Error == nil
+
+	case event.RetryType:
+		ree := ee.RetryEvent
+		if ree == nil {
+			return true
+		}
+		re := e.RetryEvent
+
+		if ree.Identifier != object.NilObjMetadata {
+			if ree.Identifier != re.Identifier {
+				return false
+			}
+		}
+
+		if ree.GroupName != "" {
+			if ree.GroupName != re.GroupName {
+				return false
+			}
+		}
+
+		if ree.Action != re.Action || ree.Attempt != re.Attempt {
+			return false
+		}
+
+		if ree.Error != nil {
+			return re.Error != nil
+		}
+		return re.Error == nil
+
 	default:
 		return true
 	}
@@ -398,6 +531,51 @@ func EventToExpEvent(e event.Event) ExpEvent {
 				Error:       e.ValidationEvent.Error,
 			},
 		}
+
+	case event.RollbackType:
+		return ExpEvent{
+			EventType: event.RollbackType,
+			RollbackEvent: &ExpRollbackEvent{
+				Identifier: e.RollbackEvent.Identifier,
+				Status:     e.RollbackEvent.Status,
+				Error:      e.RollbackEvent.Error,
+			},
+		}
+
+	case event.OwnershipType:
+		return ExpEvent{
+			EventType: event.OwnershipType,
+			OwnershipEvent: &ExpOwnershipEvent{
+				Identifier: e.OwnershipEvent.Identifier,
+				Operation:  e.OwnershipEvent.Operation,
+				Status:     e.OwnershipEvent.Status,
+				Owner:      e.OwnershipEvent.Owner,
+				Error:      e.OwnershipEvent.Error,
+			},
+		}
+
+	case event.RetryType:
+		return ExpEvent{
+			EventType: event.RetryType,
+			RetryEvent: &ExpRetryEvent{
+				GroupName:  e.RetryEvent.GroupName,
+				Action:     e.RetryEvent.Action,
+				Identifier: e.RetryEvent.Identifier,
+				Attempt:    e.RetryEvent.Attempt,
+				Error:      e.RetryEvent.Error,
+			},
+		}
+
+	case event.HookDeleteType:
+		return ExpEvent{
+			EventType: event.HookDeleteType,
+			HookDeleteEvent: &ExpHookDeleteEvent{
+				GroupName:  e.HookDeleteEvent.GroupName,
+				Identifier: e.HookDeleteEvent.Identifier,
+				Status:     e.HookDeleteEvent.Status,
+				Error:      e.HookDeleteEvent.Error,
+			},
+		}
 	}
 	return ExpEvent{}
 }
@@ -475,11 +653,12 @@ func (ape GroupedEventsByID) Less(i, j int) bool {
 }
 
 var waitStatusWeight = map[event.WaitEventStatus]int{
-	event.ReconcilePending:    0,
-	event.ReconcileSkipped:    1,
-	event.ReconcileSuccessful: 2,
-	event.ReconcileFailed:     3,
-	event.ReconcileTimeout:    4,
+	event.ReconcilePending:     0,
+	event.ReconcileStabilizing: 0,
+	event.ReconcileSkipped:     1,
+	event.ReconcileSuccessful:  2,
+	event.ReconcileFailed:      3,
+	event.ReconcileTimeout:     4,
 }
 
 func lessWaitStatus(x, y event.WaitEventStatus) bool {
@@ -6,6 +6,7 @@
 package testutil
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,8 @@ import (
 	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/dependson"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
+	"sigs.k8s.io/cli-utils/pkg/object/wave"
 )
 
 // OwningInventoryKey is the annotation key indicating the inventory owning an object.
@@ -160,3 +163,78 @@ func (d dependsOnMutator) Mutate(u *unstructured.Unstructured) {
 		d.t.FailNow()
 	}
 }
+
+// AddDependsOnConditions returns a testutil.Mutator which adds the passed
+// conditions as a depends-on-conditions annotation to the object which is
+// mutated.
+func AddDependsOnConditions(t *testing.T, conds map[object.ObjMetadata]dependson.Condition) Mutator {
+	return dependsOnConditionsMutator{
+		t:     t,
+		conds: conds,
+	}
+}
+
+// dependsOnConditionsMutator encapsulates fields for adding
+// depends-on-conditions annotation to a test object. Implements the Mutator
+// interface.
+type dependsOnConditionsMutator struct {
+	t     *testing.T
+	conds map[object.ObjMetadata]dependson.Condition
+}
+
+// Mutate writes a depends-on-conditions annotation on the supplied object.
+func (d dependsOnConditionsMutator) Mutate(u *unstructured.Unstructured) {
+	err := dependson.WriteConditions(u, d.conds)
+	if !assert.NoError(d.t, err) {
+		d.t.FailNow()
+	}
+}
+
+// AddApplyWave returns a testutil.Mutator which adds an apply-wave
+// annotation with the passed wave to the object which is mutated.
+func AddApplyWave(wave int) Mutator {
+	return applyWaveMutator{wave: wave}
+}
+
+// applyWaveMutator encapsulates fields for adding apply-wave annotation
+// to a test object. Implements the Mutator interface.
+type applyWaveMutator struct {
+	wave int
+}
+
+// Mutate writes an apply-wave annotation on the supplied object.
+func (a applyWaveMutator) Mutate(u *unstructured.Unstructured) {
+	annos := u.GetAnnotations()
+	if annos == nil {
+		annos = make(map[string]string)
+	}
+	annos[wave.Annotation] = strconv.Itoa(a.wave)
+	u.SetAnnotations(annos)
+}
+
+// AddHook returns a testutil.Mutator which adds the hook annotation with the
+// passed type, and the hook-delete-policy annotation with the passed policy
+// if not empty, to the object which is mutated.
+func AddHook(hookType hook.Type, policy hook.DeletePolicy) Mutator {
+	return hookMutator{hookType: hookType, policy: policy}
+}
+
+// hookMutator encapsulates fields for adding hook annotations to a test
+// object. Implements the Mutator interface.
+type hookMutator struct {
+	hookType hook.Type
+	policy   hook.DeletePolicy
+}
+
+// Mutate writes the hook annotations on the supplied object.
+func (h hookMutator) Mutate(u *unstructured.Unstructured) {
+	annos := u.GetAnnotations()
+	if annos == nil {
+		annos = make(map[string]string)
+	}
+	annos[hook.Annotation] = string(h.hookType)
+	if h.policy != "" {
+		annos[hook.DeletePolicyAnnotation] = string(h.policy)
+	}
+	u.SetAnnotations(annos)
+}
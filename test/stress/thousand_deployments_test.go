@@ -71,6 +71,8 @@ func thousandDeploymentsTest(ctx context.Context, c client.Client, invConfig inv
 		},
 		ReconcileTimeout: 30 * time.Minute,
 		EmitStatusEvents: false,
+		// Apply the deployments in each phase concurrently
+		ApplyConcurrency: 10,
 	}))
 
 	duration := time.Since(start)